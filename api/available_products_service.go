@@ -48,14 +48,23 @@ func (a Api) UploadAvailableProduct(input UploadAvailableProductInput) (UploadAv
 
 	req = req.WithContext(context.WithValue(req.Context(), "polling-interval", time.Duration(input.PollingInterval)*time.Second))
 
-	resp, err := a.progressClient.Do(req)
-	if err != nil {
-		return UploadAvailableProductOutput{}, fmt.Errorf("could not make api request to available_products endpoint: %s", err)
+	resp, requestErr := a.progressClient.Do(req)
+	if requestErr != nil {
+		err = fmt.Errorf("could not make api request to available_products endpoint: %s", requestErr)
+		if isTemporaryRequestError(requestErr) {
+			return UploadAvailableProductOutput{}, temporaryError{err}
+		}
+
+		return UploadAvailableProductOutput{}, err
 	}
 
 	defer resp.Body.Close()
 
 	if err = validateStatusOK(resp); err != nil {
+		if isTemporaryStatus(resp.StatusCode) {
+			return UploadAvailableProductOutput{}, temporaryError{err}
+		}
+
 		return UploadAvailableProductOutput{}, err
 	}
 
@@ -2,18 +2,30 @@ package api_test
 
 import (
 	"errors"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/api/fakes"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
 var _ = Describe("Available Products", func() {
 	var (
 		progressClient *fakes.HttpClient
@@ -71,9 +83,47 @@ var _ = Describe("Available Products", func() {
 						PollingInterval: 1,
 					})
 					Expect(err).To(MatchError("could not make api request to available_products endpoint: some client error"))
+					Expect(api.IsTemporary(err)).To(BeFalse())
+				})
+			})
+
+			Context("when the client cannot authenticate", func() {
+				It("returns an error that is not temporary", func() {
+					progressClient.DoReturns(nil, &url.Error{
+						Op:  "Post",
+						URL: "https://example.com/api/v0/available_products",
+						Err: errors.New("oauth2: cannot fetch token: 401 Unauthorized"),
+					})
+
+					_, err := service.UploadAvailableProduct(api.UploadAvailableProductInput{
+						PollingInterval: 1,
+					})
+					Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+					Expect(api.IsTemporary(err)).To(BeFalse())
 				})
 			})
 
+			Context("when the request fails at the network level", func() {
+				DescribeTable("returns a temporary error",
+					func(requestErr error) {
+						progressClient.DoReturns(nil, &url.Error{
+							Op:  "Post",
+							URL: "https://example.com/api/v0/available_products",
+							Err: requestErr,
+						})
+
+						_, err := service.UploadAvailableProduct(api.UploadAvailableProductInput{
+							PollingInterval: 1,
+						})
+						Expect(err).To(MatchError(ContainSubstring("could not make api request to available_products endpoint")))
+						Expect(api.IsTemporary(err)).To(BeTrue())
+					},
+					Entry("when the connection is reset", &net.OpError{Op: "read", Net: "tcp", Err: &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET}}),
+					Entry("when the response ends unexpectedly", io.ErrUnexpectedEOF),
+					Entry("when the request times out", timeoutError{}),
+				)
+			})
+
 			Context("when the api returns a non-200 status code", func() {
 				It("returns an error", func() {
 					progressClient.DoReturns(&http.Response{
@@ -85,6 +135,22 @@ var _ = Describe("Available Products", func() {
 						PollingInterval: 1,
 					})
 					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+					Expect(api.IsTemporary(err)).To(BeFalse())
+				})
+			})
+
+			Context("when the api is temporarily unavailable", func() {
+				It("returns a temporary error", func() {
+					progressClient.DoReturns(&http.Response{
+						StatusCode: http.StatusBadGateway,
+						Body:       ioutil.NopCloser(strings.NewReader("{}")),
+					}, nil)
+
+					_, err := service.UploadAvailableProduct(api.UploadAvailableProductInput{
+						PollingInterval: 1,
+					})
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+					Expect(api.IsTemporary(err)).To(BeTrue())
 				})
 			})
 		})
@@ -98,6 +98,101 @@ func (a Api) UpdateStagedDirectorProperties(input DirectorProperties) error {
 	return err
 }
 
+// GetStagedDirectorManifest returns the manifest the director will be
+// deployed with on the next apply-changes.
+func (a Api) GetStagedDirectorManifest() (string, error) {
+	resp, err := a.sendAPIRequest("GET", "/api/v0/staged/director/manifest", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var contents struct {
+		Manifest interface{} `json:"manifest"`
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err // un-tested
+	}
+
+	if err = yaml.Unmarshal(body, &contents); err != nil {
+		return "", fmt.Errorf("could not parse json: %s", err)
+	}
+
+	manifest, err := yaml.Marshal(contents.Manifest)
+	if err != nil {
+		return "", err // this should never happen, all valid json can be marshalled
+	}
+
+	return string(manifest), nil
+}
+
+// GetDeployedDirectorManifest returns the manifest the director was last
+// deployed with.
+func (a Api) GetDeployedDirectorManifest() (string, error) {
+	resp, err := a.sendAPIRequest("GET", "/api/v0/deployed/director/manifest", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var contents interface{}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err // un-tested
+	}
+
+	if err = yaml.Unmarshal(body, &contents); err != nil {
+		return "", fmt.Errorf("could not parse json: %s", err)
+	}
+
+	manifest, err := yaml.Marshal(contents)
+	if err != nil {
+		return "", err // this should never happen, all valid json can be marshalled
+	}
+
+	return string(manifest), nil
+}
+
+// GetStagedDirectorProperties returns the staged iaas, director, security
+// and syslog configuration of the director.
+func (a Api) GetStagedDirectorProperties() (map[string]interface{}, error) {
+	return a.getStagedDirectorConfiguration("/api/v0/staged/director/properties")
+}
+
+// GetStagedDirectorAvailabilityZones returns the staged availability zones of
+// the director.
+func (a Api) GetStagedDirectorAvailabilityZones() (map[string]interface{}, error) {
+	return a.getStagedDirectorConfiguration("/api/v0/staged/director/availability_zones")
+}
+
+// GetStagedDirectorNetworks returns the staged networks of the director.
+func (a Api) GetStagedDirectorNetworks() (map[string]interface{}, error) {
+	return a.getStagedDirectorConfiguration("/api/v0/staged/director/networks")
+}
+
+// GetStagedDirectorNetworkAndAZ returns the staged network and singleton
+// availability zone of the director.
+func (a Api) GetStagedDirectorNetworkAndAZ() (map[string]interface{}, error) {
+	return a.getStagedDirectorConfiguration("/api/v0/staged/director/network_and_az")
+}
+
+func (a Api) getStagedDirectorConfiguration(endpoint string) (map[string]interface{}, error) {
+	resp, err := a.sendAPIRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var configuration map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse json: %s", err)
+	}
+
+	return configuration, nil
+}
+
 func (a Api) addGUIDToExistingAZs(azs AvailabilityZones) (AvailabilityZones, error) {
 	existingAzsResponse, err := a.sendAPIRequest("GET", "/api/v0/staged/director/availability_zones", nil)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/api/fakes"
@@ -438,4 +439,133 @@ var _ = Describe("Director", func() {
 			})
 		})
 	})
+
+	Describe("GetStagedDirectorManifest", func() {
+		It("returns the staged director manifest", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"manifest": {"name": "p-bosh", "instance_groups": [{"name": "bosh", "instances": 1}]}}`))}, nil)
+
+			manifest, err := service.GetStagedDirectorManifest()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manifest).To(MatchYAML(`---
+name: p-bosh
+instance_groups:
+- name: bosh
+  instances: 1
+`))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/manifest"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the api request fails", func() {
+				client.DoReturns(nil, errors.New("nope"))
+
+				_, err := service.GetStagedDirectorManifest()
+				Expect(err).To(MatchError("could not send api request to GET /api/v0/staged/director/manifest: nope"))
+			})
+
+			It("returns an error when the server returns a non-200 status code", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusTeapot,
+					Body:       ioutil.NopCloser(strings.NewReader(""))}, nil)
+
+				_, err := service.GetStagedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+			})
+
+			It("returns an error when the response is invalid", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("%%%"))}, nil)
+
+				_, err := service.GetStagedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+			})
+		})
+	})
+
+	Describe("GetDeployedDirectorManifest", func() {
+		It("returns the deployed director manifest", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"name": "p-bosh", "instance_groups": [{"name": "bosh", "instances": 1}]}`))}, nil)
+
+			manifest, err := service.GetDeployedDirectorManifest()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manifest).To(MatchYAML(`---
+name: p-bosh
+instance_groups:
+- name: bosh
+  instances: 1
+`))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/deployed/director/manifest"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the api request fails", func() {
+				client.DoReturns(nil, errors.New("nope"))
+
+				_, err := service.GetDeployedDirectorManifest()
+				Expect(err).To(MatchError("could not send api request to GET /api/v0/deployed/director/manifest: nope"))
+			})
+
+			It("returns an error when the response is invalid", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("%%%"))}, nil)
+
+				_, err := service.GetDeployedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+			})
+		})
+	})
+
+	Describe("staged director configuration", func() {
+		DescribeTable("returns the staged configuration",
+			func(get func(api.Api) (map[string]interface{}, error), endpoint string) {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"some-key": {"some-property": "some-value"}}`))}, nil)
+
+				configuration, err := get(service)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configuration).To(Equal(map[string]interface{}{
+					"some-key": map[string]interface{}{"some-property": "some-value"},
+				}))
+
+				req := client.DoArgsForCall(0)
+				Expect(req.Method).To(Equal("GET"))
+				Expect(req.URL.Path).To(Equal(endpoint))
+			},
+			Entry("properties", api.Api.GetStagedDirectorProperties, "/api/v0/staged/director/properties"),
+			Entry("availability zones", api.Api.GetStagedDirectorAvailabilityZones, "/api/v0/staged/director/availability_zones"),
+			Entry("networks", api.Api.GetStagedDirectorNetworks, "/api/v0/staged/director/networks"),
+			Entry("network and az", api.Api.GetStagedDirectorNetworkAndAZ, "/api/v0/staged/director/network_and_az"),
+		)
+
+		Context("failure cases", func() {
+			It("returns an error when the api request fails", func() {
+				client.DoReturns(nil, errors.New("nope"))
+
+				_, err := service.GetStagedDirectorProperties()
+				Expect(err).To(MatchError("could not send api request to GET /api/v0/staged/director/properties: nope"))
+			})
+
+			It("returns an error when the response is invalid", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("%%%"))}, nil)
+
+				_, err := service.GetStagedDirectorNetworks()
+				Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+			})
+		})
+	})
 })
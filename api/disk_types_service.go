@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type DiskType struct {
+	Name    string `json:"name"`
+	SizeMB  int    `json:"size_mb"`
+	BuiltIn bool   `json:"builtin"`
+}
+
+func (a Api) ListDiskTypes() ([]DiskType, error) {
+	req, err := http.NewRequest("GET", "/api/v0/disk_types", nil)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to disk_types endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	var diskTypesResponse struct {
+		DiskTypes []DiskType `json:"disk_types"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&diskTypesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal disk_types response: %s", err)
+	}
+
+	return diskTypesResponse.DiskTypes, nil
+}
@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+)
+
+var _ = Describe("DiskTypes", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+		service = api.New(api.ApiInput{
+			Client: client,
+		})
+	})
+
+	Describe("ListDiskTypes", func() {
+		BeforeEach(func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"disk_types": [
+						{"name": "1024", "size_mb": 1024, "builtin": true},
+						{"name": "20480", "size_mb": 20480, "builtin": true}
+					]
+				}`)),
+			}, nil)
+		})
+
+		It("lists the disk types", func() {
+			diskTypes, err := service.ListDiskTypes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskTypes).To(Equal([]api.DiskType{
+				{Name: "1024", SizeMB: 1024, BuiltIn: true},
+				{Name: "20480", SizeMB: 20480, BuiltIn: true},
+			}))
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/disk_types"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(&http.Response{}, errors.New("api endpoint failed"))
+
+				_, err := service.ListDiskTypes()
+				Expect(err).To(MatchError("could not make api request to disk_types endpoint: api endpoint failed"))
+			})
+
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				_, err := service.ListDiskTypes()
+				Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+			})
+
+			It("returns an error when the response is not valid json", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`))}, nil)
+
+				_, err := service.ListDiskTypes()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal disk_types response")))
+			})
+		})
+	})
+})
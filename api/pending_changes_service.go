@@ -14,9 +14,22 @@ type PendingChangesOutput struct {
 }
 
 type ProductChange struct {
-	Product string   `json:"guid"`
-	Errands []Errand `json:"errands"`
-	Action  string   `json:"action"`
+	Product  string              `json:"guid"`
+	Errands  []Errand            `json:"errands"`
+	Action   string              `json:"action"`
+	Staged   *ProductChangeState `json:"staged,omitempty"`
+	Deployed *ProductChangeState `json:"deployed,omitempty"`
+}
+
+type ProductChangeState struct {
+	DeploymentName string                  `json:"deployment_name"`
+	ProductVersion string                  `json:"product_version"`
+	Stemcells      []ProductChangeStemcell `json:"stemcells"`
+}
+
+type ProductChangeStemcell struct {
+	StemcellName    string `json:"stemcell_name"`
+	StemcellVersion string `json:"stemcell_version"`
 }
 
 func (a Api) ListStagedPendingChanges() (PendingChangesOutput, error) {
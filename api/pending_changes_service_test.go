@@ -77,6 +77,42 @@ var _ = Describe("PendingChangesService", func() {
 			Expect(path).To(Equal("/api/v0/staged/pending_changes"))
 		})
 
+		It("includes the staged and deployed versions of each product", func() {
+			client.DoReturns(&http.Response{StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"product_changes": [{
+						"guid":"product-123",
+						"errands":[],
+						"action":"update",
+						"staged": {
+							"deployment_name":"product-123",
+							"product_version":"1.1.0",
+							"stemcells":[{"stemcell_name":"ubuntu-xenial","stemcell_version":"97.18"}]
+						},
+						"deployed": {
+							"deployment_name":"product-123",
+							"product_version":"1.0.0",
+							"stemcells":[{"stemcell_name":"ubuntu-xenial","stemcell_version":"97.17"}]
+						}
+					}]
+				}`)),
+			}, nil)
+
+			output, err := service.ListStagedPendingChanges()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output.ChangeList[0].Staged).To(Equal(&api.ProductChangeState{
+				DeploymentName: "product-123",
+				ProductVersion: "1.1.0",
+				Stemcells:      []api.ProductChangeStemcell{{StemcellName: "ubuntu-xenial", StemcellVersion: "97.18"}},
+			}))
+			Expect(output.ChangeList[0].Deployed).To(Equal(&api.ProductChangeState{
+				DeploymentName: "product-123",
+				ProductVersion: "1.0.0",
+				Stemcells:      []api.ProductChangeStemcell{{StemcellName: "ubuntu-xenial", StemcellVersion: "97.17"}},
+			}))
+		})
+
 		Describe("errors", func() {
 			Context("the client can't connect to the server", func() {
 				It("returns an error", func() {
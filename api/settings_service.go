@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const settingsEndpoint = "/api/v0/settings"
+
+// GetOpsManagerSetting returns the body of GET /api/v0/settings/<setting>,
+// for example "smtp" or "ssl_certificate".
+func (a Api) GetOpsManagerSetting(setting string) (map[string]interface{}, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", settingsEndpoint, setting), nil)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to %s settings endpoint: %s", setting, err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	var output map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&output)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal %s settings response: %s", setting, err)
+	}
+
+	return output, nil
+}
+
+// UpdateOpsManagerSetting replaces the setting with a PUT of the given body
+// to /api/v0/settings/<setting>.
+func (a Api) UpdateOpsManagerSetting(setting string, body map[string]interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("could not marshal %s settings: %s", setting, err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s", settingsEndpoint, setting)
+	resp, err := a.sendAPIRequest("PUT", endpoint, payload)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	return err
+}
@@ -0,0 +1,122 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SettingsService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+
+		service = api.New(api.ApiInput{
+			Client: client,
+		})
+	})
+
+	Describe("GetOpsManagerSetting", func() {
+		It("returns the setting", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"syslog": {"enabled": true, "address": "logs.example.com"}}`)),
+			}, nil)
+
+			setting, err := service.GetOpsManagerSetting("syslog")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setting).To(Equal(map[string]interface{}{
+				"syslog": map[string]interface{}{
+					"enabled": true,
+					"address": "logs.example.com",
+				},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/settings/syslog"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				_, err := service.GetOpsManagerSetting("syslog")
+				Expect(err).To(MatchError("could not make api request to syslog settings endpoint: some error"))
+			})
+
+			It("returns an error when the response is not 200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+				}, nil)
+
+				_, err := service.GetOpsManagerSetting("syslog")
+				Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+			})
+
+			It("returns an error when the response cannot be unmarshaled", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`)),
+				}, nil)
+
+				_, err := service.GetOpsManagerSetting("syslog")
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal syslog settings response")))
+			})
+		})
+	})
+
+	Describe("UpdateOpsManagerSetting", func() {
+		It("puts the setting", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			}, nil)
+
+			err := service.UpdateOpsManagerSetting("banner", map[string]interface{}{
+				"ui_banner_contents": "authorized use only",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/settings/banner"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"ui_banner_contents": "authorized use only"}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				err := service.UpdateOpsManagerSetting("banner", map[string]interface{}{})
+				Expect(err).To(MatchError("could not send api request to PUT /api/v0/settings/banner: some error"))
+			})
+
+			It("returns an error when the response is not 200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusUnprocessableEntity,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"errors": {"certificate": ["is invalid"]}}`)),
+				}, nil)
+
+				err := service.UpdateOpsManagerSetting("ssl_certificate", map[string]interface{}{})
+				Expect(err).To(MatchError(ContainSubstring("is invalid")))
+			})
+		})
+	})
+})
@@ -22,6 +22,25 @@ type SetupInput struct {
 	HTTPProxyURL                     string
 	HTTPSProxyURL                    string
 	NoProxy                          string
+	IDPMetadata                      string
+	BoshIDPMetadata                  string
+	RBACAdminGroup                   string
+	RBACGroupsAttribute              string
+	LDAPSettings                     *LDAPSettings
+}
+
+type LDAPSettings struct {
+	ServerURL         string `json:"server_url"`
+	Username          string `json:"ldap_username,omitempty"`
+	Password          string `json:"ldap_password,omitempty"`
+	UserSearchBase    string `json:"user_search_base"`
+	UserSearchFilter  string `json:"user_search_filter"`
+	GroupSearchBase   string `json:"group_search_base,omitempty"`
+	GroupSearchFilter string `json:"group_search_filter,omitempty"`
+	RBACAdminGroup    string `json:"ldap_rbac_admin_group_name"`
+	EmailAttribute    string `json:"email_attribute,omitempty"`
+	Referrals         string `json:"ldap_referrals,omitempty"`
+	ServerSSLCert     string `json:"server_ssl_cert,omitempty"`
 }
 
 type SetupOutput struct{}
@@ -29,16 +48,21 @@ type SetupOutput struct{}
 func (a Api) Setup(input SetupInput) (SetupOutput, error) {
 	var setup struct {
 		Setup struct {
-			IdentityProvider                 string `json:"identity_provider"`
-			AdminUserName                    string `json:"admin_user_name"`
-			AdminPassword                    string `json:"admin_password"`
-			AdminPasswordConfirmation        string `json:"admin_password_confirmation"`
-			DecryptionPassphrase             string `json:"decryption_passphrase"`
-			DecryptionPassphraseConfirmation string `json:"decryption_passphrase_confirmation"`
-			EULAAccepted                     string `json:"eula_accepted"`
-			HTTPProxyURL                     string `json:"http_proxy,omitempty"`
-			HTTPSProxyURL                    string `json:"https_proxy,omitempty"`
-			NoProxy                          string `json:"no_proxy,omitempty"`
+			IdentityProvider                 string        `json:"identity_provider"`
+			AdminUserName                    string        `json:"admin_user_name,omitempty"`
+			AdminPassword                    string        `json:"admin_password,omitempty"`
+			AdminPasswordConfirmation        string        `json:"admin_password_confirmation,omitempty"`
+			DecryptionPassphrase             string        `json:"decryption_passphrase"`
+			DecryptionPassphraseConfirmation string        `json:"decryption_passphrase_confirmation"`
+			EULAAccepted                     string        `json:"eula_accepted"`
+			HTTPProxyURL                     string        `json:"http_proxy,omitempty"`
+			HTTPSProxyURL                    string        `json:"https_proxy,omitempty"`
+			NoProxy                          string        `json:"no_proxy,omitempty"`
+			IDPMetadata                      string        `json:"idp_metadata,omitempty"`
+			BoshIDPMetadata                  string        `json:"bosh_idp_metadata,omitempty"`
+			RBACAdminGroup                   string        `json:"rbac_saml_admin_group,omitempty"`
+			RBACGroupsAttribute              string        `json:"rbac_saml_groups_attribute,omitempty"`
+			LDAPSettings                     *LDAPSettings `json:"ldap_settings,omitempty"`
 		} `json:"setup"`
 	}
 
@@ -51,6 +75,11 @@ func (a Api) Setup(input SetupInput) (SetupOutput, error) {
 	setup.Setup.HTTPProxyURL = input.HTTPProxyURL
 	setup.Setup.HTTPSProxyURL = input.HTTPSProxyURL
 	setup.Setup.NoProxy = input.NoProxy
+	setup.Setup.IDPMetadata = input.IDPMetadata
+	setup.Setup.BoshIDPMetadata = input.BoshIDPMetadata
+	setup.Setup.RBACAdminGroup = input.RBACAdminGroup
+	setup.Setup.RBACGroupsAttribute = input.RBACGroupsAttribute
+	setup.Setup.LDAPSettings = input.LDAPSettings
 	setup.Setup.EULAAccepted = strconv.FormatBool(input.EULAAccepted)
 
 	payload, err := json.Marshal(setup)
@@ -83,6 +112,7 @@ const (
 	EnsureAvailabilityStatusUnstarted = "unstarted"
 	EnsureAvailabilityStatusPending   = "pending"
 	EnsureAvailabilityStatusComplete  = "complete"
+	EnsureAvailabilityStatusLocked    = "locked"
 	EnsureAvailabilityStatusUnknown   = "unknown"
 )
 
@@ -116,6 +146,8 @@ func (a Api) EnsureAvailability(input EnsureAvailabilityInput) (EnsureAvailabili
 			status = EnsureAvailabilityStatusUnstarted
 		} else if location.Path == "/auth/cloudfoundry" {
 			status = EnsureAvailabilityStatusComplete
+		} else if location.Path == "/unlock" {
+			status = EnsureAvailabilityStatusLocked
 		} else {
 			return EnsureAvailabilityOutput{}, fmt.Errorf("Unexpected redirect location: %s", location.Path)
 		}
@@ -72,6 +72,90 @@ var _ = Describe("Setup", func() {
 			}`))
 		})
 
+		It("makes a request to setup the OpsManager with SAML", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			_, err := service.Setup(api.SetupInput{
+				IdentityProvider:                 "saml",
+				DecryptionPassphrase:             "some-passphrase",
+				DecryptionPassphraseConfirmation: "some-passphrase",
+				EULAAccepted:                     true,
+				IDPMetadata:                      "https://idp.example.com/metadata",
+				BoshIDPMetadata:                  "https://idp.example.com/bosh-metadata",
+				RBACAdminGroup:                   "opsman-admins",
+				RBACGroupsAttribute:              "groups",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			body, err := ioutil.ReadAll(client.DoArgsForCall(0).Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"setup": {
+					"identity_provider": "saml",
+					"decryption_passphrase": "some-passphrase",
+					"decryption_passphrase_confirmation":"some-passphrase",
+					"eula_accepted": "true",
+					"idp_metadata": "https://idp.example.com/metadata",
+					"bosh_idp_metadata": "https://idp.example.com/bosh-metadata",
+					"rbac_saml_admin_group": "opsman-admins",
+					"rbac_saml_groups_attribute": "groups"
+				}
+			}`))
+		})
+
+		It("makes a request to setup the OpsManager with LDAP", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			_, err := service.Setup(api.SetupInput{
+				IdentityProvider:                 "ldap",
+				DecryptionPassphrase:             "some-passphrase",
+				DecryptionPassphraseConfirmation: "some-passphrase",
+				EULAAccepted:                     true,
+				LDAPSettings: &api.LDAPSettings{
+					ServerURL:         "ldaps://ldap.example.com",
+					Username:          "cn=admin,dc=example,dc=com",
+					Password:          "some-password",
+					UserSearchBase:    "ou=users,dc=example,dc=com",
+					UserSearchFilter:  "cn={0}",
+					GroupSearchBase:   "ou=groups,dc=example,dc=com",
+					GroupSearchFilter: "member={0}",
+					RBACAdminGroup:    "opsman-admins",
+					EmailAttribute:    "mail",
+					Referrals:         "follow",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			body, err := ioutil.ReadAll(client.DoArgsForCall(0).Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"setup": {
+					"identity_provider": "ldap",
+					"decryption_passphrase": "some-passphrase",
+					"decryption_passphrase_confirmation":"some-passphrase",
+					"eula_accepted": "true",
+					"ldap_settings": {
+						"server_url": "ldaps://ldap.example.com",
+						"ldap_username": "cn=admin,dc=example,dc=com",
+						"ldap_password": "some-password",
+						"user_search_base": "ou=users,dc=example,dc=com",
+						"user_search_filter": "cn={0}",
+						"group_search_base": "ou=groups,dc=example,dc=com",
+						"group_search_filter": "member={0}",
+						"ldap_rbac_admin_group_name": "opsman-admins",
+						"email_attribute": "mail",
+						"ldap_referrals": "follow"
+					}
+				}
+			}`))
+		})
+
 		Context("failure cases", func() {
 			Context("when the client fails to make the request", func() {
 				It("returns an error", func() {
@@ -188,6 +272,24 @@ var _ = Describe("Setup", func() {
 			})
 		})
 
+		Context("when the Ops Manager is locked", func() {
+			It("makes a request to determine the availability of the OpsManager authentication mechanism", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusFound,
+					Header: http.Header{
+						"Location": []string{"https://some-opsman/unlock"},
+					},
+					Body: ioutil.NopCloser(strings.NewReader("")),
+				}, nil)
+
+				output, err := service.EnsureAvailability(api.EnsureAvailabilityInput{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal(api.EnsureAvailabilityOutput{
+					Status: api.EnsureAvailabilityStatusLocked,
+				}))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when the request fails", func() {
 				It("returns an error", func() {
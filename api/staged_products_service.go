@@ -40,6 +40,16 @@ type UpdateStagedProductNetworksAndAZsInput struct {
 	NetworksAndAZs string
 }
 
+type UpdateStagedProductSyslogConfigurationInput struct {
+	GUID                string
+	SyslogConfiguration string
+}
+
+type UpdateStagedProductMaxInFlightInput struct {
+	GUID        string
+	MaxInFlight map[string]interface{}
+}
+
 type ResponseProperty struct {
 	Value        interface{}
 	Configurable bool
@@ -219,6 +229,57 @@ func (a Api) UpdateStagedProductNetworksAndAZs(input UpdateStagedProductNetworks
 	return nil
 }
 
+func (a Api) UpdateStagedProductSyslogConfiguration(input UpdateStagedProductSyslogConfigurationInput) error {
+	body := bytes.NewBufferString(fmt.Sprintf(`{"syslog_configuration": %s}`, input.SyslogConfiguration))
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/api/v0/staged/products/%s/syslog_configuration", input.GUID), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to staged product syslog_configuration endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStagedProductMaxInFlight expects MaxInFlight to be keyed by job GUID.
+func (a Api) UpdateStagedProductMaxInFlight(input UpdateStagedProductMaxInFlightInput) error {
+	payload, err := json.Marshal(struct {
+		MaxInFlight map[string]interface{} `json:"max_in_flight"`
+	}{
+		MaxInFlight: input.MaxInFlight,
+	})
+	if err != nil {
+		return err // un-tested
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/api/v0/staged/products/%s/max_in_flight", input.GUID), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to staged product max_in_flight endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 //TODO consider refactoring to use fetchProductResource
 func (a Api) GetStagedProductManifest(guid string) (string, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/manifest", guid), nil)
@@ -297,6 +358,50 @@ func (a Api) GetStagedProductNetworksAndAZs(product string) (map[string]interfac
 	return networksResponse.Networks, nil
 }
 
+// GetStagedProductSyslogConfiguration returns nil when the Ops Manager does not
+// support product syslog configuration.
+func (a Api) GetStagedProductSyslogConfiguration(product string) (map[string]interface{}, error) {
+	respBody, err := a.fetchOptionalProductResource(product, "syslog_configuration")
+	if err != nil {
+		return nil, err
+	}
+	if respBody == nil {
+		return nil, nil
+	}
+	defer respBody.Close()
+
+	syslogResponse := struct {
+		SyslogConfiguration map[string]interface{} `json:"syslog_configuration"`
+	}{}
+	if err = json.NewDecoder(respBody).Decode(&syslogResponse); err != nil {
+		return nil, fmt.Errorf("could not parse json: %s", err)
+	}
+
+	return syslogResponse.SyslogConfiguration, nil
+}
+
+// GetStagedProductMaxInFlight returns the max in flight values keyed by job GUID,
+// or nil when the Ops Manager does not support max in flight configuration.
+func (a Api) GetStagedProductMaxInFlight(product string) (map[string]interface{}, error) {
+	respBody, err := a.fetchOptionalProductResource(product, "max_in_flight")
+	if err != nil {
+		return nil, err
+	}
+	if respBody == nil {
+		return nil, nil
+	}
+	defer respBody.Close()
+
+	maxInFlightResponse := struct {
+		MaxInFlight map[string]interface{} `json:"max_in_flight"`
+	}{}
+	if err = json.NewDecoder(respBody).Decode(&maxInFlightResponse); err != nil {
+		return nil, fmt.Errorf("could not parse json: %s", err)
+	}
+
+	return maxInFlightResponse.MaxInFlight, nil
+}
+
 func (a Api) fetchProductResource(guid, endpoint string) (io.ReadCloser, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/%s", guid, endpoint), nil)
 	if err != nil {
@@ -316,6 +421,31 @@ func (a Api) fetchProductResource(guid, endpoint string) (io.ReadCloser, error)
 	return resp.Body, nil
 }
 
+// fetchOptionalProductResource returns a nil body when the endpoint is not found,
+// as older versions of Ops Manager do not expose every product resource.
+func (a Api) fetchOptionalProductResource(guid, endpoint string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/%s", guid, endpoint), nil)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to staged product %s endpoint: %s", endpoint, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, nil
+	}
+
+	if err = validateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
 func (a Api) checkStagedProducts(productName string) (string, error) {
 	stagedProductsOutput, err := a.ListStagedProducts()
 	if err != nil {
@@ -625,6 +625,140 @@ var _ = Describe("StagedProducts", func() {
 		})
 	})
 
+	Describe("UpdateStagedProductSyslogConfiguration", func() {
+		BeforeEach(func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+			}, nil)
+		})
+
+		It("configures the syslog for the given staged product in the Ops Manager", func() {
+			err := service.UpdateStagedProductSyslogConfiguration(api.UpdateStagedProductSyslogConfigurationInput{
+				GUID: "some-product-guid",
+				SyslogConfiguration: `{
+					"enabled": true,
+					"address": "example.com"
+				}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/syslog_configuration"))
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			reqBody, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqBody).To(MatchJSON(`{
+				"syslog_configuration": {
+					"enabled": true,
+					"address": "example.com"
+				}
+			}`))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					err := service.UpdateStagedProductSyslogConfiguration(api.UpdateStagedProductSyslogConfigurationInput{
+						GUID:                "foo",
+						SyslogConfiguration: `{}`,
+					})
+					Expect(err).To(MatchError("could not make api request to staged product syslog_configuration endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusTeapot,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					err := service.UpdateStagedProductSyslogConfiguration(api.UpdateStagedProductSyslogConfigurationInput{
+						GUID:                "foo",
+						SyslogConfiguration: `{}`,
+					})
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateStagedProductMaxInFlight", func() {
+		BeforeEach(func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+			}, nil)
+		})
+
+		It("configures the max in flight for the given staged product in the Ops Manager", func() {
+			err := service.UpdateStagedProductMaxInFlight(api.UpdateStagedProductMaxInFlightInput{
+				GUID: "some-product-guid",
+				MaxInFlight: map[string]interface{}{
+					"some-job-guid":  5,
+					"other-job-guid": "20%",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/max_in_flight"))
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			reqBody, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqBody).To(MatchJSON(`{
+				"max_in_flight": {
+					"some-job-guid": 5,
+					"other-job-guid": "20%"
+				}
+			}`))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					err := service.UpdateStagedProductMaxInFlight(api.UpdateStagedProductMaxInFlightInput{
+						GUID: "foo",
+					})
+					Expect(err).To(MatchError("could not make api request to staged product max_in_flight endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusTeapot,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					err := service.UpdateStagedProductMaxInFlight(api.UpdateStagedProductMaxInFlightInput{
+						GUID: "foo",
+					})
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+		})
+	})
+
 	Describe("GetStagedProductManifest", func() {
 		BeforeEach(func() {
 			client.DoStub = func(req *http.Request) (*http.Response, error) {
@@ -916,4 +1050,154 @@ key-4: 2147483648
 			})
 		})
 	})
+
+	Describe("GetStagedProductSyslogConfiguration", func() {
+		BeforeEach(func() {
+			client.DoStub = func(req *http.Request) (*http.Response, error) {
+				var resp *http.Response
+				switch req.URL.Path {
+				case "/api/v0/staged/products/some-product-guid/syslog_configuration":
+					resp = &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewBufferString(`{
+							"syslog_configuration": {
+								"enabled": true,
+								"address": "example.com",
+								"port": 514,
+								"transport_protocol": "tcp"
+							}
+						}`)),
+					}
+				}
+				return resp, nil
+			}
+		})
+
+		It("returns the syslog configuration for a product", func() {
+			config, err := service.GetStagedProductSyslogConfiguration("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(map[string]interface{}{
+				"enabled":            true,
+				"address":            "example.com",
+				"port":               float64(514),
+				"transport_protocol": "tcp",
+			}))
+		})
+
+		Context("when the Ops Manager does not support the endpoint", func() {
+			BeforeEach(func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				}, nil)
+			})
+
+			It("returns nil", func() {
+				config, err := service.GetStagedProductSyslogConfiguration("some-product-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config).To(BeNil())
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when the syslog_configuration request returns an error", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{}, errors.New("some-error"))
+				})
+
+				It("returns an error", func() {
+					_, err := service.GetStagedProductSyslogConfiguration("some-product-guid")
+					Expect(err).To(MatchError(`could not make api request to staged product syslog_configuration endpoint: some-error`))
+				})
+			})
+
+			Context("when the server returns invalid json", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(`{{{`)),
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := service.GetStagedProductSyslogConfiguration("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+				})
+			})
+		})
+	})
+
+	Describe("GetStagedProductMaxInFlight", func() {
+		BeforeEach(func() {
+			client.DoStub = func(req *http.Request) (*http.Response, error) {
+				var resp *http.Response
+				switch req.URL.Path {
+				case "/api/v0/staged/products/some-product-guid/max_in_flight":
+					resp = &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewBufferString(`{
+							"max_in_flight": {
+								"some-job-guid": 1,
+								"other-job-guid": "20%",
+								"another-job-guid": "default"
+							}
+						}`)),
+					}
+				}
+				return resp, nil
+			}
+		})
+
+		It("returns the max in flight values keyed by job guid", func() {
+			maxInFlight, err := service.GetStagedProductMaxInFlight("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(maxInFlight).To(Equal(map[string]interface{}{
+				"some-job-guid":    float64(1),
+				"other-job-guid":   "20%",
+				"another-job-guid": "default",
+			}))
+		})
+
+		Context("when the Ops Manager does not support the endpoint", func() {
+			BeforeEach(func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				}, nil)
+			})
+
+			It("returns nil", func() {
+				config, err := service.GetStagedProductMaxInFlight("some-product-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config).To(BeNil())
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when the max_in_flight request returns an error", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{}, errors.New("some-error"))
+				})
+
+				It("returns an error", func() {
+					_, err := service.GetStagedProductMaxInFlight("some-product-guid")
+					Expect(err).To(MatchError(`could not make api request to staged product max_in_flight endpoint: some-error`))
+				})
+			})
+
+			Context("when the server returns invalid json", func() {
+				BeforeEach(func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(`{{{`)),
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := service.GetStagedProductMaxInFlight("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const stemcellAssignmentsEndpoint = "/api/v0/stemcell_assignments"
+
+type StemcellAssignmentsOutput struct {
+	Products        []ProductStemcells `json:"products"`
+	StemcellLibrary []StemcellLibrary  `json:"stemcell_library"`
+}
+
+type ProductStemcells struct {
+	GUID                    string   `json:"guid"`
+	ProductName             string   `json:"identifier"`
+	StagedProductVersion    string   `json:"staged_product_version"`
+	DeployedProductVersion  string   `json:"deployed_product_version"`
+	IsStagedForDeletion     bool     `json:"is_staged_for_deletion"`
+	StagedStemcellVersion   string   `json:"staged_stemcell_version"`
+	DeployedStemcellVersion string   `json:"deployed_stemcell_version"`
+	AvailableVersions       []string `json:"available_stemcell_versions"`
+	RequiredStemcellVersion string   `json:"required_stemcell_version"`
+	RequiredStemcellOS      string   `json:"required_stemcell_os"`
+}
+
+type StemcellLibrary struct {
+	Infrastructure    string `json:"infrastructure"`
+	Hypervisor        string `json:"hypervisor"`
+	OS                string `json:"os"`
+	Version           string `json:"version"`
+	Light             bool   `json:"light"`
+	StagedForDeletion bool   `json:"staged_for_deletion"`
+	FileName          string `json:"file_name"`
+}
+
+type AssignStemcellInput struct {
+	Products []StemcellAssignment `json:"products"`
+}
+
+type StemcellAssignment struct {
+	GUID                  string `json:"guid"`
+	StagedStemcellVersion string `json:"staged_stemcell_version"`
+}
+
+func (a Api) ListStemcellAssignments() (StemcellAssignmentsOutput, error) {
+	req, err := http.NewRequest("GET", stemcellAssignmentsEndpoint, nil)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, fmt.Errorf("could not make api request to stemcell_assignments endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return StemcellAssignmentsOutput{}, err
+	}
+
+	var output StemcellAssignmentsOutput
+	err = json.NewDecoder(resp.Body).Decode(&output)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, fmt.Errorf("could not unmarshal stemcell_assignments response: %s", err)
+	}
+
+	return output, nil
+}
+
+func (a Api) AssignStemcell(input AssignStemcellInput) error {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return err // un-tested
+	}
+
+	req, err := http.NewRequest("PATCH", stemcellAssignmentsEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err // un-tested
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to stemcell_assignments endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	return validateStatusOK(resp)
+}
@@ -0,0 +1,162 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StemcellAssignmentsService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+
+		service = api.New(api.ApiInput{
+			Client: client,
+		})
+	})
+
+	Describe("ListStemcellAssignments", func() {
+		It("lists the stemcell assignments of every product", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"products": [{
+						"guid": "cf-guid",
+						"identifier": "cf",
+						"label": "Pivotal Application Service",
+						"staged_product_version": "2.2.1",
+						"deployed_product_version": null,
+						"is_staged_for_deletion": false,
+						"staged_stemcell_version": "97.18",
+						"deployed_stemcell_version": null,
+						"available_stemcell_versions": ["97.18", "97.19"],
+						"required_stemcell_version": "97.18",
+						"required_stemcell_os": "ubuntu-xenial"
+					}],
+					"stemcell_library": [{
+						"infrastructure": "google",
+						"hypervisor": "kvm",
+						"os": "ubuntu-xenial",
+						"version": "97.18",
+						"light": true,
+						"staged_for_deletion": false,
+						"file_name": "light-bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz"
+					}]
+				}`)),
+			}, nil)
+
+			output, err := service.ListStemcellAssignments()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output).To(Equal(api.StemcellAssignmentsOutput{
+				Products: []api.ProductStemcells{{
+					GUID:                    "cf-guid",
+					ProductName:             "cf",
+					StagedProductVersion:    "2.2.1",
+					StagedStemcellVersion:   "97.18",
+					AvailableVersions:       []string{"97.18", "97.19"},
+					RequiredStemcellVersion: "97.18",
+					RequiredStemcellOS:      "ubuntu-xenial",
+				}},
+				StemcellLibrary: []api.StemcellLibrary{{
+					Infrastructure: "google",
+					Hypervisor:     "kvm",
+					OS:             "ubuntu-xenial",
+					Version:        "97.18",
+					Light:          true,
+					FileName:       "light-bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz",
+				}},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/stemcell_assignments"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				_, err := service.ListStemcellAssignments()
+				Expect(err).To(MatchError("could not make api request to stemcell_assignments endpoint: some error"))
+			})
+
+			It("returns an error when the response is not 200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+				}, nil)
+
+				_, err := service.ListStemcellAssignments()
+				Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+			})
+
+			It("returns an error when the response cannot be unmarshaled", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`)),
+				}, nil)
+
+				_, err := service.ListStemcellAssignments()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal stemcell_assignments response")))
+			})
+		})
+	})
+
+	Describe("AssignStemcell", func() {
+		It("patches the stemcell assignments", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			}, nil)
+
+			err := service.AssignStemcell(api.AssignStemcellInput{
+				Products: []api.StemcellAssignment{{
+					GUID:                  "cf-guid",
+					StagedStemcellVersion: "97.19",
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PATCH"))
+			Expect(req.URL.Path).To(Equal("/api/v0/stemcell_assignments"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"products": [{"guid": "cf-guid", "staged_stemcell_version": "97.19"}]}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				err := service.AssignStemcell(api.AssignStemcellInput{})
+				Expect(err).To(MatchError("could not make api request to stemcell_assignments endpoint: some error"))
+			})
+
+			It("returns an error when the response is not 200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusUnprocessableEntity,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"errors": ["stemcell is not compatible"]}`)),
+				}, nil)
+
+				err := service.AssignStemcell(api.AssignStemcellInput{})
+				Expect(err).To(MatchError(ContainSubstring("stemcell is not compatible")))
+			})
+		})
+	})
+})
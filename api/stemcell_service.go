@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 type StemcellUploadInput struct {
@@ -32,3 +33,19 @@ func (a Api) UploadStemcell(input StemcellUploadInput) (StemcellUploadOutput, er
 
 	return StemcellUploadOutput{}, validateStatusOK(resp)
 }
+
+func (a Api) DeleteStemcell(fileName string) error {
+	req, err := http.NewRequest("DELETE", "/api/v0/stemcells/"+url.PathEscape(fileName), nil)
+	if err != nil {
+		return err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to stemcells endpoint: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	return validateStatusOK(resp)
+}
@@ -76,4 +76,49 @@ var _ = Describe("StemcellService", func() {
 			})
 		})
 	})
+
+	Describe("DeleteStemcell", func() {
+		var (
+			client  *fakes.HttpClient
+			service api.Api
+		)
+
+		BeforeEach(func() {
+			client = &fakes.HttpClient{}
+			service = api.New(api.ApiInput{
+				Client: client,
+			})
+		})
+
+		It("deletes the stemcell by its file name", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			err := service.DeleteStemcell("light-bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz")
+			Expect(err).NotTo(HaveOccurred())
+
+			request := client.DoArgsForCall(0)
+			Expect(request.Method).To(Equal("DELETE"))
+			Expect(request.URL.Path).To(Equal("/api/v0/stemcells/light-bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz"))
+		})
+
+		It("returns an error when the request fails", func() {
+			client.DoReturns(nil, errors.New("some error"))
+
+			err := service.DeleteStemcell("some-stemcell.tgz")
+			Expect(err).To(MatchError("could not make api request to stemcells endpoint: some error"))
+		})
+
+		It("returns an error when the response is not 200", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			err := service.DeleteStemcell("some-stemcell.tgz")
+			Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+		})
+	})
 })
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+const (
+	uaaClientsEndpoint = "/uaa/oauth/clients"
+	uaaUsersEndpoint   = "/uaa/Users"
+	uaaGroupsEndpoint  = "/uaa/Groups"
+	uaaPageSize        = 100
+)
+
+type UAAClient struct {
+	ClientID             string   `json:"client_id"`
+	ClientSecret         string   `json:"client_secret,omitempty"`
+	Scope                []string `json:"scope,omitempty"`
+	Authorities          []string `json:"authorities,omitempty"`
+	AuthorizedGrantTypes []string `json:"authorized_grant_types,omitempty"`
+	AccessTokenValidity  int      `json:"access_token_validity,omitempty"`
+	RefreshTokenValidity int      `json:"refresh_token_validity,omitempty"`
+}
+
+type CreateUAAUserInput struct {
+	Username string
+	Password string
+	Email    string
+}
+
+func (a Api) ListUAAClients() ([]UAAClient, error) {
+	var clients []UAAClient
+
+	for startIndex := 1; ; startIndex += uaaPageSize {
+		query := url.Values{}
+		query.Set("startIndex", fmt.Sprint(startIndex))
+		query.Set("count", fmt.Sprint(uaaPageSize))
+
+		var page struct {
+			Resources    []UAAClient `json:"resources"`
+			TotalResults int         `json:"totalResults"`
+		}
+
+		err := a.uaaRequest("GET", uaaClientsEndpoint+"?"+query.Encode(), nil, http.StatusOK, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		clients = append(clients, page.Resources...)
+
+		if len(page.Resources) == 0 || len(clients) >= page.TotalResults {
+			return clients, nil
+		}
+	}
+}
+
+func (a Api) CreateUAAClient(client UAAClient) error {
+	err := a.uaaRequest("POST", uaaClientsEndpoint, client, http.StatusCreated, nil)
+	if status, ok := err.(uaaStatusError); ok && status.statusCode == http.StatusConflict {
+		return fmt.Errorf("uaa client %s already exists", client.ClientID)
+	}
+
+	return err
+}
+
+func (a Api) DeleteUAAClient(clientID string) error {
+	err := a.uaaRequest("DELETE", fmt.Sprintf("%s/%s", uaaClientsEndpoint, url.PathEscape(clientID)), nil, http.StatusOK, nil)
+	if status, ok := err.(uaaStatusError); ok && status.statusCode == http.StatusNotFound {
+		return fmt.Errorf("uaa client %s does not exist", clientID)
+	}
+
+	return err
+}
+
+// CreateUAAUser creates a user in the uaa userstore and returns its id.
+func (a Api) CreateUAAUser(input CreateUAAUserInput) (string, error) {
+	type email struct {
+		Value   string `json:"value"`
+		Primary bool   `json:"primary"`
+	}
+
+	user := struct {
+		UserName string  `json:"userName"`
+		Password string  `json:"password"`
+		Emails   []email `json:"emails"`
+	}{
+		UserName: input.Username,
+		Password: input.Password,
+		Emails:   []email{{Value: input.Email, Primary: true}},
+	}
+
+	var output struct {
+		ID string `json:"id"`
+	}
+
+	err := a.uaaRequest("POST", uaaUsersEndpoint, user, http.StatusCreated, &output)
+	if status, ok := err.(uaaStatusError); ok && status.statusCode == http.StatusConflict {
+		return "", fmt.Errorf("uaa user %s already exists", input.Username)
+	}
+
+	return output.ID, err
+}
+
+// AddUAAGroupMember adds a user to a uaa group, such as opsman.admin, which
+// grants the user the scope of the same name.
+func (a Api) AddUAAGroupMember(group, userID string) error {
+	query := url.Values{}
+	query.Set("filter", fmt.Sprintf("displayName eq %q", group))
+
+	var groups struct {
+		Resources []struct {
+			ID string `json:"id"`
+		} `json:"resources"`
+	}
+
+	err := a.uaaRequest("GET", uaaGroupsEndpoint+"?"+query.Encode(), nil, http.StatusOK, &groups)
+	if err != nil {
+		return err
+	}
+
+	if len(groups.Resources) == 0 {
+		return fmt.Errorf("uaa group %s does not exist", group)
+	}
+
+	member := map[string]string{
+		"origin": "uaa",
+		"type":   "USER",
+		"value":  userID,
+	}
+
+	return a.uaaRequest("POST", fmt.Sprintf("%s/%s/members", uaaGroupsEndpoint, groups.Resources[0].ID), member, http.StatusCreated, nil)
+}
+
+type uaaStatusError struct {
+	error
+	statusCode int
+}
+
+func (a Api) uaaRequest(method, endpoint string, input interface{}, expectedStatus int, output interface{}) error {
+	var payload []byte
+	if input != nil {
+		var err error
+		payload, err = json.Marshal(input)
+		if err != nil {
+			return err // un-tested
+		}
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err // un-tested
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if input != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make request to uaa: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		out, err := httputil.DumpResponse(resp, true)
+		if err != nil {
+			return uaaStatusError{fmt.Errorf("request failed: unexpected response: %s", err), resp.StatusCode} // un-tested
+		}
+
+		return uaaStatusError{fmt.Errorf("request failed: unexpected response:\n%s", out), resp.StatusCode}
+	}
+
+	if output != nil {
+		err = json.NewDecoder(resp.Body).Decode(output)
+		if err != nil {
+			return fmt.Errorf("could not unmarshal uaa response: %s", err)
+		}
+	}
+
+	return nil
+}
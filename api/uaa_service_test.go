@@ -0,0 +1,252 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UAAService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+
+		service = api.New(api.ApiInput{
+			Client: client,
+		})
+	})
+
+	response := func(statusCode int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	Describe("ListUAAClients", func() {
+		It("lists the clients of every page", func() {
+			var firstPage []string
+			for i := 0; i < 100; i++ {
+				firstPage = append(firstPage, fmt.Sprintf(`{"client_id": "client-%d"}`, i))
+			}
+
+			client.DoStub = func(req *http.Request) (*http.Response, error) {
+				if req.URL.Query().Get("startIndex") == "1" {
+					return response(http.StatusOK, fmt.Sprintf(`{"resources": [%s], "totalResults": 101}`, strings.Join(firstPage, ","))), nil
+				}
+
+				return response(http.StatusOK, `{
+					"resources": [{
+						"client_id": "pipeline",
+						"scope": ["uaa.none"],
+						"authorities": ["opsman.admin"],
+						"authorized_grant_types": ["client_credentials"],
+						"access_token_validity": 43200
+					}],
+					"totalResults": 101
+				}`), nil
+			}
+
+			clients, err := service.ListUAAClients()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(clients).To(HaveLen(101))
+			Expect(clients[0]).To(Equal(api.UAAClient{ClientID: "client-0"}))
+			Expect(clients[100]).To(Equal(api.UAAClient{
+				ClientID:             "pipeline",
+				Scope:                []string{"uaa.none"},
+				Authorities:          []string{"opsman.admin"},
+				AuthorizedGrantTypes: []string{"client_credentials"},
+				AccessTokenValidity:  43200,
+			}))
+
+			Expect(client.DoCallCount()).To(Equal(2))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/uaa/oauth/clients"))
+			Expect(req.URL.Query().Get("count")).To(Equal("100"))
+			Expect(req.Header.Get("Accept")).To(Equal("application/json"))
+
+			Expect(client.DoArgsForCall(1).URL.Query().Get("startIndex")).To(Equal("101"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				_, err := service.ListUAAClients()
+				Expect(err).To(MatchError("could not make request to uaa: some error"))
+			})
+
+			It("returns an error when the response is not 200", func() {
+				client.DoReturns(response(http.StatusForbidden, `{"error": "insufficient_scope"}`), nil)
+
+				_, err := service.ListUAAClients()
+				Expect(err).To(MatchError(ContainSubstring("insufficient_scope")))
+			})
+
+			It("returns an error when the response cannot be unmarshaled", func() {
+				client.DoReturns(response(http.StatusOK, `%%%`), nil)
+
+				_, err := service.ListUAAClients()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal uaa response")))
+			})
+		})
+	})
+
+	Describe("CreateUAAClient", func() {
+		It("creates the client", func() {
+			client.DoReturns(response(http.StatusCreated, `{}`), nil)
+
+			err := service.CreateUAAClient(api.UAAClient{
+				ClientID:             "pipeline",
+				ClientSecret:         "some-secret",
+				Authorities:          []string{"opsman.admin"},
+				AuthorizedGrantTypes: []string{"client_credentials"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.Path).To(Equal("/uaa/oauth/clients"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"client_id": "pipeline",
+				"client_secret": "some-secret",
+				"authorities": ["opsman.admin"],
+				"authorized_grant_types": ["client_credentials"]
+			}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the client already exists", func() {
+				client.DoReturns(response(http.StatusConflict, `{}`), nil)
+
+				err := service.CreateUAAClient(api.UAAClient{ClientID: "pipeline"})
+				Expect(err).To(MatchError("uaa client pipeline already exists"))
+			})
+
+			It("returns an error when the response is not 201", func() {
+				client.DoReturns(response(http.StatusBadRequest, `{"error": "invalid_client"}`), nil)
+
+				err := service.CreateUAAClient(api.UAAClient{ClientID: "pipeline"})
+				Expect(err).To(MatchError(ContainSubstring("invalid_client")))
+			})
+		})
+	})
+
+	Describe("DeleteUAAClient", func() {
+		It("deletes the client", func() {
+			client.DoReturns(response(http.StatusOK, `{}`), nil)
+
+			err := service.DeleteUAAClient("pipeline")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("DELETE"))
+			Expect(req.URL.Path).To(Equal("/uaa/oauth/clients/pipeline"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the client does not exist", func() {
+				client.DoReturns(response(http.StatusNotFound, `{}`), nil)
+
+				err := service.DeleteUAAClient("pipeline")
+				Expect(err).To(MatchError("uaa client pipeline does not exist"))
+			})
+
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				err := service.DeleteUAAClient("pipeline")
+				Expect(err).To(MatchError("could not make request to uaa: some error"))
+			})
+		})
+	})
+
+	Describe("CreateUAAUser", func() {
+		It("creates the user and returns its id", func() {
+			client.DoReturns(response(http.StatusCreated, `{"id": "some-user-id", "userName": "pipeline-user"}`), nil)
+
+			id, err := service.CreateUAAUser(api.CreateUAAUserInput{
+				Username: "pipeline-user",
+				Password: "some-password",
+				Email:    "pipeline@example.com",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("some-user-id"))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.Path).To(Equal("/uaa/Users"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"userName": "pipeline-user",
+				"password": "some-password",
+				"emails": [{"value": "pipeline@example.com", "primary": true}]
+			}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the user already exists", func() {
+				client.DoReturns(response(http.StatusConflict, `{}`), nil)
+
+				_, err := service.CreateUAAUser(api.CreateUAAUserInput{Username: "pipeline-user"})
+				Expect(err).To(MatchError("uaa user pipeline-user already exists"))
+			})
+		})
+	})
+
+	Describe("AddUAAGroupMember", func() {
+		It("adds the user to the group", func() {
+			client.DoStub = func(req *http.Request) (*http.Response, error) {
+				if req.Method == "GET" {
+					return response(http.StatusOK, `{"resources": [{"id": "some-group-id", "displayName": "opsman.admin"}]}`), nil
+				}
+				return response(http.StatusCreated, `{}`), nil
+			}
+
+			err := service.AddUAAGroupMember("opsman.admin", "some-user-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.Path).To(Equal("/uaa/Groups"))
+			Expect(req.URL.Query().Get("filter")).To(Equal(`displayName eq "opsman.admin"`))
+
+			req = client.DoArgsForCall(1)
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.Path).To(Equal("/uaa/Groups/some-group-id/members"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"origin": "uaa", "type": "USER", "value": "some-user-id"}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the group does not exist", func() {
+				client.DoReturns(response(http.StatusOK, `{"resources": []}`), nil)
+
+				err := service.AddUAAGroupMember("opsman.bogus", "some-user-id")
+				Expect(err).To(MatchError("uaa group opsman.bogus does not exist"))
+			})
+		})
+	})
+})
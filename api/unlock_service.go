@@ -0,0 +1,40 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type UnlockInput struct {
+	DecryptionPassphrase string `json:"passphrase"`
+}
+
+// Unlock sends the decryption passphrase to an Ops Manager that has been
+// restarted, so that its authentication system can start.
+func (a Api) Unlock(input UnlockInput) error {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return err // un-tested
+	}
+
+	req, err := http.NewRequest("PUT", "/api/v0/unlock", bytes.NewReader(payload))
+	if err != nil {
+		return err // un-tested
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.unauthedClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to unlock endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("could not unlock Ops Manager: the decryption passphrase is incorrect")
+	}
+
+	return validateStatusOK(resp)
+}
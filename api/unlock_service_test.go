@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnlockService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+		service = api.New(api.ApiInput{
+			UnauthedClient: client,
+		})
+	})
+
+	Describe("Unlock", func() {
+		It("sends the decryption passphrase", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			err := service.Unlock(api.UnlockInput{DecryptionPassphrase: "some-passphrase"})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/unlock"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"passphrase": "some-passphrase"}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client.DoReturns(nil, errors.New("some error"))
+
+				err := service.Unlock(api.UnlockInput{})
+				Expect(err).To(MatchError("could not make api request to unlock endpoint: some error"))
+			})
+
+			It("returns an error when the passphrase is incorrect", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusForbidden,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"errors": ["decryption passphrase is incorrect"]}`)),
+				}, nil)
+
+				err := service.Unlock(api.UnlockInput{})
+				Expect(err).To(MatchError("could not unlock Ops Manager: the decryption passphrase is incorrect"))
+			})
+
+			It("returns an error when the response is not 200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+				}, nil)
+
+				err := service.Unlock(api.UnlockInput{})
+				Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+			})
+		})
+	})
+})
@@ -2,8 +2,13 @@ package api
 
 import (
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"os"
+	"syscall"
 )
 
 func validateStatusOK(resp *http.Response) error {
@@ -17,3 +22,59 @@ func validateStatusOK(resp *http.Response) error {
 	}
 	return nil
 }
+
+type temporaryError struct {
+	error
+}
+
+func (temporaryError) Temporary() bool {
+	return true
+}
+
+// IsTemporary reports whether a request failed in a way that may succeed when
+// it is retried, such as a timeout, a dropped connection or an unavailable
+// gateway. Requests that were refused, for example because the credentials are
+// wrong, are not temporary.
+func IsTemporary(err error) bool {
+	temporary, ok := err.(interface {
+		Temporary() bool
+	})
+
+	return ok && temporary.Temporary()
+}
+
+// isTemporaryRequestError reports whether a request failed at the network
+// level, such as a timeout or a dropped connection, rather than being refused,
+// for example because the credentials are wrong.
+func isTemporaryRequestError(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	if err == io.ErrUnexpectedEOF {
+		return true
+	}
+
+	if netErr, ok := err.(net.Error); ok && (netErr.Timeout() || netErr.Temporary()) {
+		return true
+	}
+
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+
+	if syscallErr, ok := err.(*os.SyscallError); ok {
+		err = syscallErr.Err
+	}
+
+	return err == syscall.ECONNRESET
+}
+
+func isTemporaryStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type CreateVMExtension struct {
@@ -36,6 +37,90 @@ func (a Api) CreateStagedVMExtension(input CreateVMExtension) error {
 	if err != nil {
 		return fmt.Errorf("could not send api request to %s %s: %s", verb, endpoint, err.Error())
 	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type VMExtension struct {
+	Name            string          `json:"name"`
+	CloudProperties json.RawMessage `json:"cloud_properties"`
+}
+
+func (a Api) ListStagedVMExtensions() ([]VMExtension, error) {
+	verb := "GET"
+	endpoint := "/api/v0/staged/vm_extensions"
+	req, err := http.NewRequest(verb, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create api request %s %s: %s", verb, endpoint, err.Error()) // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send api request to %s %s: %s", verb, endpoint, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	var vmExtensions struct {
+		VMExtensions []VMExtension `json:"vm_extensions"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&vmExtensions)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal vm_extensions response: %s", err)
+	}
+
+	return vmExtensions.VMExtensions, nil
+}
+
+// UpdateStagedVMExtension replaces the cloud properties of an existing VM extension.
+func (a Api) UpdateStagedVMExtension(input CreateVMExtension) error {
+	jsonData, err := json.Marshal(&input)
+	if err != nil {
+		return fmt.Errorf("could not marshal json: %s", err)
+	}
+
+	verb := "PUT"
+	endpoint := fmt.Sprintf("/api/v0/staged/vm_extensions/%s", url.PathEscape(input.Name))
+	req, err := http.NewRequest(verb, endpoint, bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("could not create api request %s %s: %s", verb, endpoint, err.Error())
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not send api request to %s %s: %s", verb, endpoint, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a Api) DeleteStagedVMExtension(name string) error {
+	verb := "DELETE"
+	endpoint := fmt.Sprintf("/api/v0/staged/vm_extensions/%s", url.PathEscape(name))
+	req, err := http.NewRequest(verb, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("could not create api request %s %s: %s", verb, endpoint, err.Error())
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not send api request to %s %s: %s", verb, endpoint, err.Error())
+	}
+	defer resp.Body.Close()
 
 	if err = validateStatusOK(resp); err != nil {
 		return err
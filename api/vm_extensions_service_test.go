@@ -81,4 +81,132 @@ var _ = Describe("VMExtensions", func() {
 			Expect(err).To(MatchError("could not send api request to POST /api/v0/staged/vm_extensions: api endpoint failed"))
 		})
 	})
+
+	Describe("ListStagedVMExtensions", func() {
+		It("lists the VM extensions", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{"vm_extensions": [
+					{"name": "some-vm-extension", "cloud_properties": {"iam_instance_profile": "some-iam-profile"}},
+					{"name": "some-other-vm-extension", "cloud_properties": {"elbs": ["some-elb"]}}
+				]}`))}, nil)
+
+			vmExtensions, err := service.ListStagedVMExtensions()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vmExtensions).To(HaveLen(2))
+			Expect(vmExtensions[0].Name).To(Equal("some-vm-extension"))
+			Expect(vmExtensions[0].CloudProperties).To(MatchJSON(`{"iam_instance_profile": "some-iam-profile"}`))
+			Expect(vmExtensions[1].Name).To(Equal("some-other-vm-extension"))
+			Expect(vmExtensions[1].CloudProperties).To(MatchJSON(`{"elbs": ["some-elb"]}`))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/vm_extensions"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				_, err := service.ListStagedVMExtensions()
+				Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+			})
+
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(nil, errors.New("api endpoint failed"))
+
+				_, err := service.ListStagedVMExtensions()
+				Expect(err).To(MatchError("could not send api request to GET /api/v0/staged/vm_extensions: api endpoint failed"))
+			})
+
+			It("returns an error when the response is not valid json", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`))}, nil)
+
+				_, err := service.ListStagedVMExtensions()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal vm_extensions response")))
+			})
+		})
+	})
+
+	Describe("UpdateStagedVMExtension", func() {
+		It("updates a VM extension", func() {
+			err := service.UpdateStagedVMExtension(api.CreateVMExtension{
+				Name:            "some-vm-extension",
+				CloudProperties: json.RawMessage(`{"iam_instance_profile": "some-other-iam-profile"}`),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/vm_extensions/some-vm-extension"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			jsonBody, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonBody).To(MatchJSON(`{
+				"name": "some-vm-extension",
+				"cloud_properties": {"iam_instance_profile": "some-other-iam-profile"}
+			}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				err := service.UpdateStagedVMExtension(api.CreateVMExtension{Name: "some-vm-extension", CloudProperties: json.RawMessage(`{}`)})
+				Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+			})
+
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(nil, errors.New("api endpoint failed"))
+
+				err := service.UpdateStagedVMExtension(api.CreateVMExtension{Name: "some-vm-extension", CloudProperties: json.RawMessage(`{}`)})
+				Expect(err).To(MatchError("could not send api request to PUT /api/v0/staged/vm_extensions/some-vm-extension: api endpoint failed"))
+			})
+		})
+	})
+
+	Describe("DeleteStagedVMExtension", func() {
+		It("deletes a VM extension", func() {
+			err := service.DeleteStagedVMExtension("some-vm-extension")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("DELETE"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/vm_extensions/some-vm-extension"))
+		})
+
+		It("escapes the name of the VM extension", func() {
+			err := service.DeleteStagedVMExtension("some extension/with-slash")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.EscapedPath()).To(Equal("/api/v0/staged/vm_extensions/some%20extension%2Fwith-slash"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				err := service.DeleteStagedVMExtension("some-vm-extension")
+				Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+			})
+
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(nil, errors.New("api endpoint failed"))
+
+				err := service.DeleteStagedVMExtension("some-vm-extension")
+				Expect(err).To(MatchError("could not send api request to DELETE /api/v0/staged/vm_extensions/some-vm-extension: api endpoint failed"))
+			})
+		})
+	})
 })
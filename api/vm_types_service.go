@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type VMType struct {
+	Name          string `json:"name"`
+	RAM           int    `json:"ram"`
+	CPU           int    `json:"cpu"`
+	EphemeralDisk int    `json:"ephemeral_disk"`
+	BuiltIn       bool   `json:"builtin"`
+}
+
+type CreateVMTypes struct {
+	VMTypes []CreateVMType `json:"vm_types"`
+}
+
+type CreateVMType struct {
+	Name          string `json:"name" yaml:"name"`
+	RAM           int    `json:"ram" yaml:"ram"`
+	CPU           int    `json:"cpu" yaml:"cpu"`
+	EphemeralDisk int    `json:"ephemeral_disk" yaml:"ephemeral_disk"`
+}
+
+func (a Api) ListVMTypes() ([]VMType, error) {
+	req, err := http.NewRequest("GET", "/api/v0/vm_types", nil)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to vm_types endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	var vmTypesResponse struct {
+		VMTypes []VMType `json:"vm_types"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&vmTypesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal vm_types response: %s", err)
+	}
+
+	return vmTypesResponse.VMTypes, nil
+}
+
+// CreateCustomVMTypes replaces all VM types, including the Ops Manager defaults,
+// with the given VM types.
+func (a Api) CreateCustomVMTypes(input CreateVMTypes) error {
+	jsonData, err := json.Marshal(&input)
+	if err != nil {
+		return fmt.Errorf("could not marshal json: %s", err) // un-tested
+	}
+
+	req, err := http.NewRequest("PUT", "/api/v0/vm_types", bytes.NewReader(jsonData))
+	if err != nil {
+		return err // un-tested
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to vm_types endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCustomVMTypes resets the VM types to the Ops Manager defaults.
+func (a Api) DeleteCustomVMTypes() error {
+	req, err := http.NewRequest("DELETE", "/api/v0/vm_types", nil)
+	if err != nil {
+		return err // un-tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to vm_types endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
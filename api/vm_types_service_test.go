@@ -0,0 +1,167 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+)
+
+var _ = Describe("VMTypes", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+		service = api.New(api.ApiInput{
+			Client: client,
+		})
+	})
+
+	Describe("ListVMTypes", func() {
+		BeforeEach(func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"vm_types": [
+						{"name": "nano", "ram": 512, "cpu": 1, "ephemeral_disk": 1024, "builtin": true},
+						{"name": "memory-heavy", "ram": 65536, "cpu": 8, "ephemeral_disk": 32768, "builtin": false}
+					]
+				}`)),
+			}, nil)
+		})
+
+		It("lists the vm types", func() {
+			vmTypes, err := service.ListVMTypes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vmTypes).To(Equal([]api.VMType{
+				{Name: "nano", RAM: 512, CPU: 1, EphemeralDisk: 1024, BuiltIn: true},
+				{Name: "memory-heavy", RAM: 65536, CPU: 8, EphemeralDisk: 32768, BuiltIn: false},
+			}))
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/vm_types"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(&http.Response{}, errors.New("api endpoint failed"))
+
+				_, err := service.ListVMTypes()
+				Expect(err).To(MatchError("could not make api request to vm_types endpoint: api endpoint failed"))
+			})
+
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				_, err := service.ListVMTypes()
+				Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+			})
+
+			It("returns an error when the response is not valid json", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`))}, nil)
+
+				_, err := service.ListVMTypes()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal vm_types response")))
+			})
+		})
+	})
+
+	Describe("CreateCustomVMTypes", func() {
+		BeforeEach(func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+		})
+
+		It("replaces the vm types", func() {
+			err := service.CreateCustomVMTypes(api.CreateVMTypes{
+				VMTypes: []api.CreateVMType{
+					{Name: "memory-heavy", RAM: 65536, CPU: 8, EphemeralDisk: 32768},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/vm_types"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			jsonBody, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonBody).To(MatchJSON(`{
+				"vm_types": [
+					{"name": "memory-heavy", "ram": 65536, "cpu": 8, "ephemeral_disk": 32768}
+				]
+			}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(&http.Response{}, errors.New("api endpoint failed"))
+
+				err := service.CreateCustomVMTypes(api.CreateVMTypes{})
+				Expect(err).To(MatchError("could not make api request to vm_types endpoint: api endpoint failed"))
+			})
+
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusUnprocessableEntity,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				err := service.CreateCustomVMTypes(api.CreateVMTypes{})
+				Expect(err).To(MatchError(ContainSubstring("422 Unprocessable Entity")))
+			})
+		})
+	})
+
+	Describe("DeleteCustomVMTypes", func() {
+		BeforeEach(func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+		})
+
+		It("resets the vm types", func() {
+			err := service.DeleteCustomVMTypes()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("DELETE"))
+			Expect(req.URL.Path).To(Equal("/api/v0/vm_types"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(&http.Response{}, errors.New("api endpoint failed"))
+
+				err := service.DeleteCustomVMTypes()
+				Expect(err).To(MatchError("could not make api request to vm_types endpoint: api endpoint failed"))
+			})
+
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				err := service.DeleteCustomVMTypes()
+				Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+			})
+		})
+	})
+})
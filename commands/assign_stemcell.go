@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+type AssignStemcell struct {
+	service assignStemcellService
+	logger  logger
+	Options struct {
+		ProductName     string `long:"product-name"     short:"p" required:"true" description:"name of product"`
+		StemcellVersion string `long:"stemcell-version" short:"s" required:"true" description:"version of an uploaded stemcell to assign, or latest for the newest compatible version"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/assign_stemcell_service.go --fake-name AssignStemcellService . assignStemcellService
+type assignStemcellService interface {
+	ListStemcellAssignments() (api.StemcellAssignmentsOutput, error)
+	AssignStemcell(input api.AssignStemcellInput) error
+}
+
+func NewAssignStemcell(service assignStemcellService, logger logger) AssignStemcell {
+	return AssignStemcell{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (as AssignStemcell) Execute(args []string) error {
+	if _, err := jhanda.Parse(&as.Options, args); err != nil {
+		return fmt.Errorf("could not parse assign-stemcell flags: %s", err)
+	}
+
+	assignments, err := as.service.ListStemcellAssignments()
+	if err != nil {
+		return fmt.Errorf("failed to list stemcell assignments: %s", err)
+	}
+
+	var product *api.ProductStemcells
+	for i := range assignments.Products {
+		if assignments.Products[i].ProductName == as.Options.ProductName {
+			product = &assignments.Products[i]
+			break
+		}
+	}
+
+	if product == nil {
+		return fmt.Errorf("could not find product %s, it must be staged before a stemcell can be assigned", as.Options.ProductName)
+	}
+
+	version := as.Options.StemcellVersion
+	if version == "latest" {
+		version = ""
+		for _, available := range product.AvailableVersions {
+			if product.RequiredStemcellVersion != "" && !stemcellSatisfies(product.RequiredStemcellVersion, available) {
+				continue
+			}
+
+			if version == "" || compareVersions(available, version) > 0 {
+				version = available
+			}
+		}
+
+		if version == "" {
+			return fmt.Errorf("no uploaded stemcell is compatible with %s, upload one with upload-stemcell first", product.ProductName)
+		}
+	}
+
+	if product.RequiredStemcellVersion != "" && !stemcellSatisfies(product.RequiredStemcellVersion, version) {
+		return fmt.Errorf("stemcell version %s does not meet the stemcell criteria of %s, which requires %s", version, product.ProductName, requiredStemcell(*product))
+	}
+
+	if !contains(product.AvailableVersions, version) {
+		return fmt.Errorf("stemcell version %s is not available for %s, upload it with upload-stemcell first (available versions: %s)", version, product.ProductName, strings.Join(product.AvailableVersions, ", "))
+	}
+
+	err = as.service.AssignStemcell(api.AssignStemcellInput{
+		Products: []api.StemcellAssignment{{
+			GUID:                  product.GUID,
+			StagedStemcellVersion: version,
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign stemcell %s to %s: %s", version, product.ProductName, err)
+	}
+
+	as.logger.Printf("assigned stemcell %s to %s", version, product.ProductName)
+
+	return nil
+}
+
+func (as AssignStemcell) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command assigns an uploaded stemcell to a staged product, after checking that it meets the stemcell criteria of the product.",
+		ShortDescription: "assigns an uploaded stemcell to a product",
+		Flags:            as.Options,
+	}
+}
+
+// stemcellSatisfies reports whether a stemcell version is on the same major
+// line as the required version, and no older than it. A product requiring
+// 97.18 can use 97.18 and 97.20, but not 97.9 or 170.1.
+func stemcellSatisfies(required, version string) bool {
+	if strings.SplitN(required, ".", 2)[0] != strings.SplitN(version, ".", 2)[0] {
+		return false
+	}
+
+	return compareVersions(version, required) >= 0
+}
+
+func requiredStemcell(product api.ProductStemcells) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s or later", product.RequiredStemcellOS, product.RequiredStemcellVersion))
+}
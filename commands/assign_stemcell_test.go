@@ -0,0 +1,143 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("AssignStemcell", func() {
+	var (
+		fakeService *fakes.AssignStemcellService
+		logger      *fakes.Logger
+		command     commands.AssignStemcell
+	)
+
+	BeforeEach(func() {
+		fakeService = &fakes.AssignStemcellService{}
+		logger = &fakes.Logger{}
+		command = commands.NewAssignStemcell(fakeService, logger)
+
+		fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{
+			Products: []api.ProductStemcells{
+				{
+					GUID:        "p-redis-guid",
+					ProductName: "p-redis",
+				},
+				{
+					GUID:                    "cf-guid",
+					ProductName:             "cf",
+					StagedStemcellVersion:   "97.28",
+					RequiredStemcellVersion: "97.18",
+					RequiredStemcellOS:      "ubuntu-xenial",
+					AvailableVersions:       []string{"97.9", "97.18", "97.28", "170.1"},
+				},
+			},
+		}, nil)
+	})
+
+	It("assigns the stemcell to the product", func() {
+		err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "97.18"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeService.AssignStemcellCallCount()).To(Equal(1))
+		Expect(fakeService.AssignStemcellArgsForCall(0)).To(Equal(api.AssignStemcellInput{
+			Products: []api.StemcellAssignment{{
+				GUID:                  "cf-guid",
+				StagedStemcellVersion: "97.18",
+			}},
+		}))
+
+		format, content := logger.PrintfArgsForCall(0)
+		Expect(fmt.Sprintf(format, content...)).To(Equal("assigned stemcell 97.18 to cf"))
+	})
+
+	Context("when the stemcell version is latest", func() {
+		It("assigns the newest compatible stemcell", func() {
+			err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "latest"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.AssignStemcellArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal("97.28"))
+		})
+
+		It("returns an error when no stemcell is available", func() {
+			err := command.Execute([]string{"--product-name", "p-redis", "--stemcell-version", "latest"})
+			Expect(err).To(MatchError("no uploaded stemcell is compatible with p-redis, upload one with upload-stemcell first"))
+		})
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse assign-stemcell flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the stemcell assignments cannot be listed", func() {
+			It("returns an error", func() {
+				fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "97.18"})
+				Expect(err).To(MatchError("failed to list stemcell assignments: some error"))
+			})
+		})
+
+		Context("when the product is not staged", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--product-name", "p-mysql", "--stemcell-version", "97.18"})
+				Expect(err).To(MatchError("could not find product p-mysql, it must be staged before a stemcell can be assigned"))
+			})
+		})
+
+		Context("when the stemcell is older than the required version", func() {
+			It("returns an error without assigning it", func() {
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "97.9"})
+				Expect(err).To(MatchError("stemcell version 97.9 does not meet the stemcell criteria of cf, which requires ubuntu-xenial 97.18 or later"))
+				Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the stemcell is on a different major version", func() {
+			It("returns an error without assigning it", func() {
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "170.1"})
+				Expect(err).To(MatchError("stemcell version 170.1 does not meet the stemcell criteria of cf, which requires ubuntu-xenial 97.18 or later"))
+				Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the stemcell has not been uploaded", func() {
+			It("returns an error without assigning it", func() {
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "97.30"})
+				Expect(err).To(MatchError("stemcell version 97.30 is not available for cf, upload it with upload-stemcell first (available versions: 97.9, 97.18, 97.28, 170.1)"))
+				Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the stemcell cannot be assigned", func() {
+			It("returns an error", func() {
+				fakeService.AssignStemcellReturns(errors.New("some error"))
+
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell-version", "97.18"})
+				Expect(err).To(MatchError("failed to assign stemcell 97.18 to cf: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command assigns an uploaded stemcell to a staged product, after checking that it meets the stemcell criteria of the product.",
+				ShortDescription: "assigns an uploaded stemcell to a product",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+//go:generate counterfeiter -o ./fakes/bosh_diff_service.go --fake-name BoshDiffService . boshDiffService
+type boshDiffService interface {
+	GetStagedProductByName(product string) (api.StagedProductsFindOutput, error)
+	GetStagedProductManifest(guid string) (string, error)
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	GetDeployedProductManifest(guid string) (string, error)
+	GetStagedDirectorManifest() (string, error)
+	GetDeployedDirectorManifest() (string, error)
+}
+
+type BoshDiff struct {
+	service boshDiffService
+	logger  logger
+	Options struct {
+		ProductName string `long:"product-name" short:"p" description:"name of product"`
+		Director    bool   `long:"director"     short:"d" description:"compare the director manifest instead of a product manifest"`
+	}
+}
+
+func NewBoshDiff(service boshDiffService, logger logger) BoshDiff {
+	return BoshDiff{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (bd BoshDiff) Execute(args []string) error {
+	if _, err := jhanda.Parse(&bd.Options, args); err != nil {
+		return fmt.Errorf("could not parse bosh-diff flags: %s", err)
+	}
+
+	if bd.Options.ProductName == "" && !bd.Options.Director {
+		return errors.New("either --product-name or --director must be provided")
+	}
+
+	if bd.Options.ProductName != "" && bd.Options.Director {
+		return errors.New("--product-name and --director cannot be provided together")
+	}
+
+	var (
+		stagedManifest   string
+		deployedManifest string
+		err              error
+	)
+
+	if bd.Options.Director {
+		stagedManifest, err = bd.service.GetStagedDirectorManifest()
+		if err != nil {
+			return fmt.Errorf("failed to fetch staged director manifest: %s", err)
+		}
+
+		deployedManifest, err = bd.service.GetDeployedDirectorManifest()
+		if err != nil {
+			return fmt.Errorf("failed to fetch deployed director manifest: %s", err)
+		}
+	} else {
+		stagedManifest, deployedManifest, err = bd.productManifests()
+		if err != nil {
+			return err
+		}
+	}
+
+	differences, err := diffManifests(deployedManifest, stagedManifest)
+	if err != nil {
+		return err // un-tested
+	}
+
+	if len(differences) == 0 {
+		bd.logger.Printf("no differences between the staged and deployed manifests")
+		return nil
+	}
+
+	for _, difference := range differences {
+		bd.logger.Printf("%s", formatManifestDifference(difference))
+	}
+
+	return nil
+}
+
+func (bd BoshDiff) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command prints the differences between the deployed and staged manifests of a product or the director, showing what the next apply-changes will change",
+		ShortDescription: "prints the differences between the deployed and staged manifests",
+		Flags:            bd.Options,
+	}
+}
+
+func (bd BoshDiff) productManifests() (string, string, error) {
+	output, err := bd.service.GetStagedProductByName(bd.Options.ProductName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find product: %s", err)
+	}
+
+	stagedManifest, err := bd.service.GetStagedProductManifest(output.Product.GUID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch staged product manifest: %s", err)
+	}
+
+	deployedProducts, err := bd.service.ListDeployedProducts()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list deployed products: %s", err)
+	}
+
+	for _, deployedProduct := range deployedProducts {
+		if deployedProduct.Type == bd.Options.ProductName {
+			deployedManifest, err := bd.service.GetDeployedProductManifest(deployedProduct.GUID)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to fetch deployed product manifest: %s", err)
+			}
+
+			return stagedManifest, deployedManifest, nil
+		}
+	}
+
+	// the product has never been deployed, so everything in the staged
+	// manifest is new
+	return stagedManifest, "", nil
+}
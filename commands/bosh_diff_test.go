@@ -0,0 +1,276 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const deployedManifest = `---
+name: cf-some-guid
+instance_groups:
+- name: router
+  instances: 2
+  jobs:
+  - name: gorouter
+    properties:
+      router:
+        port: 80
+        status:
+          password: old-password
+- name: diego_cell
+  instances: 3
+- name: mysql_proxy
+  instances: 1
+releases:
+- name: routing
+  version: 0.170.0
+tags: [a, b]
+`
+
+const stagedManifest = `---
+name: cf-some-guid
+instance_groups:
+- name: diego_cell
+  instances: 3
+- name: router
+  instances: 3
+  jobs:
+  - name: gorouter
+    properties:
+      router:
+        port: 8080
+        status:
+          password: new-password
+- name: tcp_router
+  instances: 1
+releases:
+- name: routing
+  version: 0.171.0
+tags: [a, c]
+`
+
+var _ = Describe("BoshDiff", func() {
+	var (
+		service *fakes.BoshDiffService
+		logger  *fakes.Logger
+		command commands.BoshDiff
+	)
+
+	logLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		service = &fakes.BoshDiffService{}
+		logger = &fakes.Logger{}
+		command = commands.NewBoshDiff(service, logger)
+
+		service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+			Product: api.StagedProduct{GUID: "some-product-guid", Type: "cf"},
+		}, nil)
+		service.GetStagedProductManifestReturns(stagedManifest, nil)
+		service.ListDeployedProductsReturns([]api.DeployedProductOutput{
+			{Type: "p-bosh", GUID: "some-director-guid"},
+			{Type: "cf", GUID: "some-deployed-product-guid"},
+		}, nil)
+		service.GetDeployedProductManifestReturns(deployedManifest, nil)
+	})
+
+	Describe("Execute", func() {
+		It("prints a structural diff of the product manifests with instance groups aligned by name", func() {
+			err := command.Execute([]string{"--product-name", "cf"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetStagedProductByNameArgsForCall(0)).To(Equal("cf"))
+			Expect(service.GetStagedProductManifestArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(service.GetDeployedProductManifestArgsForCall(0)).To(Equal("some-deployed-product-guid"))
+
+			Expect(logLines()).To(Equal([]string{
+				"~ instance_groups[router].instances: 2 -> 3",
+				"~ instance_groups[router].jobs[gorouter].properties.router.port: 80 -> 8080",
+				"~ instance_groups[router].jobs[gorouter].properties.router.status.password: <redacted> -> <redacted>",
+				"+ instance_groups[tcp_router]:\n    instances: 1\n    name: tcp_router",
+				"- instance_groups[mysql_proxy]:\n    instances: 1\n    name: mysql_proxy",
+				"~ releases[routing].version: 0.170.0 -> 0.171.0",
+				"~ tags[1]: b -> c",
+			}))
+		})
+
+		Context("when the product has not been deployed", func() {
+			It("shows everything in the staged manifest as added", func() {
+				service.ListDeployedProductsReturns([]api.DeployedProductOutput{}, nil)
+				service.GetStagedProductManifestReturns("---\nname: cf-some-guid\n", nil)
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.GetDeployedProductManifestCallCount()).To(Equal(0))
+				Expect(logLines()).To(Equal([]string{"+ name: cf-some-guid"}))
+			})
+		})
+
+		Context("when there are no differences", func() {
+			It("says so", func() {
+				service.GetDeployedProductManifestReturns(stagedManifest, nil)
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logLines()).To(Equal([]string{"no differences between the staged and deployed manifests"}))
+			})
+		})
+
+		Context("when values look like credentials", func() {
+			It("redacts them whatever the spelling of the key", func() {
+				service.GetDeployedProductManifestReturns(`---
+properties:
+  sslPrivateKey: old-key
+  signingKey: old-key
+  serviceProviderKey: old-key
+  key: old-key
+  private_key_pem: old-key
+  ca: "-----BEGIN CERTIFICATE-----\nold\n-----END CERTIFICATE-----"
+  keepalive: 10
+`, nil)
+				service.GetStagedProductManifestReturns(`---
+properties:
+  sslPrivateKey: new-key
+  signingKey: new-key
+  serviceProviderKey: new-key
+  key: new-key
+  private_key_pem: new-key
+  ca: "-----BEGIN CERTIFICATE-----\nnew\n-----END CERTIFICATE-----"
+  keepalive: 20
+  saml:
+    entity_id: some-id
+    signing-key: new-key
+`, nil)
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logLines()).To(Equal([]string{
+					"~ properties.ca: <redacted> -> <redacted>",
+					"~ properties.keepalive: 10 -> 20",
+					"~ properties.key: <redacted> -> <redacted>",
+					"~ properties.private_key_pem: <redacted> -> <redacted>",
+					"+ properties.saml:\n    entity_id: some-id\n    signing-key: <redacted>",
+					"~ properties.serviceProviderKey: <redacted> -> <redacted>",
+					"~ properties.signingKey: <redacted> -> <redacted>",
+					"~ properties.sslPrivateKey: <redacted> -> <redacted>",
+				}))
+			})
+		})
+
+		Context("when --director is provided", func() {
+			It("compares the director manifests", func() {
+				service.GetStagedDirectorManifestReturns("---\nname: p-bosh\ninstance_groups:\n- name: bosh\n  instances: 1\n  properties:\n    director:\n      secret: new\n", nil)
+				service.GetDeployedDirectorManifestReturns("---\nname: p-bosh\ninstance_groups:\n- name: bosh\n  instances: 1\n  properties:\n    director:\n      secret: old\n", nil)
+
+				err := command.Execute([]string{"--director"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.GetStagedProductManifestCallCount()).To(Equal(0))
+				Expect(logLines()).To(Equal([]string{
+					"~ instance_groups[bosh].properties.director.secret: <redacted> -> <redacted>",
+				}))
+			})
+
+			It("returns an error when the staged manifest cannot be fetched", func() {
+				service.GetStagedDirectorManifestReturns("", errors.New("boom"))
+
+				err := command.Execute([]string{"--director"})
+				Expect(err).To(MatchError("failed to fetch staged director manifest: boom"))
+			})
+
+			It("returns an error when the deployed manifest cannot be fetched", func() {
+				service.GetDeployedDirectorManifestReturns("", errors.New("boom"))
+
+				err := command.Execute([]string{"--director"})
+				Expect(err).To(MatchError("failed to fetch deployed director manifest: boom"))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse bosh-diff flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when neither a product nor the director is given", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("either --product-name or --director must be provided"))
+				})
+			})
+
+			Context("when both a product and the director are given", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--product-name", "cf", "--director"})
+					Expect(err).To(MatchError("--product-name and --director cannot be provided together"))
+				})
+			})
+
+			Context("when the product cannot be found", func() {
+				It("returns an error", func() {
+					service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("could not find product \"cf\""))
+
+					err := command.Execute([]string{"--product-name", "cf"})
+					Expect(err).To(MatchError("failed to find product: could not find product \"cf\""))
+				})
+			})
+
+			Context("when the staged manifest cannot be fetched", func() {
+				It("returns an error", func() {
+					service.GetStagedProductManifestReturns("", errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "cf"})
+					Expect(err).To(MatchError("failed to fetch staged product manifest: boom"))
+				})
+			})
+
+			Context("when the deployed products cannot be listed", func() {
+				It("returns an error", func() {
+					service.ListDeployedProductsReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "cf"})
+					Expect(err).To(MatchError("failed to list deployed products: boom"))
+				})
+			})
+
+			Context("when the deployed manifest cannot be fetched", func() {
+				It("returns an error", func() {
+					service.GetDeployedProductManifestReturns("", errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "cf"})
+					Expect(err).To(MatchError("failed to fetch deployed product manifest: boom"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command prints the differences between the deployed and staged manifests of a product or the director, showing what the next apply-changes will change",
+				ShortDescription: "prints the differences between the deployed and staged manifests",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
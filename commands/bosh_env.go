@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const boshDirectorPort = 25555
+
+//go:generate counterfeiter -o ./fakes/bosh_env_service.go --fake-name BoshEnvService . boshEnvService
+type boshEnvService interface {
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	GetDeployedProductCredential(api.GetDeployedProductCredentialInput) (api.GetDeployedProductCredentialOutput, error)
+	GetSecurityRootCACertificate() (string, error)
+	GetDeployedDirectorManifest() (string, error)
+}
+
+type BoshEnv struct {
+	service boshEnvService
+	logger  logger
+	Options struct {
+		Output              string `long:"output"               short:"o" default:"shell"                                     description:"format of the output (options: shell, json, config)"`
+		Alias               string `long:"alias"                short:"a" default:"opsman"                                    description:"environment alias used in the bosh CLI config"`
+		CredentialReference string `long:"credential-reference" short:"c" default:".director.ops_manager_client_credentials" description:"credential of the director with the client id and secret"`
+	}
+}
+
+func NewBoshEnv(service boshEnvService, logger logger) BoshEnv {
+	return BoshEnv{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (be BoshEnv) Execute(args []string) error {
+	if _, err := jhanda.Parse(&be.Options, args); err != nil {
+		return fmt.Errorf("could not parse bosh-env flags: %s", err)
+	}
+
+	switch be.Options.Output {
+	case "shell", "json", "config":
+	default:
+		return fmt.Errorf("unknown output %q, the supported outputs are shell, json and config", be.Options.Output)
+	}
+
+	env, err := be.boshEnvironment()
+	if err != nil {
+		return err
+	}
+
+	switch be.Options.Output {
+	case "json":
+		output, err := json.MarshalIndent(env, "", "  ")
+		if err != nil {
+			return err // un-tested
+		}
+
+		be.logger.Println(string(output))
+	case "config":
+		output, err := yaml.Marshal(boshConfig(env, be.Options.Alias))
+		if err != nil {
+			return err // un-tested
+		}
+
+		be.logger.Printf("%s", output)
+	default:
+		var names []string
+		for name := range env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			be.logger.Printf("export %s=%s", name, shellQuote(env[name]))
+		}
+	}
+
+	return nil
+}
+
+func (be BoshEnv) boshEnvironment() (map[string]string, error) {
+	deployedProducts, err := be.service.ListDeployedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch director credentials: %s", err)
+	}
+
+	var directorGUID string
+	for _, product := range deployedProducts {
+		if product.Type == "p-bosh" {
+			directorGUID = product.GUID
+			break
+		}
+	}
+
+	if directorGUID == "" {
+		return nil, errors.New("failed to fetch director credentials: the director has not been deployed")
+	}
+
+	credential, err := be.service.GetDeployedProductCredential(api.GetDeployedProductCredentialInput{
+		DeployedGUID:        directorGUID,
+		CredentialReference: be.Options.CredentialReference,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch director credentials: %s", err)
+	}
+
+	client, secret := credential.Credential.Value["identity"], credential.Credential.Value["password"]
+	if client == "" || secret == "" {
+		return nil, fmt.Errorf("failed to fetch director credentials: %q does not have an identity and password", be.Options.CredentialReference)
+	}
+
+	caCert, err := be.service.GetSecurityRootCACertificate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch root CA certificate: %s", err)
+	}
+
+	manifest, err := be.service.GetDeployedDirectorManifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch director manifest: %s", err)
+	}
+
+	address, err := directorAddress(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"BOSH_ENVIRONMENT":   address,
+		"BOSH_CA_CERT":       caCert,
+		"BOSH_CLIENT":        client,
+		"BOSH_CLIENT_SECRET": secret,
+	}, nil
+}
+
+// directorAddress returns the first static IP of the bosh instance group in
+// the director manifest.
+func directorAddress(manifest string) (string, error) {
+	var contents struct {
+		InstanceGroups []struct {
+			Name     string `yaml:"name"`
+			Networks []struct {
+				StaticIPs []string `yaml:"static_ips"`
+			} `yaml:"networks"`
+		} `yaml:"instance_groups"`
+	}
+
+	err := yaml.Unmarshal([]byte(manifest), &contents)
+	if err != nil {
+		return "", fmt.Errorf("could not parse director manifest: %s", err)
+	}
+
+	for _, instanceGroup := range contents.InstanceGroups {
+		if instanceGroup.Name != "bosh" {
+			continue
+		}
+
+		for _, network := range instanceGroup.Networks {
+			if len(network.StaticIPs) > 0 {
+				return network.StaticIPs[0], nil
+			}
+		}
+	}
+
+	return "", errors.New("could not find the director address in the director manifest")
+}
+
+// boshConfig returns a bosh CLI config with the director as its only
+// environment. The bosh CLI does not keep client credentials in its config,
+// so BOSH_CLIENT and BOSH_CLIENT_SECRET still have to be exported.
+func boshConfig(env map[string]string, alias string) map[string]interface{} {
+	return map[string]interface{}{
+		"environments": []map[string]string{
+			{
+				"alias":   alias,
+				"url":     fmt.Sprintf("https://%s:%d", env["BOSH_ENVIRONMENT"], boshDirectorPort),
+				"ca_cert": env["BOSH_CA_CERT"],
+			},
+		},
+	}
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+func (be BoshEnv) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command prints the environment variables for the bosh CLI to target the director deployed by Ops Manager, as shell exports, JSON or a bosh CLI config.",
+		ShortDescription: "prints the environment variables to target the director with the bosh CLI",
+		Flags:            be.Options,
+	}
+}
@@ -0,0 +1,204 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const directorManifest = `---
+name: p-bosh
+instance_groups:
+- name: bosh
+  networks:
+  - name: infrastructure
+    static_ips:
+    - 10.0.0.5
+`
+
+var _ = Describe("BoshEnv", func() {
+	var (
+		service *fakes.BoshEnvService
+		logger  *fakes.Logger
+		command commands.BoshEnv
+	)
+
+	BeforeEach(func() {
+		service = &fakes.BoshEnvService{}
+		logger = &fakes.Logger{}
+		command = commands.NewBoshEnv(service, logger)
+
+		service.ListDeployedProductsReturns([]api.DeployedProductOutput{
+			{Type: "cf", GUID: "cf-some-guid"},
+			{Type: "p-bosh", GUID: "p-bosh-some-guid"},
+		}, nil)
+		service.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{
+			Credential: api.Credential{
+				Type: "simple_credentials",
+				Value: map[string]string{
+					"identity": "ops_manager",
+					"password": "some-secret",
+				},
+			},
+		}, nil)
+		service.GetSecurityRootCACertificateReturns("-----BEGIN CERTIFICATE-----\nsome-cert\n-----END CERTIFICATE-----\n", nil)
+		service.GetDeployedDirectorManifestReturns(directorManifest, nil)
+	})
+
+	Describe("Execute", func() {
+		It("prints shell exports", func() {
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetDeployedProductCredentialArgsForCall(0)).To(Equal(api.GetDeployedProductCredentialInput{
+				DeployedGUID:        "p-bosh-some-guid",
+				CredentialReference: ".director.ops_manager_client_credentials",
+			}))
+
+			Expect(logger.PrintfCallCount()).To(Equal(4))
+
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, args := logger.PrintfArgsForCall(i)
+				Expect(format).To(Equal("export %s=%s"))
+				lines = append(lines, args[0].(string)+"="+args[1].(string))
+			}
+
+			Expect(lines).To(Equal([]string{
+				"BOSH_CA_CERT='-----BEGIN CERTIFICATE-----\nsome-cert\n-----END CERTIFICATE-----\n'",
+				"BOSH_CLIENT='ops_manager'",
+				"BOSH_CLIENT_SECRET='some-secret'",
+				"BOSH_ENVIRONMENT='10.0.0.5'",
+			}))
+		})
+
+		It("quotes single quotes in shell exports", func() {
+			service.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{
+				Credential: api.Credential{
+					Value: map[string]string{"identity": "ops_manager", "password": "it's-secret"},
+				},
+			}, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, args := logger.PrintfArgsForCall(2)
+			Expect(args).To(Equal([]interface{}{"BOSH_CLIENT_SECRET", `'it'\''s-secret'`}))
+		})
+
+		It("prints JSON", func() {
+			err := command.Execute([]string{"--output", "json"})
+			Expect(err).NotTo(HaveOccurred())
+
+			output := logger.PrintlnArgsForCall(0)
+			Expect(output[0]).To(MatchJSON(`{
+				"BOSH_ENVIRONMENT": "10.0.0.5",
+				"BOSH_CA_CERT": "-----BEGIN CERTIFICATE-----\nsome-cert\n-----END CERTIFICATE-----\n",
+				"BOSH_CLIENT": "ops_manager",
+				"BOSH_CLIENT_SECRET": "some-secret"
+			}`))
+		})
+
+		It("prints a bosh CLI config", func() {
+			err := command.Execute([]string{"--output", "config", "--alias", "some-foundation"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, args := logger.PrintfArgsForCall(0)
+			Expect(string(args[0].([]byte))).To(MatchYAML(`
+environments:
+- alias: some-foundation
+  url: https://10.0.0.5:25555
+  ca_cert: |
+    -----BEGIN CERTIFICATE-----
+    some-cert
+    -----END CERTIFICATE-----
+`))
+		})
+
+		It("uses the given credential reference", func() {
+			err := command.Execute([]string{"--credential-reference", ".director.some_credentials"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetDeployedProductCredentialArgsForCall(0).CredentialReference).To(Equal(".director.some_credentials"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the flags cannot be parsed", func() {
+				err := command.Execute([]string{"--bad-flag"})
+				Expect(err).To(MatchError("could not parse bosh-env flags: flag provided but not defined: -bad-flag"))
+			})
+
+			It("returns an error for an unknown output", func() {
+				err := command.Execute([]string{"--output", "yaml"})
+				Expect(err).To(MatchError(`unknown output "yaml", the supported outputs are shell, json and config`))
+			})
+
+			It("returns an error when the deployed products cannot be listed", func() {
+				service.ListDeployedProductsReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to fetch director credentials: some error"))
+			})
+
+			It("returns an error when the director has not been deployed", func() {
+				service.ListDeployedProductsReturns([]api.DeployedProductOutput{}, nil)
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to fetch director credentials: the director has not been deployed"))
+			})
+
+			It("returns an error when the credential cannot be fetched", func() {
+				service.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to fetch director credentials: some error"))
+			})
+
+			It("returns an error when the credential has no identity and password", func() {
+				service.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{
+					Credential: api.Credential{Value: map[string]string{"private_key_pem": "some-key"}},
+				}, nil)
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError(`failed to fetch director credentials: ".director.ops_manager_client_credentials" does not have an identity and password`))
+			})
+
+			It("returns an error when the root CA certificate cannot be fetched", func() {
+				service.GetSecurityRootCACertificateReturns("", errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to fetch root CA certificate: some error"))
+			})
+
+			It("returns an error when the director manifest cannot be fetched", func() {
+				service.GetDeployedDirectorManifestReturns("", errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to fetch director manifest: some error"))
+			})
+
+			It("returns an error when the director manifest has no address", func() {
+				service.GetDeployedDirectorManifestReturns("instance_groups: [{name: bosh}]", nil)
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("could not find the director address in the director manifest"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command prints the environment variables for the bosh CLI to target the director deployed by Ops Manager, as shell exports, JSON or a bosh CLI config.",
+				ShortDescription: "prints the environment variables to target the director with the bosh CLI",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
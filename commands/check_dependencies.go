@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/extractor"
+)
+
+type CheckDependencies struct {
+	metadataExtractor metadataExtractor
+	service           checkDependenciesService
+	logger            logger
+	Options           struct {
+		Products []string `long:"product" short:"p" required:"true" description:"path or http(s) url of product, can be given more than once to check products that will be staged together"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/check_dependencies_service.go --fake-name CheckDependenciesService . checkDependenciesService
+type checkDependenciesService interface {
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+}
+
+func NewCheckDependencies(metadataExtractor metadataExtractor, service checkDependenciesService, logger logger) CheckDependencies {
+	return CheckDependencies{
+		metadataExtractor: metadataExtractor,
+		service:           service,
+		logger:            logger,
+	}
+}
+
+func (cd CheckDependencies) Execute(args []string) error {
+	if _, err := jhanda.Parse(&cd.Options, args); err != nil {
+		return fmt.Errorf("could not parse check-dependencies flags: %s", err)
+	}
+
+	report, err := cd.service.GetDiagnosticReport()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve staged and deployed products: %s", err)
+	}
+
+	available := installedProductVersions(report)
+
+	var products []extractor.Metadata
+	for _, product := range cd.Options.Products {
+		metadata, err := cd.metadataExtractor.ExtractMetadata(product)
+		if err != nil {
+			return fmt.Errorf("failed to extract product metadata from %s: %s", product, err)
+		}
+
+		products = append(products, metadata)
+		available = append(available, extractor.ProductVersion{Name: metadata.Name, Version: metadata.Version})
+		available = append(available, metadata.ProvidesProductVersions...)
+	}
+
+	var errs []string
+	for _, metadata := range products {
+		if len(metadata.RequiresProductVersions) == 0 {
+			cd.logger.Printf("%s %s does not require other products", metadata.Name, metadata.Version)
+			continue
+		}
+
+		err := checkProductDependencies(metadata, available)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		cd.logger.Printf("%s %s: all required products are staged or deployed", metadata.Name, metadata.Version)
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func (cd CheckDependencies) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command checks that the products required by a product file are staged or deployed at compatible versions, or are among the other product files given.",
+		ShortDescription: "checks that the products a product requires are staged or deployed",
+		Flags:            cd.Options,
+	}
+}
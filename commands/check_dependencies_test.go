@@ -0,0 +1,158 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+)
+
+var _ = Describe("CheckDependencies", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		fakeService       *fakes.CheckDependenciesService
+		logger            *fakes.Logger
+		command           commands.CheckDependencies
+		metadata          map[string]extractor.Metadata
+	)
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		fakeService = &fakes.CheckDependenciesService{}
+		logger = &fakes.Logger{}
+		command = commands.NewCheckDependencies(metadataExtractor, fakeService, logger)
+
+		metadata = map[string]extractor.Metadata{
+			"p-redis.pivotal": {
+				Name:    "p-redis",
+				Version: "1.14.2",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "p-bosh", Version: "~> 2.2"},
+					{Name: "cf", Version: "~> 2.2"},
+				},
+			},
+			"srt.pivotal": {
+				Name:    "srt",
+				Version: "2.2.4",
+				ProvidesProductVersions: []extractor.ProductVersion{
+					{Name: "cf", Version: "2.2.4"},
+				},
+			},
+			"p-spring-cloud-services.pivotal": {
+				Name:    "p-spring-cloud-services",
+				Version: "2.0.1",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "p-mysql", Version: "~> 2.3.0"},
+					{Name: "p-rabbitmq", Version: ">= 1.10"},
+				},
+			},
+		}
+		metadataExtractor.ExtractMetadataStub = func(path string) (extractor.Metadata, error) {
+			return metadata[path], nil
+		}
+
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.2.3"},
+				{Name: "cf", Version: "2.2.1"},
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.1.9"},
+				{Name: "p-mysql", Version: "2.2.5"},
+			},
+		}, nil)
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	It("succeeds when the required products are staged or deployed", func() {
+		err := command.Execute([]string{"--product", "p-redis.pivotal"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(loggedLines()).To(Equal([]string{"p-redis 1.14.2: all required products are staged or deployed"}))
+	})
+
+	It("counts the products provided by the other product files", func() {
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			StagedProducts: []api.DiagnosticProduct{{Name: "p-bosh", Version: "2.2.3"}},
+		}, nil)
+
+		err := command.Execute([]string{"--product", "p-redis.pivotal", "--product", "srt.pivotal"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(loggedLines()).To(Equal([]string{
+			"p-redis 1.14.2: all required products are staged or deployed",
+			"srt 2.2.4 does not require other products",
+		}))
+	})
+
+	It("returns an error listing the missing products of every product file", func() {
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			StagedProducts: []api.DiagnosticProduct{{Name: "p-bosh", Version: "2.1.9"}},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "p-mysql", Version: "2.2.5"},
+				{Name: "p-mysql", Version: "2.4.0"},
+			},
+		}, nil)
+
+		err := command.Execute([]string{"--product", "p-redis.pivotal", "--product", "p-spring-cloud-services.pivotal"})
+		Expect(err).To(MatchError(`p-redis 1.14.2 requires products that are not staged or deployed:
+- p-bosh ~> 2.2 (found 2.1.9)
+- cf ~> 2.2 (not found)
+p-spring-cloud-services 2.0.1 requires products that are not staged or deployed:
+- p-mysql ~> 2.3.0 (found 2.2.5, 2.4.0)
+- p-rabbitmq >= 1.10 (not found)`))
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse check-dependencies flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the diagnostic report cannot be fetched", func() {
+			It("returns an error", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product", "p-redis.pivotal"})
+				Expect(err).To(MatchError("failed to retrieve staged and deployed products: some error"))
+			})
+		})
+
+		Context("when the metadata cannot be extracted", func() {
+			It("returns an error", func() {
+				metadataExtractor.ExtractMetadataStub = nil
+				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product", "p-redis.pivotal"})
+				Expect(err).To(MatchError("failed to extract product metadata from p-redis.pivotal: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command checks that the products required by a product file are staged or deployed at compatible versions, or are among the other product files given.",
+				ShortDescription: "checks that the products a product requires are staged or deployed",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,355 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter -o ./fakes/check_drift_service.go --fake-name CheckDriftService . checkDriftService
+type checkDriftService interface {
+	GetStagedProductByName(product string) (api.StagedProductsFindOutput, error)
+	GetStagedProductProperties(product string) (map[string]api.ResponseProperty, error)
+	GetStagedProductNetworksAndAZs(product string) (map[string]interface{}, error)
+	ListStagedProductJobs(productGUID string) (map[string]string, error)
+	GetStagedProductJobResourceConfig(productGUID, jobGUID string) (api.JobProperties, error)
+}
+
+// productDriftService is the part of the api needed to compare a product
+// config with the staged product.
+type productDriftService interface {
+	GetStagedProductProperties(product string) (map[string]api.ResponseProperty, error)
+	GetStagedProductNetworksAndAZs(product string) (map[string]interface{}, error)
+	ListStagedProductJobs(productGUID string) (map[string]string, error)
+	GetStagedProductJobResourceConfig(productGUID, jobGUID string) (api.JobProperties, error)
+}
+
+type CheckDrift struct {
+	service checkDriftService
+	logger  logger
+	Options struct {
+		ProductName string   `long:"product-name" short:"p" required:"true" description:"name of product"`
+		ConfigFile  string   `long:"config"       short:"c" required:"true" description:"path to yml file containing the desired product config, in the format accepted by configure-product"`
+		VarsFiles   []string `long:"vars-file"    short:"l"                 description:"path to yml file with values for ((placeholders)) in the config file (can be given more than once)"`
+	}
+}
+
+type driftReport struct {
+	differences []string
+	skipped     []string
+}
+
+func NewCheckDrift(service checkDriftService, logger logger) CheckDrift {
+	return CheckDrift{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (cd CheckDrift) Execute(args []string) error {
+	if _, err := jhanda.Parse(&cd.Options, args); err != nil {
+		return fmt.Errorf("could not parse check-drift flags: %s", err)
+	}
+
+	vars, err := loadVars(cd.Options.VarsFiles)
+	if err != nil {
+		return err
+	}
+
+	configContents, err := ioutil.ReadFile(cd.Options.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	var config map[string]interface{}
+	err = yaml.Unmarshal(configContents, &config)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed as valid configuration: %s", cd.Options.ConfigFile, err)
+	}
+
+	for section, value := range config {
+		config[section] = interpolateVars(value, vars)
+	}
+
+	output, err := cd.service.GetStagedProductByName(cd.Options.ProductName)
+	if err != nil {
+		return fmt.Errorf("failed to find product: %s", err)
+	}
+	productGUID := output.Product.GUID
+
+	report := &driftReport{}
+
+	err = checkProductDrift(cd.service, report, cd.Options.ProductName, productGUID, config)
+	if err != nil {
+		return err
+	}
+
+	if len(report.skipped) > 0 {
+		cd.logger.Printf("skipped %d values that cannot be compared:", len(report.skipped))
+		for _, skipped := range report.skipped {
+			cd.logger.Printf("  %s", skipped)
+		}
+	}
+
+	if len(report.differences) > 0 {
+		cd.logger.Printf("found %d differences between %s and the staged %s product:", len(report.differences), cd.Options.ConfigFile, cd.Options.ProductName)
+		for _, difference := range report.differences {
+			cd.logger.Printf("  %s", difference)
+		}
+
+		return fmt.Errorf("staged product %s has drifted from %s", cd.Options.ProductName, cd.Options.ConfigFile)
+	}
+
+	cd.logger.Printf("no drift detected between %s and the staged %s product", cd.Options.ConfigFile, cd.Options.ProductName)
+
+	return nil
+}
+
+func (cd CheckDrift) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command compares the properties, networks and resource config of a staged product with a config file, and fails if they differ. Secrets and settings not mentioned in the config file are not compared.",
+		ShortDescription: "checks a staged product for drift from its config file",
+		Flags:            cd.Options,
+	}
+}
+
+// checkProductDrift compares the product-properties, network-properties and
+// resource-config sections of a configure-product config with the staged
+// product.
+func checkProductDrift(service productDriftService, report *driftReport, productName, productGUID string, config map[string]interface{}) error {
+	if config["product-properties"] != nil {
+		err := checkPropertiesDrift(service, report, productName, productGUID, config["product-properties"])
+		if err != nil {
+			return err
+		}
+	}
+
+	if config["network-properties"] != nil {
+		networks, err := service.GetStagedProductNetworksAndAZs(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch staged network properties: %s", err)
+		}
+
+		err = report.compare("network-properties", config["network-properties"], networks)
+		if err != nil {
+			return err // un-tested
+		}
+	}
+
+	if config["resource-config"] != nil {
+		err := checkResourcesDrift(service, report, "resource-config", productName, productGUID, config["resource-config"])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkPropertiesDrift(service productDriftService, report *driftReport, productName, productGUID string, desired interface{}) error {
+	var properties map[string]interface{}
+	err := normalizeConfig(desired, &properties)
+	if err != nil {
+		return fmt.Errorf("could not parse product-properties: %s", err)
+	}
+
+	staged, err := service.GetStagedProductProperties(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch staged properties: %s", err)
+	}
+
+	var names []string
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := "product-properties." + name
+
+		stagedProperty, ok := staged[name]
+		if !ok {
+			report.differences = append(report.differences, fmt.Sprintf("%s: is not a property of %s", path, productName))
+			continue
+		}
+
+		if stagedProperty.IsCredential {
+			report.skipped = append(report.skipped, fmt.Sprintf("%s (secret)", path))
+			continue
+		}
+
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("could not parse product-properties: %s must be a map with a value", name)
+		}
+
+		err = report.compare(path+".value", property["value"], stagedProperty.Value)
+		if err != nil {
+			return err // un-tested
+		}
+	}
+
+	return nil
+}
+
+// checkResourcesDrift compares the resource config of each job in desired,
+// which is the given section of a config file, with the staged jobs.
+func checkResourcesDrift(service productDriftService, report *driftReport, section, productName, productGUID string, desired interface{}) error {
+	var resources map[string]interface{}
+	err := normalizeConfig(desired, &resources)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %s", section, err)
+	}
+
+	jobs, err := service.ListStagedProductJobs(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	var names []string
+	for name := range resources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := section + "." + name
+
+		jobGUID, ok := jobs[name]
+		if !ok {
+			report.differences = append(report.differences, fmt.Sprintf("%s: product %q does not contain a job named %q", path, productName, name))
+			continue
+		}
+
+		jobProperties, err := service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch resource config for job %s: %s", name, err)
+		}
+
+		err = report.compare(path, resources[name], jobProperties)
+		if err != nil {
+			return err // un-tested
+		}
+	}
+
+	return nil
+}
+
+// merge adds the differences and skipped values of another report, prefixed
+// with the given name.
+func (r *driftReport) merge(name string, other *driftReport) {
+	for _, difference := range other.differences {
+		r.differences = append(r.differences, name+": "+difference)
+	}
+
+	for _, skipped := range other.skipped {
+		r.skipped = append(r.skipped, name+": "+skipped)
+	}
+}
+
+// compare records every value in desired that is not the same in actual.
+// Keys that only exist in actual are server-side defaults and are ignored.
+func (r *driftReport) compare(path string, desired, actual interface{}) error {
+	var desiredValue, actualValue interface{}
+
+	err := normalizeConfig(desired, &desiredValue)
+	if err != nil {
+		return err // un-tested
+	}
+
+	contents, err := json.Marshal(actual)
+	if err != nil {
+		return err // un-tested
+	}
+
+	err = json.Unmarshal(contents, &actualValue)
+	if err != nil {
+		return err // un-tested
+	}
+
+	r.compareValues(path, desiredValue, actualValue)
+
+	return nil
+}
+
+func (r *driftReport) compareValues(path string, desired, actual interface{}) {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			r.addDifference(path, desired, actual)
+			return
+		}
+
+		var keys []string
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			r.compareValues(path+"."+key, desiredValue[key], actualValue[key])
+		}
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(actualValue) != len(desiredValue) {
+			r.addDifference(path, desired, actual)
+			return
+		}
+
+		for i := range desiredValue {
+			r.compareValues(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], actualValue[i])
+		}
+	case string:
+		if varPattern.MatchString(desiredValue) {
+			r.skipped = append(r.skipped, fmt.Sprintf("%s (unresolved %s)", path, strings.Join(varPattern.FindAllString(desiredValue, -1), ", ")))
+			return
+		}
+
+		if actual == nil || fmt.Sprint(actual) != desiredValue {
+			r.addDifference(path, desired, actual)
+		}
+	default:
+		if fmt.Sprint(desired) != fmt.Sprint(actual) {
+			r.addDifference(path, desired, actual)
+		}
+	}
+}
+
+func (r *driftReport) addDifference(path string, desired, actual interface{}) {
+	if actual == nil {
+		r.differences = append(r.differences, fmt.Sprintf("%s: expected %s, but it is not set", path, formatDriftValue(desired)))
+		return
+	}
+
+	r.differences = append(r.differences, fmt.Sprintf("%s: expected %s, got %s", path, formatDriftValue(desired), formatDriftValue(actual)))
+}
+
+func formatDriftValue(value interface{}) string {
+	contents, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value) // un-tested
+	}
+
+	return string(contents)
+}
+
+// normalizeConfig converts values read from a yaml config file into plain json
+// types, so that they compare equally with values returned by the api.
+func normalizeConfig(value interface{}, out interface{}) error {
+	contents, err := getJSONProperties(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(contents), out)
+}
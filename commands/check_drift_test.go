@@ -0,0 +1,325 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const driftConfigFile = `---
+product-properties:
+  .properties.some-string-property:
+    value: some-value
+  .properties.some-port:
+    value: ((port))
+  .properties.some-secret-property:
+    value:
+      secret: some-secret
+  .properties.some-unresolved-property:
+    value: ((missing-var))
+network-properties:
+  network:
+    name: some-network
+  singleton_availability_zone:
+    name: az-one
+resource-config:
+  some-job:
+    instances: 2
+    instance_type:
+      id: m1.medium
+`
+
+var _ = Describe("CheckDrift", func() {
+	var (
+		service    *fakes.CheckDriftService
+		logger     *fakes.Logger
+		command    commands.CheckDrift
+		configFile *os.File
+		varsFile   *os.File
+	)
+
+	BeforeEach(func() {
+		service = &fakes.CheckDriftService{}
+		logger = &fakes.Logger{}
+		command = commands.NewCheckDrift(service, logger)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = configFile.WriteString(driftConfigFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		varsFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = varsFile.WriteString("port: 8080\n")
+		Expect(err).NotTo(HaveOccurred())
+
+		service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+			Product: api.StagedProduct{GUID: "some-product-guid", Type: "some-product"},
+		}, nil)
+
+		service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+			".properties.some-string-property":     {Value: "some-value", Configurable: true},
+			".properties.some-port":                {Value: float64(8080), Configurable: true},
+			".properties.some-secret-property":     {Value: map[string]interface{}{"secret": "***"}, Configurable: true, IsCredential: true},
+			".properties.some-unresolved-property": {Value: "anything", Configurable: true},
+			".properties.some-default-property":    {Value: "some-default", Configurable: true},
+		}, nil)
+
+		service.GetStagedProductNetworksAndAZsReturns(map[string]interface{}{
+			"network":                     map[string]interface{}{"name": "some-network"},
+			"singleton_availability_zone": map[string]interface{}{"name": "az-one"},
+			"other_availability_zones":    []interface{}{map[string]interface{}{"name": "az-one"}},
+		}, nil)
+
+		service.ListStagedProductJobsReturns(map[string]string{
+			"some-job": "some-job-guid",
+		}, nil)
+
+		service.GetStagedProductJobResourceConfigReturns(api.JobProperties{
+			Instances:      float64(2),
+			PersistentDisk: &api.Disk{Size: "20480"},
+			InstanceType:   api.InstanceType{ID: "m1.medium"},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(configFile.Name())
+		os.RemoveAll(varsFile.Name())
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	Describe("Execute", func() {
+		It("succeeds when the staged product matches the config file", func() {
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--config", configFile.Name(),
+				"--vars-file", varsFile.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetStagedProductByNameArgsForCall(0)).To(Equal("some-product"))
+			Expect(service.GetStagedProductPropertiesArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(service.GetStagedProductNetworksAndAZsArgsForCall(0)).To(Equal("some-product-guid"))
+
+			productGUID, jobGUID := service.GetStagedProductJobResourceConfigArgsForCall(0)
+			Expect(productGUID).To(Equal("some-product-guid"))
+			Expect(jobGUID).To(Equal("some-job-guid"))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"skipped 2 values that cannot be compared:",
+				"  product-properties..properties.some-secret-property (secret)",
+				"  product-properties..properties.some-unresolved-property.value (unresolved ((missing-var)))",
+				fmt.Sprintf("no drift detected between %s and the staged some-product product", configFile.Name()),
+			}))
+		})
+
+		It("reports every difference and returns an error", func() {
+			service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+				".properties.some-string-property":     {Value: "some-other-value"},
+				".properties.some-port":                {Value: float64(9090)},
+				".properties.some-secret-property":     {IsCredential: true},
+				".properties.some-unresolved-property": {Value: "anything"},
+			}, nil)
+
+			service.GetStagedProductNetworksAndAZsReturns(map[string]interface{}{
+				"network": map[string]interface{}{"name": "some-other-network"},
+			}, nil)
+
+			service.GetStagedProductJobResourceConfigReturns(api.JobProperties{
+				Instances:    float64(1),
+				InstanceType: api.InstanceType{ID: "m1.medium"},
+			}, nil)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--config", configFile.Name(),
+				"--vars-file", varsFile.Name(),
+			})
+			Expect(err).To(MatchError(fmt.Sprintf("staged product some-product has drifted from %s", configFile.Name())))
+
+			Expect(loggedLines()).To(ContainElement(fmt.Sprintf("found 5 differences between %s and the staged some-product product:", configFile.Name())))
+			Expect(loggedLines()).To(ContainElement(`  product-properties..properties.some-port.value: expected 8080, got 9090`))
+			Expect(loggedLines()).To(ContainElement(`  product-properties..properties.some-string-property.value: expected "some-value", got "some-other-value"`))
+			Expect(loggedLines()).To(ContainElement(`  network-properties.network.name: expected "some-network", got "some-other-network"`))
+			Expect(loggedLines()).To(ContainElement(`  network-properties.singleton_availability_zone: expected {"name":"az-one"}, but it is not set`))
+			Expect(loggedLines()).To(ContainElement(`  resource-config.some-job.instances: expected 2, got 1`))
+		})
+
+		Context("when the config file refers to properties and jobs that do not exist", func() {
+			It("reports them as differences", func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte(`---
+product-properties:
+  .properties.some-removed-property:
+    value: some-value
+resource-config:
+  some-removed-job:
+    instances: 1
+`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+				Expect(err).To(HaveOccurred())
+
+				Expect(loggedLines()).To(ContainElement(`  product-properties..properties.some-removed-property: is not a property of some-product`))
+				Expect(loggedLines()).To(ContainElement(`  resource-config.some-removed-job: product "some-product" does not contain a job named "some-removed-job"`))
+				Expect(service.GetStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a list in the config file has a different length", func() {
+			It("reports the whole list as a difference", func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte(`---
+network-properties:
+  other_availability_zones:
+  - name: az-one
+  - name: az-two
+`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+				Expect(err).To(HaveOccurred())
+
+				Expect(loggedLines()).To(ContainElement(`  network-properties.other_availability_zones: expected [{"name":"az-one"},{"name":"az-two"}], got [{"name":"az-one"}]`))
+			})
+		})
+
+		Context("when the config file has empty lists and nested vars", func() {
+			It("does not report them as drift", func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte(`---
+product-properties:
+  .properties.some-string-property:
+    value: ((properties.string))
+  .properties.some-list-property:
+    value: []
+`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(varsFile.Name(), []byte("properties:\n  string: some-value\n"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.some-string-property": {Value: "some-value"},
+					".properties.some-list-property":   {Value: []interface{}{}},
+				}, nil)
+
+				err = command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name(), "--vars-file", varsFile.Name()})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loggedLines()).To(Equal([]string{
+					fmt.Sprintf("no drift detected between %s and the staged some-product product", configFile.Name()),
+				}))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse check-drift flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the config file does not exist", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--product-name", "some-product", "--config", "some/non-existent/path.yml"})
+					Expect(err).To(MatchError("open some/non-existent/path.yml: no such file or directory"))
+				})
+			})
+
+			Context("when the config file is not valid yaml", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("%%%"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+				})
+			})
+
+			Context("when the vars file is not valid yaml", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(varsFile.Name(), []byte("%%%"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name(), "--vars-file", varsFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as a valid vars file")))
+				})
+			})
+
+			Context("when the product cannot be found", func() {
+				It("returns an error", func() {
+					service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to find product: boom"))
+				})
+			})
+
+			Context("when fetching the staged properties fails", func() {
+				It("returns an error", func() {
+					service.GetStagedProductPropertiesReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to fetch staged properties: boom"))
+				})
+			})
+
+			Context("when fetching the staged networks fails", func() {
+				It("returns an error", func() {
+					service.GetStagedProductNetworksAndAZsReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to fetch staged network properties: boom"))
+				})
+			})
+
+			Context("when listing the jobs fails", func() {
+				It("returns an error", func() {
+					service.ListStagedProductJobsReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to fetch jobs: boom"))
+				})
+			})
+
+			Context("when fetching the resource config fails", func() {
+				It("returns an error", func() {
+					service.GetStagedProductJobResourceConfigReturns(api.JobProperties{}, errors.New("boom"))
+
+					err := command.Execute([]string{"--product-name", "some-product", "--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to fetch resource config for job some-job: boom"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command compares the properties, networks and resource config of a staged product with a config file, and fails if they differ. Secrets and settings not mentioned in the config file are not compared.",
+				ShortDescription: "checks a staged product for drift from its config file",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
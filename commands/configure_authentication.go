@@ -3,9 +3,13 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
 )
 
 //go:generate counterfeiter -o ./fakes/configure_authentication_service.go --fake-name ConfigureAuthenticationService . configureAuthenticationService
@@ -15,22 +19,57 @@ type configureAuthenticationService interface {
 }
 
 type ConfigureAuthentication struct {
-	service configureAuthenticationService
-	logger  logger
-	Options struct {
-		Username             string `long:"username"              short:"u"  required:"true" description:"admin username"`
-		Password             string `long:"password"              short:"p"  required:"true" description:"admin password"`
-		DecryptionPassphrase string `long:"decryption-passphrase" short:"dp" required:"true" description:"passphrase used to encrypt the installation"`
-		HTTPProxyURL         string `long:"http-proxy-url"                                   description:"proxy for outbound HTTP network traffic"`
-		HTTPSProxyURL        string `long:"https-proxy-url"                                  description:"proxy for outbound HTTPS network traffic"`
-		NoProxy              string `long:"no-proxy"                                         description:"comma-separated list of hosts that do not go through the proxy"`
+	service      configureAuthenticationService
+	logger       logger
+	waitDuration int
+	Options      struct {
+		Username             string   `long:"username"              short:"u"  description:"admin username, required for the internal userstore"`
+		Password             string   `long:"password"              short:"p"  description:"admin password, required for the internal userstore"`
+		DecryptionPassphrase string   `long:"decryption-passphrase" short:"dp" description:"passphrase used to encrypt the installation (required)"`
+		HTTPProxyURL         string   `long:"http-proxy-url"                   description:"proxy for outbound HTTP network traffic"`
+		HTTPSProxyURL        string   `long:"https-proxy-url"                  description:"proxy for outbound HTTPS network traffic"`
+		NoProxy              string   `long:"no-proxy"                         description:"comma-separated list of hosts that do not go through the proxy"`
+		ConfigFile           string   `long:"config"                short:"c"  description:"path to yml file with the settings above, and saml or ldap settings to use an external identity provider"`
+		VarsFiles            []string `long:"vars-file"             short:"l"  description:"path to yml file with values for ((placeholders)) in the config file (can be given more than once)"`
+		Timeout              int      `long:"timeout"                          description:"seconds to wait for the configuration to complete" default:"600"`
 	}
 }
 
-func NewConfigureAuthentication(service configureAuthenticationService, logger logger) ConfigureAuthentication {
+// authenticationConfig is the format of the configure-authentication config
+// file. Flags take precedence over the values in the file.
+type authenticationConfig struct {
+	Username             string `yaml:"username"`
+	Password             string `yaml:"password"`
+	DecryptionPassphrase string `yaml:"decryption-passphrase"`
+	HTTPProxyURL         string `yaml:"http-proxy-url"`
+	HTTPSProxyURL        string `yaml:"https-proxy-url"`
+	NoProxy              string `yaml:"no-proxy"`
+	SAML                 *struct {
+		IDPMetadata         string `yaml:"idp-metadata"`
+		BoshIDPMetadata     string `yaml:"bosh-idp-metadata"`
+		RBACAdminGroup      string `yaml:"rbac-admin-group"`
+		RBACGroupsAttribute string `yaml:"rbac-groups-attribute"`
+	} `yaml:"saml"`
+	LDAP *struct {
+		ServerURL         string `yaml:"server-url"`
+		Username          string `yaml:"username"`
+		Password          string `yaml:"password"`
+		UserSearchBase    string `yaml:"user-search-base"`
+		UserSearchFilter  string `yaml:"user-search-filter"`
+		GroupSearchBase   string `yaml:"group-search-base"`
+		GroupSearchFilter string `yaml:"group-search-filter"`
+		RBACAdminGroup    string `yaml:"rbac-admin-group"`
+		EmailAttribute    string `yaml:"email-attribute"`
+		Referrals         string `yaml:"referrals"`
+		ServerSSLCert     string `yaml:"server-ssl-cert"`
+	} `yaml:"ldap"`
+}
+
+func NewConfigureAuthentication(service configureAuthenticationService, logger logger, waitDuration int) ConfigureAuthentication {
 	return ConfigureAuthentication{
-		service: service,
-		logger:  logger,
+		service:      service,
+		logger:       logger,
+		waitDuration: waitDuration,
 	}
 }
 
@@ -39,39 +78,49 @@ func (ca ConfigureAuthentication) Execute(args []string) error {
 		return fmt.Errorf("could not parse configure-authentication flags: %s", err)
 	}
 
+	setupInput, err := ca.setupInput()
+	if err != nil {
+		return err
+	}
+
 	ensureAvailabilityOutput, err := ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 	if err != nil {
 		return fmt.Errorf("could not determine initial configuration status: %s", err)
 	}
 
-	if ensureAvailabilityOutput.Status == api.EnsureAvailabilityStatusUnknown {
+	switch ensureAvailabilityOutput.Status {
+	case api.EnsureAvailabilityStatusUnknown:
 		return errors.New("could not determine initial configuration status: received unexpected status")
-	}
-
-	if ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusUnstarted {
+	case api.EnsureAvailabilityStatusLocked:
+		return errors.New("configuration previously completed, but Ops Manager is locked: run `om unlock` with the decryption passphrase")
+	case api.EnsureAvailabilityStatusPending, api.EnsureAvailabilityStatusComplete:
 		ca.logger.Printf("configuration previously completed, skipping configuration")
 		return nil
 	}
 
-	ca.logger.Printf("configuring internal userstore...")
-	_, err = ca.service.Setup(api.SetupInput{
-		IdentityProvider:                 "internal",
-		AdminUserName:                    ca.Options.Username,
-		AdminPassword:                    ca.Options.Password,
-		AdminPasswordConfirmation:        ca.Options.Password,
-		DecryptionPassphrase:             ca.Options.DecryptionPassphrase,
-		DecryptionPassphraseConfirmation: ca.Options.DecryptionPassphrase,
-		HTTPProxyURL:                     ca.Options.HTTPProxyURL,
-		HTTPSProxyURL:                    ca.Options.HTTPSProxyURL,
-		NoProxy:                          ca.Options.NoProxy,
-		EULAAccepted:                     true,
-	})
+	switch setupInput.IdentityProvider {
+	case "saml":
+		ca.logger.Printf("configuring SAML identity provider...")
+	case "ldap":
+		ca.logger.Printf("configuring LDAP identity provider...")
+	default:
+		ca.logger.Printf("configuring internal userstore...")
+	}
+
+	_, err = ca.service.Setup(setupInput)
 	if err != nil {
 		return fmt.Errorf("could not configure authentication: %s", err)
 	}
 
 	ca.logger.Printf("waiting for configuration to complete...")
+	deadline := time.Now().Add(time.Duration(ca.Options.Timeout) * time.Second)
 	for ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusComplete {
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("configuration did not complete within %d seconds", ca.Options.Timeout)
+		}
+
+		time.Sleep(time.Duration(ca.waitDuration) * time.Second)
+
 		ensureAvailabilityOutput, err = ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 		if err != nil {
 			return fmt.Errorf("could not determine final configuration status: %s", err)
@@ -85,8 +134,130 @@ func (ca ConfigureAuthentication) Execute(args []string) error {
 
 func (ca ConfigureAuthentication) Usage() jhanda.Usage {
 	return jhanda.Usage{
-		Description:      "This unauthenticated command helps setup the authentication mechanism for your Ops Manager.\nThe \"internal\" userstore mechanism is the only currently supported option.",
-		ShortDescription: "configures Ops Manager with an internal userstore and admin user account",
+		Description:      "This unauthenticated command helps setup the authentication mechanism for your Ops Manager.\nBy default an \"internal\" userstore with an admin user account is configured. A config file with saml or ldap settings configures an external identity provider instead.",
+		ShortDescription: "configures Ops Manager with an internal userstore and admin user account, or with SAML or LDAP",
 		Flags:            ca.Options,
 	}
 }
+
+func (ca ConfigureAuthentication) setupInput() (api.SetupInput, error) {
+	var config authenticationConfig
+	if ca.Options.ConfigFile != "" {
+		vars, err := loadVars(ca.Options.VarsFiles)
+		if err != nil {
+			return api.SetupInput{}, err
+		}
+
+		contents, err := ioutil.ReadFile(ca.Options.ConfigFile)
+		if err != nil {
+			return api.SetupInput{}, err
+		}
+
+		var document interface{}
+		err = yaml.Unmarshal(contents, &document)
+		if err != nil {
+			return api.SetupInput{}, fmt.Errorf("%s could not be parsed as valid configuration: %s", ca.Options.ConfigFile, err)
+		}
+
+		contents, err = yaml.Marshal(interpolateVars(document, vars))
+		if err != nil {
+			return api.SetupInput{}, err // un-tested
+		}
+
+		err = yaml.UnmarshalStrict(contents, &config)
+		if err != nil {
+			return api.SetupInput{}, fmt.Errorf("%s could not be parsed as valid configuration: %s", ca.Options.ConfigFile, err)
+		}
+	}
+
+	setFromConfig := func(option *string, value string) {
+		if *option == "" {
+			*option = value
+		}
+	}
+
+	setFromConfig(&ca.Options.Username, config.Username)
+	setFromConfig(&ca.Options.Password, config.Password)
+	setFromConfig(&ca.Options.DecryptionPassphrase, config.DecryptionPassphrase)
+	setFromConfig(&ca.Options.HTTPProxyURL, config.HTTPProxyURL)
+	setFromConfig(&ca.Options.HTTPSProxyURL, config.HTTPSProxyURL)
+	setFromConfig(&ca.Options.NoProxy, config.NoProxy)
+
+	input := api.SetupInput{
+		IdentityProvider:                 "internal",
+		DecryptionPassphrase:             ca.Options.DecryptionPassphrase,
+		DecryptionPassphraseConfirmation: ca.Options.DecryptionPassphrase,
+		HTTPProxyURL:                     ca.Options.HTTPProxyURL,
+		HTTPSProxyURL:                    ca.Options.HTTPSProxyURL,
+		NoProxy:                          ca.Options.NoProxy,
+		EULAAccepted:                     true,
+	}
+
+	var missing []string
+	switch {
+	case config.SAML != nil && config.LDAP != nil:
+		return api.SetupInput{}, errors.New("could not configure authentication: the config must not contain both saml and ldap")
+	case config.SAML != nil:
+		input.IdentityProvider = "saml"
+		input.IDPMetadata = config.SAML.IDPMetadata
+		input.BoshIDPMetadata = config.SAML.BoshIDPMetadata
+		input.RBACAdminGroup = config.SAML.RBACAdminGroup
+		input.RBACGroupsAttribute = config.SAML.RBACGroupsAttribute
+
+		if input.IDPMetadata == "" {
+			missing = append(missing, "saml.idp-metadata")
+		}
+		if input.RBACAdminGroup == "" {
+			missing = append(missing, "saml.rbac-admin-group")
+		}
+	case config.LDAP != nil:
+		input.IdentityProvider = "ldap"
+		input.LDAPSettings = &api.LDAPSettings{
+			ServerURL:         config.LDAP.ServerURL,
+			Username:          config.LDAP.Username,
+			Password:          config.LDAP.Password,
+			UserSearchBase:    config.LDAP.UserSearchBase,
+			UserSearchFilter:  config.LDAP.UserSearchFilter,
+			GroupSearchBase:   config.LDAP.GroupSearchBase,
+			GroupSearchFilter: config.LDAP.GroupSearchFilter,
+			RBACAdminGroup:    config.LDAP.RBACAdminGroup,
+			EmailAttribute:    config.LDAP.EmailAttribute,
+			Referrals:         config.LDAP.Referrals,
+			ServerSSLCert:     config.LDAP.ServerSSLCert,
+		}
+
+		if input.LDAPSettings.ServerURL == "" {
+			missing = append(missing, "ldap.server-url")
+		}
+		if input.LDAPSettings.UserSearchBase == "" {
+			missing = append(missing, "ldap.user-search-base")
+		}
+		if input.LDAPSettings.UserSearchFilter == "" {
+			missing = append(missing, "ldap.user-search-filter")
+		}
+		if input.LDAPSettings.RBACAdminGroup == "" {
+			missing = append(missing, "ldap.rbac-admin-group")
+		}
+	default:
+		if ca.Options.Username == "" {
+			return api.SetupInput{}, errors.New("could not parse configure-authentication flags: missing required flag \"--username\"")
+		}
+		if ca.Options.Password == "" {
+			return api.SetupInput{}, errors.New("could not parse configure-authentication flags: missing required flag \"--password\"")
+		}
+
+		input.AdminUserName = ca.Options.Username
+		input.AdminPassword = ca.Options.Password
+		input.AdminPasswordConfirmation = ca.Options.Password
+	}
+
+	if ca.Options.DecryptionPassphrase == "" {
+		return api.SetupInput{}, errors.New("could not parse configure-authentication flags: missing required flag \"--decryption-passphrase\"")
+	}
+
+	if len(missing) > 0 {
+		return api.SetupInput{}, fmt.Errorf("could not configure authentication: the config is missing %s", strings.Join(missing, ", "))
+	}
+
+	return input, nil
+}
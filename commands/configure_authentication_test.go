@@ -3,6 +3,8 @@ package commands_test
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
@@ -30,7 +32,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 			logger := &fakes.Logger{}
 
-			command := commands.NewConfigureAuthentication(service, logger)
+			command := commands.NewConfigureAuthentication(service, logger, 0)
 			err := command.Execute([]string{
 				"--username", "some-username",
 				"--password", "some-password",
@@ -69,7 +71,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 				logger := &fakes.Logger{}
 
-				command := commands.NewConfigureAuthentication(service, logger)
+				command := commands.NewConfigureAuthentication(service, logger, 0)
 				err := command.Execute([]string{
 					"--username", "some-username",
 					"--password", "some-password",
@@ -85,10 +87,209 @@ var _ = Describe("ConfigureAuthentication", func() {
 			})
 		})
 
+		Context("when a config file is provided", func() {
+			var (
+				service    *fakes.ConfigureAuthenticationService
+				logger     *fakes.Logger
+				configFile *os.File
+				varsFile   *os.File
+			)
+
+			BeforeEach(func() {
+				service = &fakes.ConfigureAuthenticationService{}
+				eaOutputs := []api.EnsureAvailabilityOutput{
+					{Status: api.EnsureAvailabilityStatusUnstarted},
+					{Status: api.EnsureAvailabilityStatusPending},
+					{Status: api.EnsureAvailabilityStatusComplete},
+				}
+
+				service.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+					return eaOutputs[service.EnsureAvailabilityCallCount()-1], nil
+				}
+
+				logger = &fakes.Logger{}
+
+				var err error
+				configFile, err = ioutil.TempFile("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				varsFile, err = ioutil.TempFile("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = varsFile.WriteString("passphrase: some-passphrase\nldap_password: some-ldap-password\n")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(configFile.Name())
+				os.RemoveAll(varsFile.Name())
+			})
+
+			writeConfig := func(config string) {
+				err := ioutil.WriteFile(configFile.Name(), []byte(config), 0600)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			It("sets up SAML authentication", func() {
+				writeConfig(`---
+decryption-passphrase: ((passphrase))
+saml:
+  idp-metadata: https://idp.example.com/metadata
+  bosh-idp-metadata: https://idp.example.com/bosh-metadata
+  rbac-admin-group: opsman-admins
+  rbac-groups-attribute: groups
+`)
+
+				command := commands.NewConfigureAuthentication(service, logger, 0)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.SetupArgsForCall(0)).To(Equal(api.SetupInput{
+					IdentityProvider:                 "saml",
+					DecryptionPassphrase:             "some-passphrase",
+					DecryptionPassphraseConfirmation: "some-passphrase",
+					EULAAccepted:                     true,
+					IDPMetadata:                      "https://idp.example.com/metadata",
+					BoshIDPMetadata:                  "https://idp.example.com/bosh-metadata",
+					RBACAdminGroup:                   "opsman-admins",
+					RBACGroupsAttribute:              "groups",
+				}))
+
+				Expect(service.EnsureAvailabilityCallCount()).To(Equal(3))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("configuring SAML identity provider..."))
+			})
+
+			It("sets up LDAP authentication", func() {
+				writeConfig(`---
+decryption-passphrase: ((passphrase))
+ldap:
+  server-url: ldaps://ldap.example.com
+  username: cn=admin,dc=example,dc=com
+  password: ((ldap_password))
+  user-search-base: ou=users,dc=example,dc=com
+  user-search-filter: cn={0}
+  group-search-base: ou=groups,dc=example,dc=com
+  group-search-filter: member={0}
+  rbac-admin-group: opsman-admins
+  referrals: follow
+`)
+
+				command := commands.NewConfigureAuthentication(service, logger, 0)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.SetupArgsForCall(0)).To(Equal(api.SetupInput{
+					IdentityProvider:                 "ldap",
+					DecryptionPassphrase:             "some-passphrase",
+					DecryptionPassphraseConfirmation: "some-passphrase",
+					EULAAccepted:                     true,
+					LDAPSettings: &api.LDAPSettings{
+						ServerURL:         "ldaps://ldap.example.com",
+						Username:          "cn=admin,dc=example,dc=com",
+						Password:          "some-ldap-password",
+						UserSearchBase:    "ou=users,dc=example,dc=com",
+						UserSearchFilter:  "cn={0}",
+						GroupSearchBase:   "ou=groups,dc=example,dc=com",
+						GroupSearchFilter: "member={0}",
+						RBACAdminGroup:    "opsman-admins",
+						Referrals:         "follow",
+					},
+				}))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("configuring LDAP identity provider..."))
+			})
+
+			It("prefers flags over the values in the config file", func() {
+				writeConfig(`---
+username: config-username
+password: config-password
+decryption-passphrase: config-passphrase
+no-proxy: 10.0.0.1
+`)
+
+				command := commands.NewConfigureAuthentication(service, logger, 0)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--username", "some-username",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.SetupArgsForCall(0)).To(Equal(api.SetupInput{
+					IdentityProvider:                 "internal",
+					AdminUserName:                    "some-username",
+					AdminPassword:                    "config-password",
+					AdminPasswordConfirmation:        "config-password",
+					DecryptionPassphrase:             "config-passphrase",
+					DecryptionPassphraseConfirmation: "config-passphrase",
+					NoProxy:                          "10.0.0.1",
+					EULAAccepted:                     true,
+				}))
+			})
+
+			Context("failure cases", func() {
+				It("returns an error when the config file cannot be read", func() {
+					command := commands.NewConfigureAuthentication(service, logger, 0)
+					err := command.Execute([]string{"--config", "/no/such/file"})
+					Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+					Expect(service.EnsureAvailabilityCallCount()).To(Equal(0))
+				})
+
+				It("returns an error when the config file contains unknown keys", func() {
+					writeConfig("decryption-passphrase: some-passphrase\noidc: {}\n")
+
+					command := commands.NewConfigureAuthentication(service, logger, 0)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+				})
+
+				It("returns an error when both saml and ldap are configured", func() {
+					writeConfig("decryption-passphrase: some-passphrase\nsaml: {}\nldap: {}\n")
+
+					command := commands.NewConfigureAuthentication(service, logger, 0)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("could not configure authentication: the config must not contain both saml and ldap"))
+				})
+
+				It("returns an error when required saml settings are missing", func() {
+					writeConfig("decryption-passphrase: some-passphrase\nsaml:\n  rbac-groups-attribute: groups\n")
+
+					command := commands.NewConfigureAuthentication(service, logger, 0)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("could not configure authentication: the config is missing saml.idp-metadata, saml.rbac-admin-group"))
+					Expect(service.SetupCallCount()).To(Equal(0))
+				})
+
+				It("returns an error when required ldap settings are missing", func() {
+					writeConfig("decryption-passphrase: some-passphrase\nldap:\n  server-url: ldaps://ldap.example.com\n")
+
+					command := commands.NewConfigureAuthentication(service, logger, 0)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("could not configure authentication: the config is missing ldap.user-search-base, ldap.user-search-filter, ldap.rbac-admin-group"))
+				})
+
+				It("returns an error when the decryption passphrase is missing", func() {
+					writeConfig("saml:\n  idp-metadata: some-metadata\n  rbac-admin-group: opsman-admins\n")
+
+					command := commands.NewConfigureAuthentication(service, logger, 0)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("could not parse configure-authentication flags: missing required flag \"--decryption-passphrase\""))
+				})
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when an unknown flag is provided", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{})
+					command := commands.NewConfigureAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{}, 0)
 					err := command.Execute([]string{"--banana"})
 					Expect(err).To(MatchError("could not parse configure-authentication flags: flag provided but not defined: -banana"))
 				})
@@ -99,7 +300,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 					service := &fakes.ConfigureAuthenticationService{}
 					service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, errors.New("failed to fetch status"))
 
-					command := commands.NewConfigureAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureAuthentication(service, &fakes.Logger{}, 0)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -116,7 +317,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 						Status: api.EnsureAvailabilityStatusUnknown,
 					}, nil)
 
-					command := commands.NewConfigureAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureAuthentication(service, &fakes.Logger{}, 0)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -135,7 +336,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 					service.SetupReturns(api.SetupOutput{}, errors.New("could not setup"))
 
-					command := commands.NewConfigureAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureAuthentication(service, &fakes.Logger{}, 0)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -162,7 +363,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 						return eaOutputs[service.EnsureAvailabilityCallCount()-1], eaErrors[service.EnsureAvailabilityCallCount()-1]
 					}
 
-					command := commands.NewConfigureAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureAuthentication(service, &fakes.Logger{}, 0)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -172,9 +373,47 @@ var _ = Describe("ConfigureAuthentication", func() {
 				})
 			})
 
+			Context("when the configuration does not complete before the timeout", func() {
+				It("returns an error", func() {
+					service := &fakes.ConfigureAuthenticationService{}
+					service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{
+						Status: api.EnsureAvailabilityStatusUnstarted,
+					}, nil)
+
+					command := commands.NewConfigureAuthentication(service, &fakes.Logger{}, 0)
+					err := command.Execute([]string{
+						"--username", "some-username",
+						"--password", "some-password",
+						"--decryption-passphrase", "some-passphrase",
+						"--timeout", "0",
+					})
+					Expect(err).To(MatchError("configuration did not complete within 0 seconds"))
+					Expect(service.SetupCallCount()).To(Equal(1))
+					Expect(service.EnsureAvailabilityCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when Ops Manager is locked", func() {
+				It("returns an error pointing to om unlock without configuring the authentication system", func() {
+					service := &fakes.ConfigureAuthenticationService{}
+					service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{
+						Status: api.EnsureAvailabilityStatusLocked,
+					}, nil)
+
+					command := commands.NewConfigureAuthentication(service, &fakes.Logger{}, 0)
+					err := command.Execute([]string{
+						"--username", "some-username",
+						"--password", "some-password",
+						"--decryption-passphrase", "some-passphrase",
+					})
+					Expect(err).To(MatchError("configuration previously completed, but Ops Manager is locked: run `om unlock` with the decryption passphrase"))
+					Expect(service.SetupCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the --username flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(nil, nil)
+					command := commands.NewConfigureAuthentication(nil, nil, 0)
 					err := command.Execute([]string{
 						"--password", "some-password",
 						"--decryption-passphrase", "some-passphrase",
@@ -185,7 +424,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 			Context("when the --password flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(nil, nil)
+					command := commands.NewConfigureAuthentication(nil, nil, 0)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--decryption-passphrase", "some-passphrase",
@@ -196,7 +435,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 			Context("when the --decryption-passphrase flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(nil, nil)
+					command := commands.NewConfigureAuthentication(nil, nil, 0)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -209,10 +448,10 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewConfigureAuthentication(nil, nil)
+			command := commands.NewConfigureAuthentication(nil, nil, 0)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
-				Description:      "This unauthenticated command helps setup the authentication mechanism for your Ops Manager.\nThe \"internal\" userstore mechanism is the only currently supported option.",
-				ShortDescription: "configures Ops Manager with an internal userstore and admin user account",
+				Description:      "This unauthenticated command helps setup the authentication mechanism for your Ops Manager.\nBy default an \"internal\" userstore with an admin user account is configured. A config file with saml or ldap settings configures an external identity provider instead.",
+				ShortDescription: "configures Ops Manager with an internal userstore and admin user account, or with SAML or LDAP",
 				Flags:            command.Options,
 			}))
 		})
@@ -35,6 +35,9 @@ type configureDirectorService interface {
 	UpdateStagedProductJobResourceConfig(string, string, api.JobProperties) error
 	GetStagedProductByName(name string) (api.StagedProductsFindOutput, error)
 	GetStagedProductManifest(guid string) (manifest string, err error)
+	ListVMTypes() ([]api.VMType, error)
+	ListDiskTypes() ([]api.DiskType, error)
+	ListStagedVMExtensions() ([]api.VMExtension, error)
 }
 
 func NewConfigureDirector(service configureDirectorService, logger logger) ConfigureDirector {
@@ -46,6 +49,35 @@ func (c ConfigureDirector) Execute(args []string) error {
 		return fmt.Errorf("could not parse configure-director flags: %s", err)
 	}
 
+	var (
+		productGUID        string
+		jobs               map[string]string
+		userProvidedConfig map[string]json.RawMessage
+	)
+
+	if c.Options.ResourceConfiguration != "" {
+		findOutput, err := c.service.GetStagedProductByName("p-bosh")
+		if err != nil {
+			return fmt.Errorf("could not find staged product with name 'p-bosh': %s", err)
+		}
+		productGUID = findOutput.Product.GUID
+
+		err = json.Unmarshal([]byte(c.Options.ResourceConfiguration), &userProvidedConfig)
+		if err != nil {
+			return fmt.Errorf("could not decode resource-configuration json: %s", err)
+		}
+
+		jobs, err = c.service.ListStagedProductJobs(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch jobs: %s", err)
+		}
+
+		err = validateResourceConfig(c.service, "p-bosh", jobs, userProvidedConfig)
+		if err != nil {
+			return err
+		}
+	}
+
 	c.logger.Printf("started configuring director options for bosh tile")
 
 	err := c.service.UpdateStagedDirectorProperties(api.DirectorProperties{
@@ -100,23 +132,6 @@ func (c ConfigureDirector) Execute(args []string) error {
 	if c.Options.ResourceConfiguration != "" {
 		c.logger.Printf("started configuring resource options for bosh tile")
 
-		findOutput, err := c.service.GetStagedProductByName("p-bosh")
-		if err != nil {
-			return fmt.Errorf("could not find staged product with name 'p-bosh': %s", err)
-		}
-		productGUID := findOutput.Product.GUID
-
-		var userProvidedConfig map[string]json.RawMessage
-		err = json.Unmarshal([]byte(c.Options.ResourceConfiguration), &userProvidedConfig)
-		if err != nil {
-			return fmt.Errorf("could not decode resource-configuration json: %s", err)
-		}
-
-		jobs, err := c.service.ListStagedProductJobs(productGUID)
-		if err != nil {
-			return fmt.Errorf("failed to fetch jobs: %s", err)
-		}
-
 		var names []string
 		for name, _ := range userProvidedConfig {
 			names = append(names, name)
@@ -127,10 +142,7 @@ func (c ConfigureDirector) Execute(args []string) error {
 		c.logger.Printf("applying resource configuration for the following jobs:")
 		for _, name := range names {
 			c.logger.Printf("\t%s", name)
-			jobGUID, ok := jobs[name]
-			if !ok {
-				return fmt.Errorf("product 'p-bosh' does not contain a job named '%s'", name)
-			}
+			jobGUID := jobs[name]
 
 			jobProperties, err := c.service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
 			if err != nil {
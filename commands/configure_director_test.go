@@ -35,6 +35,9 @@ var _ = Describe("ConfigureDirector", func() {
 			},
 			FloatingIPs: "1.2.3.4",
 		}, nil)
+		service.ListVMTypesReturns([]api.VMType{
+			{Name: "some-type"},
+		}, nil)
 
 		command = commands.NewConfigureDirector(service, logger)
 	})
@@ -204,6 +207,25 @@ var _ = Describe("ConfigureDirector", func() {
 				})
 			})
 
+			Context("when user-provided job is a typo of an existing job", func() {
+				It("suggests the existing job and does not configure the director", func() {
+					err := command.Execute([]string{
+						"--director-configuration", `{"some-director-assignment": "director"}`,
+						"--resource-configuration", `{"resorce": {}}`,
+					})
+					Expect(err).To(MatchError(`invalid resource configuration: product "p-bosh" does not contain a job named "resorce" (did you mean "resource"?)`))
+					Expect(service.UpdateStagedDirectorPropertiesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when user-provided instance type does not exist", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--resource-configuration", `{"resource": {"instance_type": {"id": "x1.huge"}}}`})
+					Expect(err).To(MatchError(`invalid resource configuration: instance_type "x1.huge" for job "resource" is not a known vm type`))
+					Expect(service.UpdateStagedDirectorPropertiesCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when retrieving existing job config fails", func() {
 				It("returns an error", func() {
 					service.GetStagedProductJobResourceConfigReturns(api.JobProperties{}, errors.New("some-error"))
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type ConfigureOpsman struct {
+	service configureOpsmanService
+	logger  logger
+	Options struct {
+		ConfigFile string   `long:"config"    short:"c" required:"true" description:"path to yml file containing the Ops Manager settings"`
+		VarsFiles  []string `long:"vars-file" short:"l"                 description:"path to yml file with values for ((placeholders)) in the config file (can be given more than once)"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/configure_opsman_service.go --fake-name ConfigureOpsmanService . configureOpsmanService
+type configureOpsmanService interface {
+	GetOpsManagerSetting(setting string) (map[string]interface{}, error)
+	UpdateOpsManagerSetting(setting string, body map[string]interface{}) error
+}
+
+// opsmanSetting describes how a section of the configure-opsman config file
+// maps onto an /api/v0/settings endpoint.
+type opsmanSetting struct {
+	key      string
+	endpoint string
+
+	// wrapper is the key the settings are nested under in the request and
+	// response bodies, or empty when they are at the top level.
+	wrapper string
+
+	// secrets are the paths of values that are never returned by the api.
+	secrets []string
+
+	// readable is false when the settings cannot be read back, in which case
+	// they are always applied.
+	readable bool
+}
+
+var opsmanSettings = []opsmanSetting{
+	{key: "ssl-certificate", endpoint: "ssl_certificate", wrapper: "ssl_certificate", secrets: []string{"private_key"}, readable: true},
+	{key: "banner", endpoint: "banner", readable: true},
+	{key: "smtp", endpoint: "smtp", wrapper: "smtp_settings", secrets: []string{"credentials.password"}, readable: true},
+	{key: "syslog", endpoint: "syslog", wrapper: "syslog", readable: true},
+	{key: "pivotal-network", endpoint: "pivotal_network_settings", wrapper: "pivotal_network_settings", readable: false},
+	{key: "rbac", endpoint: "rbac", readable: true},
+}
+
+func NewConfigureOpsman(service configureOpsmanService, logger logger) ConfigureOpsman {
+	return ConfigureOpsman{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (co ConfigureOpsman) Execute(args []string) error {
+	if _, err := jhanda.Parse(&co.Options, args); err != nil {
+		return fmt.Errorf("could not parse configure-opsman flags: %s", err)
+	}
+
+	vars, err := loadVars(co.Options.VarsFiles)
+	if err != nil {
+		return err
+	}
+
+	configContents, err := ioutil.ReadFile(co.Options.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	var config map[string]interface{}
+	err = yaml.Unmarshal(configContents, &config)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed as valid configuration: %s", co.Options.ConfigFile, err)
+	}
+
+	var keys []string
+	for _, setting := range opsmanSettings {
+		keys = append(keys, setting.key)
+	}
+
+	var unknown []string
+	for key := range config {
+		if !contains(keys, key) {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown Ops Manager settings %s, the supported settings are %s", strings.Join(unknown, ", "), strings.Join(keys, ", "))
+	}
+
+	for _, setting := range opsmanSettings {
+		if config[setting.key] == nil {
+			continue
+		}
+
+		err = co.configure(setting, interpolateVars(config[setting.key], vars))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (co ConfigureOpsman) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command configures the settings of Ops Manager itself: the SSL certificate, banner, SMTP, syslog, Pivotal Network token and RBAC. Only the sections in the config file are configured, and only when they differ from the current settings.",
+		ShortDescription: "configures Ops Manager settings",
+		Flags:            co.Options,
+	}
+}
+
+func (co ConfigureOpsman) configure(setting opsmanSetting, desired interface{}) error {
+	var settings map[string]interface{}
+	err := normalizeConfig(desired, &settings)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: it must be a map of settings", setting.key)
+	}
+
+	contents, err := json.Marshal(settings)
+	if err != nil {
+		return err // un-tested
+	}
+
+	if placeholders := varPattern.FindAllString(string(contents), -1); len(placeholders) > 0 {
+		return fmt.Errorf("could not configure %s: no value was given for %s", setting.key, strings.Join(placeholders, ", "))
+	}
+
+	secrets := includedSecrets(settings, setting.secrets)
+	if setting.readable && len(secrets) == 0 {
+		current, err := co.service.GetOpsManagerSetting(setting.endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve %s settings: %s", setting.key, err)
+		}
+
+		var actual interface{} = current
+		if setting.wrapper != "" {
+			actual = current[setting.wrapper]
+		}
+
+		report := &driftReport{}
+		err = report.compare(setting.key, settings, actual)
+		if err != nil {
+			return err // un-tested
+		}
+
+		if len(report.differences) == 0 {
+			co.logger.Printf("%s is already configured", setting.key)
+			return nil
+		}
+	}
+
+	if len(secrets) > 0 {
+		co.logger.Printf("configuring %s, as %s cannot be compared with the current settings", setting.key, strings.Join(secrets, ", "))
+	} else {
+		co.logger.Printf("configuring %s", setting.key)
+	}
+
+	body := settings
+	if setting.wrapper != "" {
+		body = map[string]interface{}{setting.wrapper: settings}
+	}
+
+	err = co.service.UpdateOpsManagerSetting(setting.endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to configure %s: %s", setting.key, err)
+	}
+
+	return nil
+}
+
+// includedSecrets returns the dotted paths of the secrets that have a value
+// in the settings. The api never returns them, so a setting that includes
+// one is always applied.
+func includedSecrets(settings map[string]interface{}, secrets []string) []string {
+	var included []string
+	for _, secret := range secrets {
+		var value interface{} = settings
+		for _, part := range strings.Split(secret, ".") {
+			nested, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+
+			value = nested[part]
+		}
+
+		if value != nil {
+			included = append(included, secret)
+		}
+	}
+
+	return included
+}
@@ -0,0 +1,274 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const opsmanConfigFile = `---
+ssl-certificate:
+  certificate: some-certificate
+  private_key: ((ssl_private_key))
+banner:
+  ui_banner_contents: authorized use only
+smtp:
+  from: opsman@example.com
+  address: smtp.example.com
+  port: 25
+  credentials:
+    identity: some-user
+    password: ((smtp_password))
+pivotal-network:
+  api_token: ((pivnet_token))
+`
+
+var _ = Describe("ConfigureOpsman", func() {
+	var (
+		service    *fakes.ConfigureOpsmanService
+		logger     *fakes.Logger
+		command    commands.ConfigureOpsman
+		configFile *os.File
+		varsFile   *os.File
+		settings   map[string]map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		service = &fakes.ConfigureOpsmanService{}
+		logger = &fakes.Logger{}
+		command = commands.NewConfigureOpsman(service, logger)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = configFile.WriteString(opsmanConfigFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		varsFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = varsFile.WriteString("ssl_private_key: some-private-key\nsmtp_password: some-password\npivnet_token: some-token\n")
+		Expect(err).NotTo(HaveOccurred())
+
+		settings = map[string]map[string]interface{}{
+			"ssl_certificate": {
+				"ssl_certificate": map[string]interface{}{"certificate": "some-certificate"},
+			},
+			"banner": {
+				"ui_banner_contents":  "authorized use only",
+				"ssh_banner_contents": "",
+			},
+			"smtp": {
+				"smtp_settings": map[string]interface{}{
+					"from":           "opsman@example.com",
+					"address":        "smtp.example.com",
+					"port":           float64(25),
+					"auth_mechanism": "plain",
+					"credentials":    map[string]interface{}{"identity": "some-user"},
+				},
+			},
+		}
+
+		service.GetOpsManagerSettingStub = func(setting string) (map[string]interface{}, error) {
+			return settings[setting], nil
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(configFile.Name())
+		os.RemoveAll(varsFile.Name())
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	Describe("Execute", func() {
+		It("applies the settings that cannot be compared and skips the others that are already configured", func() {
+			err := command.Execute([]string{
+				"--config", configFile.Name(),
+				"--vars-file", varsFile.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetOpsManagerSettingCallCount()).To(Equal(1))
+			Expect(service.GetOpsManagerSettingArgsForCall(0)).To(Equal("banner"))
+
+			Expect(service.UpdateOpsManagerSettingCallCount()).To(Equal(3))
+
+			setting, body := service.UpdateOpsManagerSettingArgsForCall(0)
+			Expect(setting).To(Equal("ssl_certificate"))
+			Expect(body).To(Equal(map[string]interface{}{
+				"ssl_certificate": map[string]interface{}{
+					"certificate": "some-certificate",
+					"private_key": "some-private-key",
+				},
+			}))
+
+			setting, body = service.UpdateOpsManagerSettingArgsForCall(1)
+			Expect(setting).To(Equal("smtp"))
+			Expect(body).To(Equal(map[string]interface{}{
+				"smtp_settings": map[string]interface{}{
+					"from":    "opsman@example.com",
+					"address": "smtp.example.com",
+					"port":    float64(25),
+					"credentials": map[string]interface{}{
+						"identity": "some-user",
+						"password": "some-password",
+					},
+				},
+			}))
+
+			setting, body = service.UpdateOpsManagerSettingArgsForCall(2)
+			Expect(setting).To(Equal("pivotal_network_settings"))
+			Expect(body).To(Equal(map[string]interface{}{
+				"pivotal_network_settings": map[string]interface{}{"api_token": "some-token"},
+			}))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"configuring ssl-certificate, as private_key cannot be compared with the current settings",
+				"banner is already configured",
+				"configuring smtp, as credentials.password cannot be compared with the current settings",
+				"configuring pivotal-network",
+			}))
+		})
+
+		It("applies the settings without secret values only when they differ from the current settings", func() {
+			err := ioutil.WriteFile(configFile.Name(), []byte(`---
+banner:
+  ui_banner_contents: welcome
+smtp:
+  from: opsman@example.com
+  address: smtp.example.com
+  port: 25
+  credentials:
+    identity: some-user
+`), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = command.Execute([]string{"--config", configFile.Name()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetOpsManagerSettingCallCount()).To(Equal(2))
+			Expect(service.UpdateOpsManagerSettingCallCount()).To(Equal(1))
+
+			setting, body := service.UpdateOpsManagerSettingArgsForCall(0)
+			Expect(setting).To(Equal("banner"))
+			Expect(body).To(Equal(map[string]interface{}{
+				"ui_banner_contents": "welcome",
+			}))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"configuring banner",
+				"smtp is already configured",
+			}))
+		})
+
+		It("applies settings that are not set yet", func() {
+			settings["ssl_certificate"] = map[string]interface{}{}
+
+			err := command.Execute([]string{
+				"--config", configFile.Name(),
+				"--vars-file", varsFile.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			setting, body := service.UpdateOpsManagerSettingArgsForCall(0)
+			Expect(setting).To(Equal("ssl_certificate"))
+			Expect(body).To(Equal(map[string]interface{}{
+				"ssl_certificate": map[string]interface{}{
+					"certificate": "some-certificate",
+					"private_key": "some-private-key",
+				},
+			}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the flags cannot be parsed", func() {
+				err := command.Execute([]string{"--bogus"})
+				Expect(err).To(MatchError(ContainSubstring("could not parse configure-opsman flags")))
+			})
+
+			It("returns an error when the config file does not exist", func() {
+				err := command.Execute([]string{"--config", "/no/such/file"})
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+
+			It("returns an error when the config file is not valid yaml", func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte("%%%"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--config", configFile.Name()})
+				Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+			})
+
+			It("returns an error when the config file contains unknown settings", func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte("uaa: {}\nbanner: {}\n"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--config", configFile.Name()})
+				Expect(err).To(MatchError("unknown Ops Manager settings uaa, the supported settings are ssl-certificate, banner, smtp, syslog, pivotal-network, rbac"))
+				Expect(service.UpdateOpsManagerSettingCallCount()).To(Equal(0))
+			})
+
+			It("returns an error when a setting is not a map", func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte("banner: hello\n"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--config", configFile.Name()})
+				Expect(err).To(MatchError("could not parse banner: it must be a map of settings"))
+			})
+
+			It("returns an error when a placeholder has no value", func() {
+				err := command.Execute([]string{"--config", configFile.Name()})
+				Expect(err).To(MatchError("could not configure ssl-certificate: no value was given for ((ssl_private_key))"))
+				Expect(service.UpdateOpsManagerSettingCallCount()).To(Equal(0))
+			})
+
+			It("returns an error when the current settings cannot be retrieved", func() {
+				service.GetOpsManagerSettingStub = nil
+				service.GetOpsManagerSettingReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+				})
+				Expect(err).To(MatchError("failed to retrieve banner settings: some error"))
+			})
+
+			It("returns an error when a setting cannot be updated", func() {
+				service.UpdateOpsManagerSettingReturns(errors.New("some error"))
+
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+				})
+				Expect(err).To(MatchError("failed to configure ssl-certificate: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command configures the settings of Ops Manager itself: the SSL certificate, banner, SMTP, syslog, Pivotal Network token and RBAC. Only the sections in the config file are configured, and only when they differ from the current settings.",
+				ShortDescription: "configures Ops Manager settings",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
@@ -33,6 +35,18 @@ type configureProductService interface {
 	UpdateStagedProductProperties(api.UpdateStagedProductPropertiesInput) error
 	UpdateStagedProductNetworksAndAZs(api.UpdateStagedProductNetworksAndAZsInput) error
 	UpdateStagedProductJobResourceConfig(productGUID, jobGUID string, jobProperties api.JobProperties) error
+	ListStagedProductErrands(productID string) (api.ErrandsListOutput, error)
+	UpdateStagedProductErrands(productID, errandName string, postDeployState, preDeleteState interface{}) error
+	UpdateStagedProductSyslogConfiguration(api.UpdateStagedProductSyslogConfigurationInput) error
+	UpdateStagedProductMaxInFlight(api.UpdateStagedProductMaxInFlightInput) error
+	ListVMTypes() ([]api.VMType, error)
+	ListDiskTypes() ([]api.DiskType, error)
+	ListStagedVMExtensions() ([]api.VMExtension, error)
+}
+
+type errandConfig struct {
+	PostDeployState interface{} `yaml:"post-deploy-state,omitempty"`
+	PreDeleteState  interface{} `yaml:"pre-delete-state,omitempty"`
 }
 
 func NewConfigureProduct(service configureProductService, logger logger) ConfigureProduct {
@@ -81,6 +95,9 @@ func (cp ConfigureProduct) Execute(args []string) error {
 		networkProperties string
 		productProperties string
 		productResources  string
+		syslogProperties  string
+		maxInFlight       map[string]interface{}
+		errandConfigs     map[string]errandConfig
 	)
 
 	if cp.Options.ConfigFile != "" {
@@ -115,6 +132,27 @@ func (cp ConfigureProduct) Execute(args []string) error {
 				return err
 			}
 		}
+
+		if config["syslog-properties"] != nil {
+			syslogProperties, err = getJSONProperties(config["syslog-properties"])
+			if err != nil {
+				return err
+			}
+		}
+
+		if config["max-in-flight"] != nil {
+			maxInFlight, err = getMaxInFlight(config["max-in-flight"])
+			if err != nil {
+				return err
+			}
+		}
+
+		if config["errand-config"] != nil {
+			errandConfigs, err = getErrandConfigs(config["errand-config"])
+			if err != nil {
+				return err
+			}
+		}
 	} else {
 		if cp.Options.NetworkProperties != "" {
 			networkProperties = cp.Options.NetworkProperties
@@ -129,6 +167,44 @@ func (cp ConfigureProduct) Execute(args []string) error {
 		}
 	}
 
+	// Everything that can be checked is validated before the first update, so
+	// that a mistake in the config file does not leave a half-configured
+	// product.
+	var (
+		resourceConfig    map[string]json.RawMessage
+		jobs              map[string]string
+		maxInFlightByGUID map[string]interface{}
+	)
+
+	if productResources != "" || len(maxInFlight) > 0 {
+		jobs, err = cp.service.ListStagedProductJobs(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch jobs: %s", err)
+		}
+	}
+
+	if productResources != "" {
+		resourceConfig, err = cp.validateResources(productResources, jobs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(maxInFlight) > 0 {
+		maxInFlightByGUID, err = validateMaxInFlightConfig(maxInFlight, jobs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var errandStates map[string]errandConfig
+	if len(errandConfigs) > 0 {
+		errandStates, err = cp.validateErrands(errandConfigs, productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
 	if networkProperties != "" {
 		err = cp.configureNetwork(networkProperties, productGUID)
 		if err != nil {
@@ -144,7 +220,28 @@ func (cp ConfigureProduct) Execute(args []string) error {
 	}
 
 	if productResources != "" {
-		err = cp.configureResources(productResources, productGUID)
+		err = cp.configureResources(resourceConfig, jobs, productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if syslogProperties != "" {
+		err = cp.configureSyslog(syslogProperties, productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(maxInFlight) > 0 {
+		err = cp.configureMaxInFlight(maxInFlightByGUID, productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(errandConfigs) > 0 {
+		err = cp.configureErrands(errandStates, productGUID)
 		if err != nil {
 			return err
 		}
@@ -177,18 +274,52 @@ func getJSONProperties(properties interface{}) (string, error) {
 	return string(jsonProperties), nil
 }
 
-func (cp ConfigureProduct) configureResources(productResources string, productGUID string) error {
+func getMaxInFlight(maxInFlight interface{}) (map[string]interface{}, error) {
+	contents, err := yaml.Marshal(maxInFlight)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	var maxInFlightByJob map[string]interface{}
+	err = yaml.Unmarshal(contents, &maxInFlightByJob)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse max-in-flight: %s", err)
+	}
+
+	return maxInFlightByJob, nil
+}
+
+func getErrandConfigs(errands interface{}) (map[string]errandConfig, error) {
+	contents, err := yaml.Marshal(errands)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	var errandConfigs map[string]errandConfig
+	err = yaml.Unmarshal(contents, &errandConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse errand-config: %s", err)
+	}
+
+	return errandConfigs, nil
+}
+
+func (cp ConfigureProduct) validateResources(productResources string, jobs map[string]string) (map[string]json.RawMessage, error) {
 	var userProvidedConfig map[string]json.RawMessage
 	err := json.Unmarshal([]byte(productResources), &userProvidedConfig)
 	if err != nil {
-		return fmt.Errorf("could not decode product-resource json: %s", err)
+		return nil, fmt.Errorf("could not decode product-resource json: %s", err)
 	}
 
-	jobs, err := cp.service.ListStagedProductJobs(productGUID)
+	err = validateResourceConfig(cp.service, cp.Options.ProductName, jobs, userProvidedConfig)
 	if err != nil {
-		return fmt.Errorf("failed to fetch jobs: %s", err)
+		return nil, err
 	}
 
+	return userProvidedConfig, nil
+}
+
+func (cp ConfigureProduct) configureResources(userProvidedConfig map[string]json.RawMessage, jobs map[string]string, productGUID string) error {
 	var names []string
 	for name, _ := range userProvidedConfig {
 		names = append(names, name)
@@ -242,3 +373,178 @@ func (cp ConfigureProduct) configureNetwork(networkProperties string, productGUI
 	cp.logger.Printf("finished setting up network")
 	return nil
 }
+
+func (cp ConfigureProduct) configureSyslog(syslogProperties string, productGUID string) error {
+	cp.logger.Printf("setting syslog configuration")
+	err := cp.service.UpdateStagedProductSyslogConfiguration(api.UpdateStagedProductSyslogConfigurationInput{
+		GUID:                productGUID,
+		SyslogConfiguration: syslogProperties,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure syslog: %s", err)
+	}
+	cp.logger.Printf("finished setting syslog configuration")
+	return nil
+}
+
+// validateMaxInFlightConfig checks the job names and values of the
+// max-in-flight config, and returns the values by job guid.
+func validateMaxInFlightConfig(maxInFlight map[string]interface{}, jobs map[string]string) (map[string]interface{}, error) {
+	var jobNames []string
+	for name := range jobs {
+		jobNames = append(jobNames, name)
+	}
+
+	var names []string
+	for name := range maxInFlight {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var errs []string
+	maxInFlightByGUID := map[string]interface{}{}
+	for _, name := range names {
+		jobGUID, ok := jobs[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("job %q does not exist%s", name, didYouMean(name, jobNames)))
+			continue
+		}
+
+		err := validateMaxInFlight(maxInFlight[name])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("max-in-flight for job %q is invalid: %s", name, err))
+			continue
+		}
+
+		maxInFlightByGUID[jobGUID] = maxInFlight[name]
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid max-in-flight: %s", strings.Join(errs, ", "))
+	}
+
+	return maxInFlightByGUID, nil
+}
+
+func (cp ConfigureProduct) configureMaxInFlight(maxInFlightByGUID map[string]interface{}, productGUID string) error {
+	cp.logger.Printf("setting max in flight")
+	err := cp.service.UpdateStagedProductMaxInFlight(api.UpdateStagedProductMaxInFlightInput{
+		GUID:        productGUID,
+		MaxInFlight: maxInFlightByGUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure max in flight: %s", err)
+	}
+	cp.logger.Printf("finished setting max in flight")
+
+	return nil
+}
+
+var maxInFlightPercentage = regexp.MustCompile(`^([1-9][0-9]?|100)%$`)
+
+// validateMaxInFlight accepts a positive number of instances, a percentage
+// of instances (e.g. "20%") or "default".
+func validateMaxInFlight(value interface{}) error {
+	switch v := value.(type) {
+	case int:
+		if v > 0 {
+			return nil
+		}
+	case string:
+		if v == "default" || maxInFlightPercentage.MatchString(v) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%v is not a positive integer or a percentage", value)
+}
+
+// validateErrands checks the errand names and states of the errand config,
+// and returns the states in the form Ops Manager expects.
+func (cp ConfigureProduct) validateErrands(errandConfigs map[string]errandConfig, productGUID string) (map[string]errandConfig, error) {
+	errandsListOutput, err := cp.service.ListStagedProductErrands(productGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list errands: %s", err)
+	}
+
+	var errandNames []string
+	for _, errand := range errandsListOutput.Errands {
+		errandNames = append(errandNames, errand.Name)
+	}
+
+	var names []string
+	for name := range errandConfigs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var errs []string
+	states := map[string]errandConfig{}
+	for _, name := range names {
+		if !contains(errandNames, name) {
+			errs = append(errs, fmt.Sprintf("errand %q does not exist%s", name, didYouMean(name, errandNames)))
+			continue
+		}
+
+		postDeployState, err := errandStateValue(errandConfigs[name].PostDeployState)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("post-deploy-state for errand %q is invalid: %s", name, err))
+		}
+
+		preDeleteState, err := errandStateValue(errandConfigs[name].PreDeleteState)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("pre-delete-state for errand %q is invalid: %s", name, err))
+		} else if preDeleteState == "when-changed" {
+			errs = append(errs, fmt.Sprintf("pre-delete-state for errand %q is invalid: when-changed is only supported for post-deploy errands", name))
+		}
+
+		states[name] = errandConfig{
+			PostDeployState: postDeployState,
+			PreDeleteState:  preDeleteState,
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid errand-config: %s", strings.Join(errs, ", "))
+	}
+
+	return states, nil
+}
+
+func (cp ConfigureProduct) configureErrands(states map[string]errandConfig, productGUID string) error {
+	var names []string
+	for name := range states {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	cp.logger.Printf("applying errand configuration for the following errands:")
+	for _, name := range names {
+		cp.logger.Printf("\t%s", name)
+		err := cp.service.UpdateStagedProductErrands(productGUID, name, states[name].PostDeployState, states[name].PreDeleteState)
+		if err != nil {
+			return fmt.Errorf("failed to set errand state for errand %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// errandStateValue accepts either the values Ops Manager returns for an
+// errand (true, false, "when-changed", "default") or the names used by
+// set-errand-state (enabled, disabled, when-changed, default).
+func errandStateValue(state interface{}) (interface{}, error) {
+	switch s := state.(type) {
+	case nil, bool:
+		return s, nil
+	case string:
+		if value, ok := userToOMInputs[s]; ok {
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%v is not one of true, false, enabled, disabled, when-changed, default", state)
+}
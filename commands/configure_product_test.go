@@ -90,6 +90,24 @@ resource-config:
       id: m1.medium
 `
 
+const syslogAndMaxInFlightFile = `---
+syslog-properties:
+  enabled: true
+  address: example.com
+  port: 514
+max-in-flight:
+  some-job: 2
+  some-other-job: 20%
+`
+
+const errandConfigFile = `---
+errand-config:
+  smoke-tests:
+    post-deploy-state: when-changed
+  delete-all-apps:
+    pre-delete-state: disabled
+`
+
 var _ = Describe("ConfigureProduct", func() {
 	Describe("Execute", func() {
 		var (
@@ -100,6 +118,15 @@ var _ = Describe("ConfigureProduct", func() {
 		BeforeEach(func() {
 			service = &fakes.ConfigureProductService{}
 			logger = &fakes.Logger{}
+
+			service.ListVMTypesReturns([]api.VMType{
+				{Name: "t2.micro"},
+				{Name: "m1.medium"},
+			}, nil)
+			service.ListDiskTypesReturns([]api.DiskType{
+				{Name: "10240"},
+				{Name: "20480"},
+			}, nil)
 		})
 
 		It("configures a product's properties", func() {
@@ -474,6 +501,337 @@ var _ = Describe("ConfigureProduct", func() {
 					Expect(fmt.Sprintf(format, content...)).To(Equal("finished configuring product"))
 				})
 			})
+
+			Context("when the config file contains syslog properties and max in flight", func() {
+				It("configures the syslog and max in flight", func() {
+					client := commands.NewConfigureProduct(service, logger)
+
+					configFile, err = ioutil.TempFile("", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = configFile.WriteString(syslogAndMaxInFlightFile)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = client.Execute([]string{
+						"--product-name", "cf",
+						"--config", configFile.Name(),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(service.UpdateStagedProductSyslogConfigurationCallCount()).To(Equal(1))
+					Expect(service.UpdateStagedProductSyslogConfigurationArgsForCall(0).GUID).To(Equal("some-product-guid"))
+					Expect(service.UpdateStagedProductSyslogConfigurationArgsForCall(0).SyslogConfiguration).To(MatchJSON(`{
+						"enabled": true,
+						"address": "example.com",
+						"port": 514
+					}`))
+
+					Expect(service.ListStagedProductJobsArgsForCall(0)).To(Equal("some-product-guid"))
+					Expect(service.UpdateStagedProductMaxInFlightCallCount()).To(Equal(1))
+					Expect(service.UpdateStagedProductMaxInFlightArgsForCall(0)).To(Equal(api.UpdateStagedProductMaxInFlightInput{
+						GUID: "some-product-guid",
+						MaxInFlight: map[string]interface{}{
+							"a-guid":           2,
+							"a-different-guid": "20%",
+						},
+					}))
+
+					format, content := logger.PrintfArgsForCall(1)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("setting syslog configuration"))
+
+					format, content = logger.PrintfArgsForCall(2)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("finished setting syslog configuration"))
+
+					format, content = logger.PrintfArgsForCall(3)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("setting max in flight"))
+
+					format, content = logger.PrintfArgsForCall(4)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("finished setting max in flight"))
+				})
+
+				Context("when the max in flight is invalid", func() {
+					It("returns an error without configuring max in flight", func() {
+						client := commands.NewConfigureProduct(service, logger)
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(`---
+max-in-flight:
+  some-job: 0
+  some-other-job: 150%
+  router: 1
+`)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError(`invalid max-in-flight: job "router" does not exist, max-in-flight for job "some-job" is invalid: 0 is not a positive integer or a percentage, max-in-flight for job "some-other-job" is invalid: 150% is not a positive integer or a percentage`))
+						Expect(service.UpdateStagedProductMaxInFlightCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a max in flight job name is misspelled", func() {
+					It("returns an error with a suggestion before configuring anything", func() {
+						client := commands.NewConfigureProduct(service, logger)
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(`---
+network-properties:
+  singleton_availability_zone:
+    name: az-one
+product-properties:
+  .properties.something:
+    value: configure-me
+syslog-properties:
+  enabled: true
+max-in-flight:
+  some-jobb: 2
+`)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError(`invalid max-in-flight: job "some-jobb" does not exist (did you mean "some-job"?)`))
+						Expect(service.UpdateStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+						Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(0))
+						Expect(service.UpdateStagedProductSyslogConfigurationCallCount()).To(Equal(0))
+						Expect(service.UpdateStagedProductMaxInFlightCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the syslog fails to configure", func() {
+					It("returns an error", func() {
+						client := commands.NewConfigureProduct(service, logger)
+						service.UpdateStagedProductSyslogConfigurationReturns(errors.New("bad things happened"))
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(syslogAndMaxInFlightFile)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError("failed to configure syslog: bad things happened"))
+					})
+				})
+
+				Context("when the max in flight fails to configure", func() {
+					It("returns an error", func() {
+						client := commands.NewConfigureProduct(service, logger)
+						service.UpdateStagedProductMaxInFlightReturns(errors.New("bad things happened"))
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(syslogAndMaxInFlightFile)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError("failed to configure max in flight: bad things happened"))
+					})
+				})
+			})
+
+			Context("when the config file contains errand config", func() {
+				BeforeEach(func() {
+					service.ListStagedProductErrandsReturns(api.ErrandsListOutput{
+						Errands: []api.Errand{
+							{Name: "smoke-tests", PostDeploy: true},
+							{Name: "delete-all-apps", PreDelete: true},
+						},
+					}, nil)
+				})
+
+				It("sets the state of each errand", func() {
+					client := commands.NewConfigureProduct(service, logger)
+
+					configFile, err = ioutil.TempFile("", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = configFile.WriteString(errandConfigFile)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = client.Execute([]string{
+						"--product-name", "cf",
+						"--config", configFile.Name(),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(service.ListStagedProductErrandsCallCount()).To(Equal(1))
+					Expect(service.ListStagedProductErrandsArgsForCall(0)).To(Equal("some-product-guid"))
+					Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(2))
+
+					productGUID, errandName, postDeployState, preDeleteState := service.UpdateStagedProductErrandsArgsForCall(0)
+					Expect(productGUID).To(Equal("some-product-guid"))
+					Expect(errandName).To(Equal("delete-all-apps"))
+					Expect(postDeployState).To(BeNil())
+					Expect(preDeleteState).To(Equal(false))
+
+					productGUID, errandName, postDeployState, preDeleteState = service.UpdateStagedProductErrandsArgsForCall(1)
+					Expect(productGUID).To(Equal("some-product-guid"))
+					Expect(errandName).To(Equal("smoke-tests"))
+					Expect(postDeployState).To(Equal("when-changed"))
+					Expect(preDeleteState).To(BeNil())
+
+					format, content := logger.PrintfArgsForCall(1)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("applying errand configuration for the following errands:"))
+
+					format, content = logger.PrintfArgsForCall(2)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("\tdelete-all-apps"))
+
+					format, content = logger.PrintfArgsForCall(3)
+					Expect(fmt.Sprintf(format, content...)).To(Equal("\tsmoke-tests"))
+				})
+
+				Context("when an errand does not exist", func() {
+					It("returns an error without changing any errand", func() {
+						client := commands.NewConfigureProduct(service, logger)
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(`---
+errand-config:
+  smoke-tests:
+    post-deploy-state: true
+  not-an-errand:
+    post-deploy-state: true
+`)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError(`invalid errand-config: errand "not-an-errand" does not exist`))
+						Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when an errand name is misspelled", func() {
+					It("returns an error with a suggestion before configuring anything", func() {
+						client := commands.NewConfigureProduct(service, logger)
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(`---
+product-properties:
+  .properties.something:
+    value: configure-me
+errand-config:
+  smoke-test:
+    post-deploy-state: true
+`)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError(`invalid errand-config: errand "smoke-test" does not exist (did you mean "smoke-tests"?)`))
+						Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(0))
+						Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when an errand state is invalid", func() {
+					It("returns an error", func() {
+						client := commands.NewConfigureProduct(service, logger)
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(`---
+errand-config:
+  smoke-tests:
+    post-deploy-state: sometimes
+`)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError(`invalid errand-config: post-deploy-state for errand "smoke-tests" is invalid: sometimes is not one of true, false, enabled, disabled, when-changed, default`))
+						Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a pre-delete errand is set to when-changed", func() {
+					It("returns an error", func() {
+						client := commands.NewConfigureProduct(service, logger)
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(`---
+errand-config:
+  smoke-tests:
+    pre-delete-state: when-changed
+`)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError(`invalid errand-config: pre-delete-state for errand "smoke-tests" is invalid: when-changed is only supported for post-deploy errands`))
+						Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the errands cannot be listed", func() {
+					It("returns an error", func() {
+						client := commands.NewConfigureProduct(service, logger)
+						service.ListStagedProductErrandsReturns(api.ErrandsListOutput{}, errors.New("boom"))
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(errandConfigFile)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError("failed to list errands: boom"))
+					})
+				})
+
+				Context("when an errand state fails to update", func() {
+					It("returns an error", func() {
+						client := commands.NewConfigureProduct(service, logger)
+						service.UpdateStagedProductErrandsReturns(errors.New("bad things happened"))
+
+						configFile, err = ioutil.TempFile("", "")
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = configFile.WriteString(errandConfigFile)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = client.Execute([]string{
+							"--product-name", "cf",
+							"--config", configFile.Name(),
+						})
+						Expect(err).To(MatchError("failed to set errand state for errand delete-all-apps: bad things happened"))
+					})
+				})
+			})
 		})
 
 		Context("when the instance count is not an int", func() {
@@ -633,7 +991,8 @@ var _ = Describe("ConfigureProduct", func() {
 
 					service.ListStagedProductJobsReturns(
 						map[string]string{
-							"some-job": "a-guid",
+							"some-job":       "a-guid",
+							"some-other-job": "a-different-guid",
 						}, nil)
 
 					service.UpdateStagedProductJobResourceConfigReturns(errors.New("bad things happened"))
@@ -643,6 +1002,127 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 			})
 
+			Context("when the resource config is invalid", func() {
+				BeforeEach(func() {
+					service.ListStagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+
+					service.ListStagedProductJobsReturns(
+						map[string]string{
+							"some_job":       "a-guid",
+							"some-other-job": "a-different-guid",
+						}, nil)
+				})
+
+				It("returns an error suggesting close matches before configuring anything", func() {
+					command := commands.NewConfigureProduct(service, logger)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-network", networkProperties,
+						"--product-resources", `{
+							"some-job": {"instances": 1},
+							"some-other-job": {
+								"instance_type": {"id": "m1.mediun"},
+								"persistent_disk": {"size_mb": "12345"}
+							},
+							"unrelated": {"instances": 1}
+						}`,
+					})
+					Expect(err).To(MatchError(`invalid resource configuration: ` +
+						`product "cf" does not contain a job named "some-job" (did you mean "some_job"?), ` +
+						`product "cf" does not contain a job named "unrelated", ` +
+						`instance_type "m1.mediun" for job "some-other-job" is not a known vm type (did you mean "m1.medium"?), ` +
+						`persistent_disk size_mb "12345" for job "some-other-job" is not a known disk type`))
+
+					Expect(service.UpdateStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+					Expect(service.UpdateStagedProductJobResourceConfigCallCount()).To(Equal(0))
+				})
+
+				It("accepts automatic instance types and disks without fetching the catalogs", func() {
+					command := commands.NewConfigureProduct(service, logger)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-resources", `{
+							"some_job": {
+								"instance_type": {"id": "automatic"},
+								"persistent_disk": {"size_mb": "automatic"}
+							}
+						}`,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(service.ListVMTypesCallCount()).To(Equal(0))
+					Expect(service.ListDiskTypesCallCount()).To(Equal(0))
+					Expect(service.ListStagedVMExtensionsCallCount()).To(Equal(0))
+					Expect(service.UpdateStagedProductJobResourceConfigCallCount()).To(Equal(1))
+				})
+
+				Context("when the vm types cannot be fetched", func() {
+					It("returns an error", func() {
+						command := commands.NewConfigureProduct(service, logger)
+						service.ListVMTypesReturns(nil, errors.New("boom"))
+
+						err := command.Execute([]string{
+							"--product-name", "cf",
+							"--product-resources", `{"some_job": {"instance_type": {"id": "m1.medium"}}}`,
+						})
+						Expect(err).To(MatchError("failed to fetch vm types: boom"))
+					})
+				})
+
+				It("rejects additional vm extensions that are not staged", func() {
+					command := commands.NewConfigureProduct(service, logger)
+					service.ListStagedVMExtensionsReturns([]api.VMExtension{
+						{Name: "some-lb-extension"},
+						{Name: "some-iam-extension"},
+					}, nil)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-resources", `{
+							"some_job": {"additional_vm_extensions": ["some-lb-extension", "some-lb-extenson"]},
+							"some-other-job": {"additional_vm_extensions": ["some-iam-extension"]}
+						}`,
+					})
+					Expect(err).To(MatchError(`invalid resource configuration: ` +
+						`additional_vm_extension "some-lb-extenson" for job "some_job" is not a known vm extension (did you mean "some-lb-extension"?)`))
+
+					Expect(service.ListStagedVMExtensionsCallCount()).To(Equal(1))
+					Expect(service.UpdateStagedProductJobResourceConfigCallCount()).To(Equal(0))
+				})
+
+				Context("when the vm extensions cannot be fetched", func() {
+					It("returns an error", func() {
+						command := commands.NewConfigureProduct(service, logger)
+						service.ListStagedVMExtensionsReturns(nil, errors.New("boom"))
+
+						err := command.Execute([]string{
+							"--product-name", "cf",
+							"--product-resources", `{"some_job": {"additional_vm_extensions": ["some-lb-extension"]}}`,
+						})
+						Expect(err).To(MatchError("failed to fetch vm extensions: boom"))
+					})
+				})
+
+				Context("when the disk types cannot be fetched", func() {
+					It("returns an error", func() {
+						command := commands.NewConfigureProduct(service, logger)
+						service.ListDiskTypesReturns(nil, errors.New("boom"))
+
+						err := command.Execute([]string{
+							"--product-name", "cf",
+							"--product-resources", `{"some_job": {"persistent_disk": {"size_mb": "20480"}}}`,
+						})
+						Expect(err).To(MatchError("failed to fetch disk types: boom"))
+					})
+				})
+			})
+
 			Context("when an unknown flag is provided", func() {
 				It("returns an error", func() {
 					command := commands.NewConfigureProduct(service, logger)
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter -o ./fakes/configure_vm_extensions_service.go --fake-name ConfigureVMExtensionsService . configureVMExtensionsService
+type configureVMExtensionsService interface {
+	ListStagedVMExtensions() ([]api.VMExtension, error)
+	CreateStagedVMExtension(api.CreateVMExtension) error
+	UpdateStagedVMExtension(api.CreateVMExtension) error
+	DeleteStagedVMExtension(name string) error
+}
+
+type ConfigureVMExtensions struct {
+	service configureVMExtensionsService
+	logger  logger
+	Options struct {
+		ConfigFile string `long:"config" short:"c" required:"true" description:"path to yml file containing the vm-extensions (see docs/configure-vm-extensions/README.md for format)"`
+	}
+}
+
+type vmExtensionConfig struct {
+	Name            string      `yaml:"name"`
+	CloudProperties interface{} `yaml:"cloud_properties"`
+}
+
+func NewConfigureVMExtensions(service configureVMExtensionsService, logger logger) ConfigureVMExtensions {
+	return ConfigureVMExtensions{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (c ConfigureVMExtensions) Execute(args []string) error {
+	if _, err := jhanda.Parse(&c.Options, args); err != nil {
+		return fmt.Errorf("could not parse configure-vm-extensions flags: %s", err)
+	}
+
+	configContents, err := ioutil.ReadFile(c.Options.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	var config struct {
+		VMExtensions []vmExtensionConfig `yaml:"vm-extensions"`
+	}
+	err = yaml.Unmarshal(configContents, &config)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed as valid configuration: %s", c.Options.ConfigFile, err)
+	}
+
+	desired, err := getVMExtensions(config.VMExtensions)
+	if err != nil {
+		return err
+	}
+
+	existingVMExtensions, err := c.service.ListStagedVMExtensions()
+	if err != nil {
+		return fmt.Errorf("failed to list vm extensions: %s", err)
+	}
+
+	existing := map[string]json.RawMessage{}
+	for _, vmExtension := range existingVMExtensions {
+		existing[vmExtension.Name] = vmExtension.CloudProperties
+	}
+
+	for _, vmExtension := range desired {
+		cloudProperties, ok := existing[vmExtension.Name]
+		switch {
+		case !ok:
+			c.logger.Printf("creating vm extension %s", vmExtension.Name)
+			err = c.service.CreateStagedVMExtension(vmExtension)
+		case !sameCloudProperties(cloudProperties, vmExtension.CloudProperties):
+			c.logger.Printf("updating vm extension %s", vmExtension.Name)
+			err = c.service.UpdateStagedVMExtension(vmExtension)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to configure vm extension %s: %s", vmExtension.Name, err)
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, vmExtension := range desired {
+		wanted[vmExtension.Name] = true
+	}
+
+	for _, vmExtension := range existingVMExtensions {
+		if wanted[vmExtension.Name] {
+			continue
+		}
+
+		c.logger.Printf("deleting vm extension %s", vmExtension.Name)
+		err = c.service.DeleteStagedVMExtension(vmExtension.Name)
+		if err != nil {
+			return fmt.Errorf("failed to delete vm extension %s: %s", vmExtension.Name, err)
+		}
+	}
+
+	c.logger.Printf("finished configuring vm extensions")
+
+	return nil
+}
+
+func (c ConfigureVMExtensions) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command creates, updates and deletes staged VM extensions so that they match the VM extensions in the config file.",
+		ShortDescription: "configures VM extensions",
+		Flags:            c.Options,
+	}
+}
+
+func getVMExtensions(configs []vmExtensionConfig) ([]api.CreateVMExtension, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no vm-extensions found in config file")
+	}
+
+	var (
+		errs         []string
+		names        = map[string]bool{}
+		vmExtensions []api.CreateVMExtension
+	)
+	for i, config := range configs {
+		if config.Name == "" {
+			errs = append(errs, fmt.Sprintf("vm extension at index %d has no name", i))
+			continue
+		}
+
+		if names[config.Name] {
+			errs = append(errs, fmt.Sprintf("vm extension %q is defined more than once", config.Name))
+			continue
+		}
+		names[config.Name] = true
+
+		if config.CloudProperties == nil {
+			config.CloudProperties = map[string]interface{}{}
+		}
+
+		cloudProperties, err := getJSONProperties(config.CloudProperties)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("vm extension %q has invalid cloud_properties: %s", config.Name, err))
+			continue
+		}
+
+		vmExtensions = append(vmExtensions, api.CreateVMExtension{
+			Name:            config.Name,
+			CloudProperties: json.RawMessage(cloudProperties),
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid vm-extensions: %s", strings.Join(errs, ", "))
+	}
+
+	return vmExtensions, nil
+}
+
+func sameCloudProperties(a, b json.RawMessage) bool {
+	var left, right interface{}
+	if err := json.Unmarshal(a, &left); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &right); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(left, right)
+}
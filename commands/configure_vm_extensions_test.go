@@ -0,0 +1,197 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const vmExtensionsFile = `---
+vm-extensions:
+- name: some-lb-extension
+  cloud_properties:
+    elbs: [some-elb]
+- name: some-iam-extension
+  cloud_properties:
+    iam_instance_profile: some-iam-profile
+- name: some-unchanged-extension
+  cloud_properties:
+    ephemeral_disk:
+      size: 10240
+`
+
+var _ = Describe("ConfigureVMExtensions", func() {
+	var (
+		service    *fakes.ConfigureVMExtensionsService
+		logger     *fakes.Logger
+		command    commands.ConfigureVMExtensions
+		configFile *os.File
+	)
+
+	BeforeEach(func() {
+		service = &fakes.ConfigureVMExtensionsService{}
+		logger = &fakes.Logger{}
+		command = commands.NewConfigureVMExtensions(service, logger)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = configFile.WriteString(vmExtensionsFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		service.ListStagedVMExtensionsReturns([]api.VMExtension{
+			{Name: "some-iam-extension", CloudProperties: json.RawMessage(`{"iam_instance_profile": "some-old-iam-profile"}`)},
+			{Name: "some-unchanged-extension", CloudProperties: json.RawMessage(`{"ephemeral_disk": {"size": 10240}}`)},
+			{Name: "some-stale-extension", CloudProperties: json.RawMessage(`{}`)},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(configFile.Name())
+	})
+
+	Describe("Execute", func() {
+		It("converges the staged vm extensions on the ones in the config file", func() {
+			err := command.Execute([]string{"--config", configFile.Name()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.CreateStagedVMExtensionCallCount()).To(Equal(1))
+			created := service.CreateStagedVMExtensionArgsForCall(0)
+			Expect(created.Name).To(Equal("some-lb-extension"))
+			Expect(created.CloudProperties).To(MatchJSON(`{"elbs": ["some-elb"]}`))
+
+			Expect(service.UpdateStagedVMExtensionCallCount()).To(Equal(1))
+			updated := service.UpdateStagedVMExtensionArgsForCall(0)
+			Expect(updated.Name).To(Equal("some-iam-extension"))
+			Expect(updated.CloudProperties).To(MatchJSON(`{"iam_instance_profile": "some-iam-profile"}`))
+
+			Expect(service.DeleteStagedVMExtensionCallCount()).To(Equal(1))
+			Expect(service.DeleteStagedVMExtensionArgsForCall(0)).To(Equal("some-stale-extension"))
+
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, content := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, content...))
+			}
+			Expect(lines).To(Equal([]string{
+				"creating vm extension some-lb-extension",
+				"updating vm extension some-iam-extension",
+				"deleting vm extension some-stale-extension",
+				"finished configuring vm extensions",
+			}))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse configure-vm-extensions flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the config file does not exist", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--config", "some/non-existent/path.yml"})
+					Expect(err).To(MatchError("open some/non-existent/path.yml: no such file or directory"))
+				})
+			})
+
+			Context("when the config file is not valid yaml", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("%%%"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+				})
+			})
+
+			Context("when the config file has no vm extensions", func() {
+				It("returns an error without deleting anything", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("---\nvm-extension: []\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("no vm-extensions found in config file"))
+
+					Expect(service.ListStagedVMExtensionsCallCount()).To(Equal(0))
+					Expect(service.DeleteStagedVMExtensionCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the vm extensions are invalid", func() {
+				It("returns an error without changing anything", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte(`---
+vm-extensions:
+- cloud_properties: {}
+- name: some-lb-extension
+- name: some-lb-extension
+`), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`invalid vm-extensions: vm extension at index 0 has no name, vm extension "some-lb-extension" is defined more than once`))
+
+					Expect(service.ListStagedVMExtensionsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when listing the vm extensions fails", func() {
+				It("returns an error", func() {
+					service.ListStagedVMExtensionsReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to list vm extensions: boom"))
+				})
+			})
+
+			Context("when creating a vm extension fails", func() {
+				It("returns an error", func() {
+					service.CreateStagedVMExtensionReturns(errors.New("boom"))
+
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to configure vm extension some-lb-extension: boom"))
+				})
+			})
+
+			Context("when updating a vm extension fails", func() {
+				It("returns an error", func() {
+					service.UpdateStagedVMExtensionReturns(errors.New("boom"))
+
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to configure vm extension some-iam-extension: boom"))
+				})
+			})
+
+			Context("when deleting a vm extension fails", func() {
+				It("returns an error", func() {
+					service.DeleteStagedVMExtensionReturns(errors.New("boom"))
+
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to delete vm extension some-stale-extension: boom"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command creates, updates and deletes staged VM extensions so that they match the VM extensions in the config file.",
+				ShortDescription: "configures VM extensions",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter -o ./fakes/configure_vm_types_service.go --fake-name ConfigureVMTypesService . configureVMTypesService
+type configureVMTypesService interface {
+	ListVMTypes() ([]api.VMType, error)
+	CreateCustomVMTypes(api.CreateVMTypes) error
+}
+
+type ConfigureVMTypes struct {
+	service configureVMTypesService
+	logger  logger
+	Options struct {
+		ConfigFile string `long:"config" short:"c" required:"true" description:"path to yml file containing the vm-types (see docs/configure-vm-types/README.md for format)"`
+		Merge      bool   `long:"merge"  short:"m"                 description:"keep the current VM types, replacing any with the same name"`
+	}
+}
+
+func NewConfigureVMTypes(service configureVMTypesService, logger logger) ConfigureVMTypes {
+	return ConfigureVMTypes{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (c ConfigureVMTypes) Execute(args []string) error {
+	if _, err := jhanda.Parse(&c.Options, args); err != nil {
+		return fmt.Errorf("could not parse configure-vm-types flags: %s", err)
+	}
+
+	configContents, err := ioutil.ReadFile(c.Options.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	var config struct {
+		VMTypes []api.CreateVMType `yaml:"vm-types"`
+	}
+	err = yaml.Unmarshal(configContents, &config)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed as valid configuration: %s", c.Options.ConfigFile, err)
+	}
+
+	err = validateVMTypes(config.VMTypes)
+	if err != nil {
+		return err
+	}
+
+	vmTypes := config.VMTypes
+
+	if c.Options.Merge {
+		c.logger.Printf("merging vm types with the current vm types")
+		defaultVMTypes, err := c.service.ListVMTypes()
+		if err != nil {
+			return fmt.Errorf("failed to list vm types: %s", err)
+		}
+
+		vmTypes = mergeVMTypes(defaultVMTypes, config.VMTypes)
+	}
+
+	c.logger.Printf("configuring vm types...")
+	err = c.service.CreateCustomVMTypes(api.CreateVMTypes{
+		VMTypes: vmTypes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure vm types: %s", err)
+	}
+	c.logger.Printf("finished configuring vm types")
+
+	return nil
+}
+
+func (c ConfigureVMTypes) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command replaces the VM types available to the director with the VM types in the config file.",
+		ShortDescription: "configures custom VM types",
+		Flags:            c.Options,
+	}
+}
+
+func validateVMTypes(vmTypes []api.CreateVMType) error {
+	if len(vmTypes) == 0 {
+		return fmt.Errorf("no vm-types found in config file")
+	}
+
+	var (
+		errs  []string
+		names = map[string]bool{}
+	)
+	for i, vmType := range vmTypes {
+		if vmType.Name == "" {
+			errs = append(errs, fmt.Sprintf("vm type at index %d has no name", i))
+			continue
+		}
+
+		if names[vmType.Name] {
+			errs = append(errs, fmt.Sprintf("vm type %q is defined more than once", vmType.Name))
+		}
+		names[vmType.Name] = true
+
+		if vmType.CPU <= 0 || vmType.RAM <= 0 || vmType.EphemeralDisk <= 0 {
+			errs = append(errs, fmt.Sprintf("vm type %q must have a positive cpu, ram and ephemeral_disk", vmType.Name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid vm-types: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// mergeVMTypes keeps the current VM types that are not overridden by the
+// given VM types. Every current VM type is kept rather than only the
+// built-in ones, as the defaults are stored as custom VM types once they have
+// been merged.
+func mergeVMTypes(currentVMTypes []api.VMType, vmTypes []api.CreateVMType) []api.CreateVMType {
+	overridden := map[string]bool{}
+	for _, vmType := range vmTypes {
+		overridden[vmType.Name] = true
+	}
+
+	var merged []api.CreateVMType
+	for _, vmType := range currentVMTypes {
+		if !overridden[vmType.Name] {
+			merged = append(merged, api.CreateVMType{
+				Name:          vmType.Name,
+				RAM:           vmType.RAM,
+				CPU:           vmType.CPU,
+				EphemeralDisk: vmType.EphemeralDisk,
+			})
+		}
+	}
+
+	return append(merged, vmTypes...)
+}
@@ -0,0 +1,215 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const vmTypesFile = `---
+vm-types:
+- name: memory-heavy
+  cpu: 8
+  ram: 65536
+  ephemeral_disk: 32768
+- name: micro
+  cpu: 2
+  ram: 2048
+  ephemeral_disk: 10240
+`
+
+var _ = Describe("ConfigureVMTypes", func() {
+	var (
+		service    *fakes.ConfigureVMTypesService
+		logger     *fakes.Logger
+		command    commands.ConfigureVMTypes
+		configFile *os.File
+	)
+
+	BeforeEach(func() {
+		service = &fakes.ConfigureVMTypesService{}
+		logger = &fakes.Logger{}
+		command = commands.NewConfigureVMTypes(service, logger)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = configFile.WriteString(vmTypesFile)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(configFile.Name())
+	})
+
+	Describe("Execute", func() {
+		It("replaces the vm types with the ones in the config file", func() {
+			err := command.Execute([]string{"--config", configFile.Name()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.ListVMTypesCallCount()).To(Equal(0))
+			Expect(service.CreateCustomVMTypesCallCount()).To(Equal(1))
+			Expect(service.CreateCustomVMTypesArgsForCall(0)).To(Equal(api.CreateVMTypes{
+				VMTypes: []api.CreateVMType{
+					{Name: "memory-heavy", CPU: 8, RAM: 65536, EphemeralDisk: 32768},
+					{Name: "micro", CPU: 2, RAM: 2048, EphemeralDisk: 10240},
+				},
+			}))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("configuring vm types..."))
+
+			format, content = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("finished configuring vm types"))
+		})
+
+		Context("when --merge is provided", func() {
+			BeforeEach(func() {
+				service.ListVMTypesReturns([]api.VMType{
+					{Name: "nano", CPU: 1, RAM: 512, EphemeralDisk: 1024, BuiltIn: true},
+					{Name: "micro", CPU: 1, RAM: 1024, EphemeralDisk: 8192, BuiltIn: true},
+					{Name: "old-custom", CPU: 4, RAM: 4096, EphemeralDisk: 8192},
+				}, nil)
+			})
+
+			It("keeps the current vm types alongside the vm types in the config file in a single update", func() {
+				err := command.Execute([]string{"--config", configFile.Name(), "--merge"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.ListVMTypesCallCount()).To(Equal(1))
+				Expect(service.CreateCustomVMTypesCallCount()).To(Equal(1))
+				Expect(service.CreateCustomVMTypesArgsForCall(0)).To(Equal(api.CreateVMTypes{
+					VMTypes: []api.CreateVMType{
+						{Name: "nano", CPU: 1, RAM: 512, EphemeralDisk: 1024},
+						{Name: "old-custom", CPU: 4, RAM: 4096, EphemeralDisk: 8192},
+						{Name: "memory-heavy", CPU: 8, RAM: 65536, EphemeralDisk: 32768},
+						{Name: "micro", CPU: 2, RAM: 2048, EphemeralDisk: 10240},
+					},
+				}))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("merging vm types with the current vm types"))
+			})
+
+			It("keeps the defaults when merging again after they have been stored as custom vm types", func() {
+				var stored []api.VMType
+				service.CreateCustomVMTypesStub = func(input api.CreateVMTypes) error {
+					stored = nil
+					for _, vmType := range input.VMTypes {
+						stored = append(stored, api.VMType{Name: vmType.Name, CPU: vmType.CPU, RAM: vmType.RAM, EphemeralDisk: vmType.EphemeralDisk})
+					}
+					return nil
+				}
+
+				err := command.Execute([]string{"--config", configFile.Name(), "--merge"})
+				Expect(err).NotTo(HaveOccurred())
+
+				service.ListVMTypesReturns(stored, nil)
+
+				err = command.Execute([]string{"--config", configFile.Name(), "--merge"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.CreateCustomVMTypesCallCount()).To(Equal(2))
+				Expect(service.CreateCustomVMTypesArgsForCall(1)).To(Equal(service.CreateCustomVMTypesArgsForCall(0)))
+				Expect(service.CreateCustomVMTypesArgsForCall(1).VMTypes).To(ContainElement(api.CreateVMType{Name: "nano", CPU: 1, RAM: 512, EphemeralDisk: 1024}))
+			})
+
+			Context("when listing the vm types fails", func() {
+				It("returns an error", func() {
+					service.ListVMTypesReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--config", configFile.Name(), "--merge"})
+					Expect(err).To(MatchError("failed to list vm types: boom"))
+					Expect(service.CreateCustomVMTypesCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse configure-vm-types flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the config file does not exist", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--config", "some/non-existent/path.yml"})
+					Expect(err).To(MatchError("open some/non-existent/path.yml: no such file or directory"))
+				})
+			})
+
+			Context("when the config file is not valid yaml", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("%%%"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+				})
+			})
+
+			Context("when the config file has no vm types", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("---\nvm-types: []\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("no vm-types found in config file"))
+				})
+			})
+
+			Context("when the vm types are invalid", func() {
+				It("returns an error without configuring anything", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte(`---
+vm-types:
+- cpu: 1
+- name: micro
+  cpu: 1
+  ram: 1024
+  ephemeral_disk: 1024
+- name: micro
+  cpu: 0
+  ram: 1024
+  ephemeral_disk: 1024
+`), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`invalid vm-types: vm type at index 0 has no name, vm type "micro" is defined more than once, vm type "micro" must have a positive cpu, ram and ephemeral_disk`))
+					Expect(service.CreateCustomVMTypesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when configuring the vm types fails", func() {
+				It("returns an error", func() {
+					service.CreateCustomVMTypesReturns(errors.New("boom"))
+
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to configure vm types: boom"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command replaces the VM types available to the director with the VM types in the config file.",
+				ShortDescription: "configures custom VM types",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+type CreateUAAClient struct {
+	service createUAAClientService
+	logger  logger
+	Options struct {
+		ClientID             string   `long:"client-id"              short:"i" required:"true" description:"id of the new client"`
+		ClientSecret         string   `long:"client-secret"          short:"s" required:"true" description:"secret of the new client"`
+		AuthorizedGrantTypes []string `long:"authorized-grant-types" short:"g"                 description:"grant types the client can use, defaults to client_credentials (can be given more than once or comma-separated)"`
+		Authorities          []string `long:"authorities"            short:"a"                 description:"authorities of the client for the client_credentials grant, such as opsman.admin or opsman.restricted_view (can be given more than once or comma-separated)"`
+		Scope                []string `long:"scope"                                            description:"scopes the client can request on behalf of users (can be given more than once or comma-separated)"`
+		AccessTokenValidity  int      `long:"access-token-validity"                            description:"access token validity in seconds, defaults to the uaa setting"`
+		RefreshTokenValidity int      `long:"refresh-token-validity"                           description:"refresh token validity in seconds, defaults to the uaa setting"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/create_uaa_client_service.go --fake-name CreateUAAClientService . createUAAClientService
+type createUAAClientService interface {
+	CreateUAAClient(client api.UAAClient) error
+}
+
+func NewCreateUAAClient(service createUAAClientService, logger logger) CreateUAAClient {
+	return CreateUAAClient{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (cuc CreateUAAClient) Execute(args []string) error {
+	if _, err := jhanda.Parse(&cuc.Options, args); err != nil {
+		return fmt.Errorf("could not parse create-uaa-client flags: %s", err)
+	}
+
+	grantTypes := splitValues(cuc.Options.AuthorizedGrantTypes)
+	if len(grantTypes) == 0 {
+		grantTypes = []string{"client_credentials"}
+	}
+
+	err := cuc.service.CreateUAAClient(api.UAAClient{
+		ClientID:             cuc.Options.ClientID,
+		ClientSecret:         cuc.Options.ClientSecret,
+		AuthorizedGrantTypes: grantTypes,
+		Authorities:          splitValues(cuc.Options.Authorities),
+		Scope:                splitValues(cuc.Options.Scope),
+		AccessTokenValidity:  cuc.Options.AccessTokenValidity,
+		RefreshTokenValidity: cuc.Options.RefreshTokenValidity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create uaa client: %s", err)
+	}
+
+	cuc.logger.Printf("created uaa client %s", cuc.Options.ClientID)
+
+	return nil
+}
+
+func (cuc CreateUAAClient) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command creates a client in the Ops Manager uaa, so that automation can authenticate with its own scoped credentials instead of the admin user.",
+		ShortDescription: "creates a uaa client",
+		Flags:            cuc.Options,
+	}
+}
+
+// splitValues splits comma-separated flag values, so that lists can be given
+// either as repeated flags or in a single flag.
+func splitValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+
+	return split
+}
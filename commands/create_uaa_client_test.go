@@ -0,0 +1,95 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateUAAClient", func() {
+	var (
+		service *fakes.CreateUAAClientService
+		logger  *fakes.Logger
+		command commands.CreateUAAClient
+	)
+
+	BeforeEach(func() {
+		service = &fakes.CreateUAAClientService{}
+		logger = &fakes.Logger{}
+		command = commands.NewCreateUAAClient(service, logger)
+	})
+
+	Describe("Execute", func() {
+		It("creates a client_credentials client by default", func() {
+			err := command.Execute([]string{
+				"--client-id", "pipeline",
+				"--client-secret", "some-secret",
+				"--authorities", "opsman.admin",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.CreateUAAClientArgsForCall(0)).To(Equal(api.UAAClient{
+				ClientID:             "pipeline",
+				ClientSecret:         "some-secret",
+				AuthorizedGrantTypes: []string{"client_credentials"},
+				Authorities:          []string{"opsman.admin"},
+			}))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("created uaa client pipeline"))
+		})
+
+		It("accepts repeated and comma-separated values", func() {
+			err := command.Execute([]string{
+				"--client-id", "dashboard",
+				"--client-secret", "some-secret",
+				"--authorized-grant-types", "password,refresh_token",
+				"--scope", "opsman.restricted_view",
+				"--scope", "uaa.user, openid",
+				"--access-token-validity", "3600",
+				"--refresh-token-validity", "86400",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.CreateUAAClientArgsForCall(0)).To(Equal(api.UAAClient{
+				ClientID:             "dashboard",
+				ClientSecret:         "some-secret",
+				AuthorizedGrantTypes: []string{"password", "refresh_token"},
+				Scope:                []string{"opsman.restricted_view", "uaa.user", "openid"},
+				AccessTokenValidity:  3600,
+				RefreshTokenValidity: 86400,
+			}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the client id is missing", func() {
+				err := command.Execute([]string{"--client-secret", "some-secret"})
+				Expect(err).To(MatchError("could not parse create-uaa-client flags: missing required flag \"--client-id\""))
+			})
+
+			It("returns an error when the client cannot be created", func() {
+				service.CreateUAAClientReturns(errors.New("uaa client pipeline already exists"))
+
+				err := command.Execute([]string{"--client-id", "pipeline", "--client-secret", "some-secret"})
+				Expect(err).To(MatchError("failed to create uaa client: uaa client pipeline already exists"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command creates a client in the Ops Manager uaa, so that automation can authenticate with its own scoped credentials instead of the admin user.",
+				ShortDescription: "creates a uaa client",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
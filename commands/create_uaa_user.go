@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+type CreateUAAUser struct {
+	service createUAAUserService
+	logger  logger
+	Options struct {
+		Username string   `long:"username" short:"u" required:"true" description:"name of the new user"`
+		Password string   `long:"password" short:"p" required:"true" description:"password of the new user"`
+		Email    string   `long:"email"    short:"e" required:"true" description:"email address of the new user"`
+		Groups   []string `long:"group"    short:"g"                 description:"group to add the user to, which grants the scope of the same name, such as opsman.admin or opsman.restricted_view (can be given more than once or comma-separated)"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/create_uaa_user_service.go --fake-name CreateUAAUserService . createUAAUserService
+type createUAAUserService interface {
+	CreateUAAUser(input api.CreateUAAUserInput) (string, error)
+	AddUAAGroupMember(group, userID string) error
+}
+
+func NewCreateUAAUser(service createUAAUserService, logger logger) CreateUAAUser {
+	return CreateUAAUser{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (cuu CreateUAAUser) Execute(args []string) error {
+	if _, err := jhanda.Parse(&cuu.Options, args); err != nil {
+		return fmt.Errorf("could not parse create-uaa-user flags: %s", err)
+	}
+
+	userID, err := cuu.service.CreateUAAUser(api.CreateUAAUserInput{
+		Username: cuu.Options.Username,
+		Password: cuu.Options.Password,
+		Email:    cuu.Options.Email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create uaa user: %s", err)
+	}
+
+	cuu.logger.Printf("created uaa user %s", cuu.Options.Username)
+
+	for _, group := range splitValues(cuu.Options.Groups) {
+		err = cuu.service.AddUAAGroupMember(group, userID)
+		if err != nil {
+			return fmt.Errorf("failed to add uaa user %s to %s: %s", cuu.Options.Username, group, err)
+		}
+
+		cuu.logger.Printf("added uaa user %s to %s", cuu.Options.Username, group)
+	}
+
+	return nil
+}
+
+func (cuu CreateUAAUser) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command creates a user in the Ops Manager uaa internal userstore, and adds it to groups to grant it scopes.",
+		ShortDescription: "creates a uaa user",
+		Flags:            cuu.Options,
+	}
+}
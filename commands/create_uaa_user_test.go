@@ -0,0 +1,100 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateUAAUser", func() {
+	var (
+		service *fakes.CreateUAAUserService
+		logger  *fakes.Logger
+		command commands.CreateUAAUser
+	)
+
+	BeforeEach(func() {
+		service = &fakes.CreateUAAUserService{}
+		service.CreateUAAUserReturns("some-user-id", nil)
+		logger = &fakes.Logger{}
+		command = commands.NewCreateUAAUser(service, logger)
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	Describe("Execute", func() {
+		It("creates the user and adds it to the groups", func() {
+			err := command.Execute([]string{
+				"--username", "auditor",
+				"--password", "some-password",
+				"--email", "auditor@example.com",
+				"--group", "opsman.restricted_view,scim.me",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.CreateUAAUserArgsForCall(0)).To(Equal(api.CreateUAAUserInput{
+				Username: "auditor",
+				Password: "some-password",
+				Email:    "auditor@example.com",
+			}))
+
+			Expect(service.AddUAAGroupMemberCallCount()).To(Equal(2))
+			group, userID := service.AddUAAGroupMemberArgsForCall(0)
+			Expect(group).To(Equal("opsman.restricted_view"))
+			Expect(userID).To(Equal("some-user-id"))
+			group, _ = service.AddUAAGroupMemberArgsForCall(1)
+			Expect(group).To(Equal("scim.me"))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"created uaa user auditor",
+				"added uaa user auditor to opsman.restricted_view",
+				"added uaa user auditor to scim.me",
+			}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the email is missing", func() {
+				err := command.Execute([]string{"--username", "auditor", "--password", "some-password"})
+				Expect(err).To(MatchError("could not parse create-uaa-user flags: missing required flag \"--email\""))
+			})
+
+			It("returns an error when the user cannot be created", func() {
+				service.CreateUAAUserReturns("", errors.New("uaa user auditor already exists"))
+
+				err := command.Execute([]string{"--username", "auditor", "--password", "some-password", "--email", "auditor@example.com"})
+				Expect(err).To(MatchError("failed to create uaa user: uaa user auditor already exists"))
+			})
+
+			It("returns an error when the user cannot be added to a group", func() {
+				service.AddUAAGroupMemberReturns(errors.New("uaa group opsman.bogus does not exist"))
+
+				err := command.Execute([]string{"--username", "auditor", "--password", "some-password", "--email", "auditor@example.com", "--group", "opsman.bogus"})
+				Expect(err).To(MatchError("failed to add uaa user auditor to opsman.bogus: uaa group opsman.bogus does not exist"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command creates a user in the Ops Manager uaa internal userstore, and adds it to groups to grant it scopes.",
+				ShortDescription: "creates a uaa user",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+)
+
+type DeleteUAAClient struct {
+	service deleteUAAClientService
+	logger  logger
+	Options struct {
+		ClientID string `long:"client-id" short:"i" required:"true" description:"id of the client to delete"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/delete_uaa_client_service.go --fake-name DeleteUAAClientService . deleteUAAClientService
+type deleteUAAClientService interface {
+	DeleteUAAClient(clientID string) error
+}
+
+func NewDeleteUAAClient(service deleteUAAClientService, logger logger) DeleteUAAClient {
+	return DeleteUAAClient{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (duc DeleteUAAClient) Execute(args []string) error {
+	if _, err := jhanda.Parse(&duc.Options, args); err != nil {
+		return fmt.Errorf("could not parse delete-uaa-client flags: %s", err)
+	}
+
+	err := duc.service.DeleteUAAClient(duc.Options.ClientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete uaa client: %s", err)
+	}
+
+	duc.logger.Printf("deleted uaa client %s", duc.Options.ClientID)
+
+	return nil
+}
+
+func (duc DeleteUAAClient) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command deletes a client from the Ops Manager uaa.",
+		ShortDescription: "deletes a uaa client",
+		Flags:            duc.Options,
+	}
+}
@@ -0,0 +1,63 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteUAAClient", func() {
+	var (
+		service *fakes.DeleteUAAClientService
+		logger  *fakes.Logger
+		command commands.DeleteUAAClient
+	)
+
+	BeforeEach(func() {
+		service = &fakes.DeleteUAAClientService{}
+		logger = &fakes.Logger{}
+		command = commands.NewDeleteUAAClient(service, logger)
+	})
+
+	Describe("Execute", func() {
+		It("deletes the client", func() {
+			err := command.Execute([]string{"--client-id", "pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.DeleteUAAClientArgsForCall(0)).To(Equal("pipeline"))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("deleted uaa client pipeline"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the client id is missing", func() {
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("could not parse delete-uaa-client flags: missing required flag \"--client-id\""))
+			})
+
+			It("returns an error when the client cannot be deleted", func() {
+				service.DeleteUAAClientReturns(errors.New("uaa client pipeline does not exist"))
+
+				err := command.Execute([]string{"--client-id", "pipeline"})
+				Expect(err).To(MatchError("failed to delete uaa client: uaa client pipeline does not exist"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command deletes a client from the Ops Manager uaa.",
+				ShortDescription: "deletes a uaa client",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package commands
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
@@ -8,11 +12,19 @@ import (
 type DeleteUnusedProducts struct {
 	service deleteUnusedProductsService
 	logger  logger
+	Options struct {
+		ProductNames     []string `long:"product-name"       short:"p" description:"only delete versions of this product (can be given more than once)"`
+		KeepLatest       int      `long:"keep-latest"                  description:"number of the most recent versions of each product to keep"`
+		KeepVersionRegex string   `long:"keep-version-regex"           description:"keep versions matching this regular expression"`
+		DryRun           bool     `long:"dry-run"                      description:"list the products that would be deleted without deleting them"`
+	}
 }
 
 //go:generate counterfeiter -o ./fakes/delete_unused_products_service.go --fake-name DeleteUnusedProductsService . deleteUnusedProductsService
 type deleteUnusedProductsService interface {
 	DeleteAvailableProducts(input api.DeleteAvailableProductsInput) error
+	ListAvailableProducts() (api.AvailableProductsOutput, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
 func NewDeleteUnusedProducts(service deleteUnusedProductsService, logger logger) DeleteUnusedProducts {
@@ -23,15 +35,68 @@ func NewDeleteUnusedProducts(service deleteUnusedProductsService, logger logger)
 }
 
 func (dup DeleteUnusedProducts) Execute(args []string) error {
-	dup.logger.Printf("trashing unused products")
+	if _, err := jhanda.Parse(&dup.Options, args); err != nil {
+		return fmt.Errorf("could not parse delete-unused-products flags: %s", err)
+	}
 
-	err := dup.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
-		ShouldDeleteAllProducts: true,
-	})
+	if len(dup.Options.ProductNames) == 0 && dup.Options.KeepLatest == 0 && dup.Options.KeepVersionRegex == "" && !dup.Options.DryRun {
+		dup.logger.Printf("trashing unused products")
+
+		err := dup.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
+			ShouldDeleteAllProducts: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		dup.logger.Printf("done")
+
+		return nil
+	}
+
+	if dup.Options.KeepLatest < 0 {
+		return fmt.Errorf("--keep-latest must not be negative")
+	}
+
+	var keepRegex *regexp.Regexp
+	if dup.Options.KeepVersionRegex != "" {
+		var err error
+		keepRegex, err = regexp.Compile(dup.Options.KeepVersionRegex)
+		if err != nil {
+			return fmt.Errorf("invalid --keep-version-regex: %s", err)
+		}
+	}
+
+	deletions, err := dup.unusedProducts(keepRegex)
 	if err != nil {
 		return err
 	}
 
+	if len(deletions) == 0 {
+		dup.logger.Printf("no unused products to delete")
+		return nil
+	}
+
+	if dup.Options.DryRun {
+		for _, product := range deletions {
+			dup.logger.Printf("would delete %s %s", product.Name, product.Version)
+		}
+
+		return nil
+	}
+
+	for _, product := range deletions {
+		dup.logger.Printf("deleting %s %s", product.Name, product.Version)
+
+		err := dup.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
+			ProductName:    product.Name,
+			ProductVersion: product.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete %s %s: %s", product.Name, product.Version, err)
+		}
+	}
+
 	dup.logger.Printf("done")
 
 	return nil
@@ -41,5 +106,56 @@ func (dup DeleteUnusedProducts) Usage() jhanda.Usage {
 	return jhanda.Usage{
 		Description:      "This command deletes unused products in the targeted Ops Manager",
 		ShortDescription: "deletes unused products on the Ops Manager targeted",
+		Flags:            dup.Options,
+	}
+}
+
+// unusedProducts returns the available products that are neither staged,
+// deployed, nor kept by the retention options, grouped by product name and
+// from the newest version to the oldest.
+func (dup DeleteUnusedProducts) unusedProducts(keepRegex *regexp.Regexp) ([]api.ProductInfo, error) {
+	available, err := dup.service.ListAvailableProducts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available products: %s", err)
+	}
+
+	report, err := dup.service.GetDiagnosticReport()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve staged and deployed products: %s", err)
+	}
+
+	inUse := map[api.ProductInfo]bool{}
+	for _, product := range append(report.StagedProducts, report.DeployedProducts...) {
+		inUse[api.ProductInfo{Name: product.Name, Version: product.Version}] = true
 	}
+
+	products := append([]api.ProductInfo{}, available.ProductsList...)
+	sort.SliceStable(products, func(i, j int) bool {
+		if products[i].Name != products[j].Name {
+			return products[i].Name < products[j].Name
+		}
+
+		return compareVersions(products[i].Version, products[j].Version) > 0
+	})
+
+	var deletions []api.ProductInfo
+	kept := map[string]int{}
+	for _, product := range products {
+		if len(dup.Options.ProductNames) > 0 && !contains(dup.Options.ProductNames, product.Name) {
+			continue
+		}
+
+		if kept[product.Name] < dup.Options.KeepLatest {
+			kept[product.Name]++
+			continue
+		}
+
+		if inUse[product] || (keepRegex != nil && keepRegex.MatchString(product.Version)) {
+			continue
+		}
+
+		deletions = append(deletions, product)
+	}
+
+	return deletions, nil
 }
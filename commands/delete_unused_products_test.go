@@ -46,15 +46,164 @@ var _ = Describe("DeleteProduct", func() {
 		})
 	})
 
+	Context("when retention options are given", func() {
+		BeforeEach(func() {
+			fakeService.ListAvailableProductsReturns(api.AvailableProductsOutput{
+				ProductsList: []api.ProductInfo{
+					{Name: "cf", Version: "2.2.9"},
+					{Name: "p-redis", Version: "1.13.0"},
+					{Name: "cf", Version: "2.1.7"},
+					{Name: "cf", Version: "2.2.10"},
+					{Name: "cf", Version: "2.3.0-build.12"},
+					{Name: "p-redis", Version: "1.14.2"},
+					{Name: "cf", Version: "2.0.3"},
+				},
+			}, nil)
+
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				StagedProducts:   []api.DiagnosticProduct{{Name: "cf", Version: "2.2.9"}},
+				DeployedProducts: []api.DiagnosticProduct{{Name: "cf", Version: "2.1.7"}},
+			}, nil)
+		})
+
+		loggedLines := func() []string {
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, content := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, content...))
+			}
+			return lines
+		}
+
+		deleted := func() []api.DeleteAvailableProductsInput {
+			var inputs []api.DeleteAvailableProductsInput
+			for i := 0; i < fakeService.DeleteAvailableProductsCallCount(); i++ {
+				inputs = append(inputs, fakeService.DeleteAvailableProductsArgsForCall(i))
+			}
+			return inputs
+		}
+
+		It("keeps the most recent versions of each product and the ones in use", func() {
+			err := command.Execute([]string{"--keep-latest", "1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted()).To(Equal([]api.DeleteAvailableProductsInput{
+				{ProductName: "cf", ProductVersion: "2.2.10"},
+				{ProductName: "cf", ProductVersion: "2.0.3"},
+				{ProductName: "p-redis", ProductVersion: "1.13.0"},
+			}))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"deleting cf 2.2.10",
+				"deleting cf 2.0.3",
+				"deleting p-redis 1.13.0",
+				"done",
+			}))
+		})
+
+		It("keeps the versions matching the regex", func() {
+			err := command.Execute([]string{"--keep-version-regex", `^2\.2\.`})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted()).To(Equal([]api.DeleteAvailableProductsInput{
+				{ProductName: "cf", ProductVersion: "2.3.0-build.12"},
+				{ProductName: "cf", ProductVersion: "2.0.3"},
+				{ProductName: "p-redis", ProductVersion: "1.14.2"},
+				{ProductName: "p-redis", ProductVersion: "1.13.0"},
+			}))
+		})
+
+		It("only deletes the named products", func() {
+			err := command.Execute([]string{"--product-name", "p-redis", "--keep-latest", "1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted()).To(Equal([]api.DeleteAvailableProductsInput{
+				{ProductName: "p-redis", ProductVersion: "1.13.0"},
+			}))
+		})
+
+		It("lists what would be deleted without deleting it on a dry run", func() {
+			err := command.Execute([]string{"--keep-latest", "2", "--dry-run"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(0))
+			Expect(loggedLines()).To(Equal([]string{
+				"would delete cf 2.0.3",
+			}))
+		})
+
+		It("lists every unused product on a dry run without other options", func() {
+			err := command.Execute([]string{"--dry-run"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(0))
+			Expect(loggedLines()).To(Equal([]string{
+				"would delete cf 2.3.0-build.12",
+				"would delete cf 2.2.10",
+				"would delete cf 2.0.3",
+				"would delete p-redis 1.14.2",
+				"would delete p-redis 1.13.0",
+			}))
+		})
+
+		It("says so when there is nothing to delete", func() {
+			err := command.Execute([]string{"--keep-latest", "5"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(0))
+			Expect(loggedLines()).To(Equal([]string{"no unused products to delete"}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the regex is invalid", func() {
+				err := command.Execute([]string{"--keep-version-regex", "("})
+				Expect(err).To(MatchError(ContainSubstring("invalid --keep-version-regex")))
+			})
+
+			It("returns an error when keep-latest is negative", func() {
+				err := command.Execute([]string{"--keep-latest", "-1"})
+				Expect(err).To(MatchError("--keep-latest must not be negative"))
+			})
+
+			It("returns an error when the available products cannot be listed", func() {
+				fakeService.ListAvailableProductsReturns(api.AvailableProductsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--dry-run"})
+				Expect(err).To(MatchError("failed to list available products: some error"))
+			})
+
+			It("returns an error when the diagnostic report cannot be fetched", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--dry-run"})
+				Expect(err).To(MatchError("failed to retrieve staged and deployed products: some error"))
+			})
+
+			It("returns an error when a product cannot be deleted", func() {
+				fakeService.DeleteAvailableProductsReturns(errors.New("some error"))
+
+				err := command.Execute([]string{"--keep-latest", "1"})
+				Expect(err).To(MatchError("failed to delete cf 2.2.10: some error"))
+			})
+		})
+	})
+
 	Context("when an error occurs", func() {
 		Context("when deleting all products fails", func() {
 			It("returns an error", func() {
 				fakeService.DeleteAvailableProductsReturns(errors.New("something bad happened"))
 
-				err := command.Execute([]string{"-p", "nah", "-v", "nope"})
+				err := command.Execute([]string{})
 				Expect(err).To(MatchError("something bad happened"))
 			})
 		})
+
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse delete-unused-products flags: flag provided but not defined: -badflag"))
+			})
+		})
 	})
 
 	Describe("Usage", func() {
@@ -63,6 +212,7 @@ var _ = Describe("DeleteProduct", func() {
 			Expect(usage).To(Equal(jhanda.Usage{
 				Description:      "This command deletes unused products in the targeted Ops Manager",
 				ShortDescription: "deletes unused products on the Ops Manager targeted",
+				Flags:            command.Options,
 			}))
 		})
 	})
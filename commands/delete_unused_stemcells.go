@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+type DeleteUnusedStemcells struct {
+	service deleteUnusedStemcellsService
+	logger  logger
+	Options struct {
+		DryRun bool `long:"dry-run" description:"list the stemcells that would be deleted without deleting them"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/delete_unused_stemcells_service.go --fake-name DeleteUnusedStemcellsService . deleteUnusedStemcellsService
+type deleteUnusedStemcellsService interface {
+	ListStemcellAssignments() (api.StemcellAssignmentsOutput, error)
+	DeleteStemcell(fileName string) error
+}
+
+func NewDeleteUnusedStemcells(service deleteUnusedStemcellsService, logger logger) DeleteUnusedStemcells {
+	return DeleteUnusedStemcells{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (dus DeleteUnusedStemcells) Execute(args []string) error {
+	if _, err := jhanda.Parse(&dus.Options, args); err != nil {
+		return fmt.Errorf("could not parse delete-unused-stemcells flags: %s", err)
+	}
+
+	assignments, err := dus.service.ListStemcellAssignments()
+	if err != nil {
+		return fmt.Errorf("failed to list stemcell assignments: %s", err)
+	}
+
+	var unused []string
+	for _, stemcell := range assignments.StemcellLibrary {
+		if stemcell.StagedForDeletion || stemcellInUse(stemcell, assignments.Products) {
+			continue
+		}
+
+		unused = append(unused, stemcell.FileName)
+	}
+
+	if len(unused) == 0 {
+		dus.logger.Printf("no unused stemcells to delete")
+		return nil
+	}
+
+	if dus.Options.DryRun {
+		for _, fileName := range unused {
+			dus.logger.Printf("would delete %s", fileName)
+		}
+
+		return nil
+	}
+
+	for _, fileName := range unused {
+		dus.logger.Printf("deleting %s", fileName)
+
+		err := dus.service.DeleteStemcell(fileName)
+		if err != nil {
+			return fmt.Errorf("failed to delete stemcell %s: %s", fileName, err)
+		}
+	}
+
+	dus.logger.Printf("done")
+
+	return nil
+}
+
+func (dus DeleteUnusedStemcells) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command deletes the uploaded stemcells that are not assigned to any staged or deployed product.",
+		ShortDescription: "deletes stemcells that no product uses",
+		Flags:            dus.Options,
+	}
+}
+
+// stemcellInUse reports whether a product has the stemcell staged or
+// deployed. The stemcells of the diagnostic report are not used, as it lists
+// every uploaded stemcell rather than the ones in use.
+func stemcellInUse(stemcell api.StemcellLibrary, products []api.ProductStemcells) bool {
+	for _, product := range products {
+		if product.RequiredStemcellOS != "" && product.RequiredStemcellOS != stemcell.OS {
+			continue
+		}
+
+		if product.StagedStemcellVersion == stemcell.Version || product.DeployedStemcellVersion == stemcell.Version {
+			return true
+		}
+	}
+
+	return false
+}
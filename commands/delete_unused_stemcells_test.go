@@ -0,0 +1,141 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("DeleteUnusedStemcells", func() {
+	var (
+		fakeService *fakes.DeleteUnusedStemcellsService
+		logger      *fakes.Logger
+		command     commands.DeleteUnusedStemcells
+	)
+
+	BeforeEach(func() {
+		fakeService = &fakes.DeleteUnusedStemcellsService{}
+		logger = &fakes.Logger{}
+		command = commands.NewDeleteUnusedStemcells(fakeService, logger)
+
+		fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{
+			Products: []api.ProductStemcells{
+				{
+					ProductName:             "cf",
+					StagedStemcellVersion:   "97.28",
+					DeployedStemcellVersion: "97.18",
+					RequiredStemcellOS:      "ubuntu-xenial",
+				},
+				{
+					ProductName:           "pas-windows",
+					StagedStemcellVersion: "1709.10",
+					RequiredStemcellOS:    "windows1803",
+				},
+			},
+			StemcellLibrary: []api.StemcellLibrary{
+				{OS: "ubuntu-xenial", Version: "97.9", FileName: "light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz"},
+				{OS: "ubuntu-xenial", Version: "97.18", FileName: "light-bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz"},
+				{OS: "ubuntu-xenial", Version: "97.28", FileName: "light-bosh-stemcell-97.28-google-kvm-ubuntu-xenial-go_agent.tgz"},
+				{OS: "ubuntu-trusty", Version: "3586.40", FileName: "light-bosh-stemcell-3586.40-google-kvm-ubuntu-trusty-go_agent.tgz"},
+				{OS: "ubuntu-trusty", Version: "3586.27", FileName: "light-bosh-stemcell-3586.27-google-kvm-ubuntu-trusty-go_agent.tgz"},
+				{OS: "windows2016", Version: "1709.10", FileName: "light-bosh-stemcell-1709.10-google-kvm-windows2016-go_agent.tgz"},
+				{OS: "ubuntu-xenial", Version: "97.3", FileName: "light-bosh-stemcell-97.3-google-kvm-ubuntu-xenial-go_agent.tgz", StagedForDeletion: true},
+			},
+		}, nil)
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	It("deletes the stemcells that no product uses", func() {
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeService.DeleteStemcellCallCount()).To(Equal(4))
+		Expect(fakeService.DeleteStemcellArgsForCall(0)).To(Equal("light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz"))
+		Expect(fakeService.DeleteStemcellArgsForCall(1)).To(Equal("light-bosh-stemcell-3586.40-google-kvm-ubuntu-trusty-go_agent.tgz"))
+		Expect(fakeService.DeleteStemcellArgsForCall(2)).To(Equal("light-bosh-stemcell-3586.27-google-kvm-ubuntu-trusty-go_agent.tgz"))
+		Expect(fakeService.DeleteStemcellArgsForCall(3)).To(Equal("light-bosh-stemcell-1709.10-google-kvm-windows2016-go_agent.tgz"))
+
+		Expect(loggedLines()).To(Equal([]string{
+			"deleting light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz",
+			"deleting light-bosh-stemcell-3586.40-google-kvm-ubuntu-trusty-go_agent.tgz",
+			"deleting light-bosh-stemcell-3586.27-google-kvm-ubuntu-trusty-go_agent.tgz",
+			"deleting light-bosh-stemcell-1709.10-google-kvm-windows2016-go_agent.tgz",
+			"done",
+		}))
+	})
+
+	It("lists what would be deleted without deleting it on a dry run", func() {
+		err := command.Execute([]string{"--dry-run"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeService.DeleteStemcellCallCount()).To(Equal(0))
+		Expect(loggedLines()).To(Equal([]string{
+			"would delete light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz",
+			"would delete light-bosh-stemcell-3586.40-google-kvm-ubuntu-trusty-go_agent.tgz",
+			"would delete light-bosh-stemcell-3586.27-google-kvm-ubuntu-trusty-go_agent.tgz",
+			"would delete light-bosh-stemcell-1709.10-google-kvm-windows2016-go_agent.tgz",
+		}))
+	})
+
+	It("says so when there is nothing to delete", func() {
+		fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{}, nil)
+
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeService.DeleteStemcellCallCount()).To(Equal(0))
+		Expect(loggedLines()).To(Equal([]string{"no unused stemcells to delete"}))
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse delete-unused-stemcells flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the stemcell assignments cannot be listed", func() {
+			It("returns an error", func() {
+				fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list stemcell assignments: some error"))
+			})
+		})
+
+		Context("when a stemcell cannot be deleted", func() {
+			It("returns an error", func() {
+				fakeService.DeleteStemcellReturns(errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to delete stemcell light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command deletes the uploaded stemcells that are not assigned to any staged or deployed product.",
+				ShortDescription: "deletes stemcells that no product uses",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+)
+
+//go:generate counterfeiter -o ./fakes/delete_vm_extension_service.go --fake-name DeleteVMExtensionService . deleteVMExtensionService
+type deleteVMExtensionService interface {
+	DeleteStagedVMExtension(name string) error
+}
+
+type DeleteVMExtension struct {
+	service deleteVMExtensionService
+	logger  logger
+	Options struct {
+		Name string `long:"name" short:"n" required:"true" description:"VM extension name"`
+	}
+}
+
+func NewDeleteVMExtension(service deleteVMExtensionService, logger logger) DeleteVMExtension {
+	return DeleteVMExtension{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (d DeleteVMExtension) Execute(args []string) error {
+	if _, err := jhanda.Parse(&d.Options, args); err != nil {
+		return fmt.Errorf("could not parse delete-vm-extension flags: %s", err)
+	}
+
+	err := d.service.DeleteStagedVMExtension(d.Options.Name)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Printf("VM Extension '%s' deleted\n", d.Options.Name)
+
+	return nil
+}
+
+func (d DeleteVMExtension) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command deletes a staged VM extension",
+		ShortDescription: "deletes a VM extension",
+		Flags:            d.Options,
+	}
+}
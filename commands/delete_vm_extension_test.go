@@ -0,0 +1,75 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("DeleteVMExtension", func() {
+	var (
+		fakeService *fakes.DeleteVMExtensionService
+		fakeLogger  *fakes.Logger
+		command     commands.DeleteVMExtension
+	)
+
+	BeforeEach(func() {
+		fakeService = &fakes.DeleteVMExtensionService{}
+		fakeLogger = &fakes.Logger{}
+		command = commands.NewDeleteVMExtension(fakeService, fakeLogger)
+	})
+
+	Describe("Execute", func() {
+		It("makes a request to the OpsMan to delete a VM extension", func() {
+			err := command.Execute([]string{"--name", "some-vm-extension"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.DeleteStagedVMExtensionCallCount()).To(Equal(1))
+			Expect(fakeService.DeleteStagedVMExtensionArgsForCall(0)).To(Equal("some-vm-extension"))
+
+			Expect(fakeLogger.PrintfCallCount()).To(Equal(1))
+			format, content := fakeLogger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("VM Extension 'some-vm-extension' deleted\n"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the service fails to delete the VM extension", func() {
+				It("returns an error", func() {
+					fakeService.DeleteStagedVMExtensionReturns(errors.New("failed to delete VM extension"))
+
+					err := command.Execute([]string{"--name", "some-vm-extension"})
+					Expect(err).To(MatchError("failed to delete VM extension"))
+				})
+			})
+
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse delete-vm-extension flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the name flag is missing", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("could not parse delete-vm-extension flags: missing required flag \"--name\""))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command deletes a staged VM extension",
+				ShortDescription: "deletes a VM extension",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type DiffFoundations struct {
+	newService func(Environment) (stagedConfigService, error)
+	logger     logger
+	Options    struct {
+		Envs        []string `long:"env"          short:"e" required:"true" description:"path to yml file describing an Ops Manager to compare (must be given twice)"`
+		ProductName string   `long:"product-name" short:"p" required:"true" description:"name of product"`
+		IgnoreFile  string   `long:"ignore"       short:"i"                 description:"path to yml file listing config paths that are expected to differ between foundations"`
+	}
+}
+
+func NewDiffFoundations(newAPI func(Environment) (api.Api, error), logger logger) DiffFoundations {
+	return DiffFoundations{
+		newService: func(env Environment) (stagedConfigService, error) {
+			return newAPI(env)
+		},
+		logger: logger,
+	}
+}
+
+func (df DiffFoundations) Execute(args []string) error {
+	if _, err := jhanda.Parse(&df.Options, args); err != nil {
+		return fmt.Errorf("could not parse diff-foundations flags: %s", err)
+	}
+
+	if len(df.Options.Envs) != 2 {
+		return errors.New("--env must be provided exactly twice")
+	}
+
+	var ignored []string
+	if df.Options.IgnoreFile != "" {
+		contents, err := ioutil.ReadFile(df.Options.IgnoreFile)
+		if err != nil {
+			return err
+		}
+
+		var ignoreFile struct {
+			Ignore []string `yaml:"ignore"`
+		}
+		err = yaml.UnmarshalStrict(contents, &ignoreFile)
+		if err != nil {
+			return fmt.Errorf("%s could not be parsed as a valid ignore file: %s", df.Options.IgnoreFile, err)
+		}
+
+		ignored = ignoreFile.Ignore
+	}
+
+	var configs []string
+	for _, envFile := range df.Options.Envs {
+		env, err := loadEnvironment(envFile)
+		if err != nil {
+			return err
+		}
+
+		service, err := df.newService(env)
+		if err != nil {
+			return fmt.Errorf("could not connect to %s: %s", env.Target, err) // un-tested
+		}
+
+		config, err := fetchStagedConfig(service, df.Options.ProductName, false)
+		if err != nil {
+			return fmt.Errorf("failed to fetch staged config of %s from %s: %s", df.Options.ProductName, env.Target, err)
+		}
+
+		contents, err := yaml.Marshal(config)
+		if err != nil {
+			return err // un-tested
+		}
+
+		configs = append(configs, string(contents))
+	}
+
+	differences, err := diffManifests(configs[0], configs[1])
+	if err != nil {
+		return err // un-tested
+	}
+
+	var reported int
+	for _, difference := range differences {
+		if isIgnoredPath(difference.path, ignored) {
+			continue
+		}
+
+		df.logger.Printf("%s", formatManifestDifference(difference))
+		reported++
+	}
+
+	if reported == 0 {
+		df.logger.Printf("no differences in %s between %s and %s", df.Options.ProductName, df.Options.Envs[0], df.Options.Envs[1])
+	}
+
+	return nil
+}
+
+func (df DiffFoundations) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This command compares the staged config of a product on two Ops Managers and prints every property that differs",
+		ShortDescription: "compares the staged config of a product between two foundations",
+		Flags:            df.Options,
+	}
+}
+
+// isIgnoredPath reports whether the path, or any of its parents, matches one
+// of the patterns.
+func isIgnoredPath(configPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, configPath); matched {
+			return true
+		}
+
+		if strings.HasPrefix(configPath, pattern+".") || strings.HasPrefix(configPath, pattern+"[") {
+			return true
+		}
+	}
+
+	return false
+}
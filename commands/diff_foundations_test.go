@@ -0,0 +1,237 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	apifakes "github.com/pivotal-cf/om/api/fakes"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func fakeOpsManager(responses map[string]string) *apifakes.HttpClient {
+	client := &apifakes.HttpClient{}
+	client.DoStub = func(req *http.Request) (*http.Response, error) {
+		body, ok := responses[req.URL.Path]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+
+	return client
+}
+
+func stagedProductResponses(systemDomain string, routerInstances int, azs string) map[string]string {
+	return map[string]string{
+		"/api/v0/staged/products": `[{"guid": "cf-guid", "type": "cf"}]`,
+		"/api/v0/staged/products/cf-guid/properties": fmt.Sprintf(`{"properties": {
+			".cloud_controller.system_domain": {"value": %q, "configurable": true},
+			".properties.networking_poe_ssl_certs": {"value": [{"name": "cert"}], "configurable": true},
+			".properties.credhub_key": {"value": {"secret": "***"}, "configurable": true, "credential": true},
+			".uaa.service_provider_key_credentials": {"value": "ignored", "configurable": false}
+		}}`, systemDomain),
+		"/api/v0/staged/products/cf-guid/networks_and_azs":                 fmt.Sprintf(`{"networks_and_azs": {"network": {"name": "cf"}, "other_availability_zones": [%s]}}`, azs),
+		"/api/v0/staged/products/cf-guid/jobs":                             `{"jobs": [{"name": "router", "guid": "router-guid"}]}`,
+		"/api/v0/staged/products/cf-guid/jobs/router-guid/resource_config": fmt.Sprintf(`{"instances": %d, "instance_type": {"id": "automatic"}}`, routerInstances),
+		"/api/v0/staged/products/cf-guid/errands":                          `{"errands": []}`,
+	}
+}
+
+var _ = Describe("DiffFoundations", func() {
+	var (
+		logger     *fakes.Logger
+		command    commands.DiffFoundations
+		clients    map[string]*apifakes.HttpClient
+		envs       []commands.Environment
+		tempDir    string
+		sandboxEnv string
+		prodEnv    string
+		ignoreFile string
+	)
+
+	logLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		envs = nil
+		clients = map[string]*apifakes.HttpClient{
+			"https://sandbox.example.com": fakeOpsManager(stagedProductResponses("sys.sandbox.example.com", 1, `{"name": "az1"}`)),
+			"https://prod.example.com":    fakeOpsManager(stagedProductResponses("sys.prod.example.com", 3, `{"name": "az1"}, {"name": "az2"}`)),
+		}
+
+		command = commands.NewDiffFoundations(func(env commands.Environment) (api.Api, error) {
+			envs = append(envs, env)
+			return api.New(api.ApiInput{Client: clients[env.Target]}), nil
+		}, logger)
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		sandboxEnv = filepath.Join(tempDir, "sandbox.yml")
+		err = ioutil.WriteFile(sandboxEnv, []byte("---\ntarget: https://sandbox.example.com\nusername: admin\npassword: some-password\nskip-ssl-validation: true\n"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		prodEnv = filepath.Join(tempDir, "prod.yml")
+		err = ioutil.WriteFile(prodEnv, []byte("---\ntarget: https://prod.example.com\nclient-id: some-client\nclient-secret: some-secret\n"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		ignoreFile = filepath.Join(tempDir, "ignore.yml")
+		err = ioutil.WriteFile(ignoreFile, []byte("---\nignore:\n- product-properties..cloud_controller.system_domain\n- resource-config.*.instances\n"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("Execute", func() {
+		It("prints every difference in the staged config between the two foundations", func() {
+			err := command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(envs).To(Equal([]commands.Environment{
+				{Target: "https://sandbox.example.com", Username: "admin", Password: "some-password", SkipSSLValidation: true},
+				{Target: "https://prod.example.com", ClientID: "some-client", ClientSecret: "some-secret"},
+			}))
+
+			Expect(logLines()).To(Equal([]string{
+				"+ network-properties.other_availability_zones[az2]:\n    name: az2",
+				"~ product-properties..cloud_controller.system_domain.value: sys.sandbox.example.com -> sys.prod.example.com",
+				"~ resource-config.router.instances: 1 -> 3",
+			}))
+		})
+
+		Context("when an ignore file is provided", func() {
+			It("does not report the ignored paths", func() {
+				err := command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf", "--ignore", ignoreFile})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logLines()).To(Equal([]string{
+					"+ network-properties.other_availability_zones[az2]:\n    name: az2",
+				}))
+			})
+		})
+
+		Context("when there are no differences", func() {
+			It("says so", func() {
+				clients["https://prod.example.com"] = fakeOpsManager(stagedProductResponses("sys.sandbox.example.com", 1, `{"name": "az1"}`))
+
+				err := command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logLines()).To(Equal([]string{
+					fmt.Sprintf("no differences in cf between %s and %s", sandboxEnv, prodEnv),
+				}))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse diff-foundations flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when --env is not given twice", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--env", sandboxEnv, "--product-name", "cf"})
+					Expect(err).To(MatchError("--env must be provided exactly twice"))
+				})
+			})
+
+			Context("when an env file does not exist", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--env", sandboxEnv, "--env", "missing.yml", "--product-name", "cf"})
+					Expect(err).To(MatchError("open missing.yml: no such file or directory"))
+				})
+			})
+
+			Context("when an env file has no credentials", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(prodEnv, []byte("---\ntarget: https://prod.example.com\nusername: admin\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf"})
+					Expect(err).To(MatchError(fmt.Sprintf("environment %s: either username and password or client-id and client-secret are required", prodEnv)))
+				})
+			})
+
+			Context("when an env file has no target", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(prodEnv, []byte("---\nusername: admin\npassword: some-password\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf"})
+					Expect(err).To(MatchError(fmt.Sprintf("environment %s: target is required", prodEnv)))
+				})
+			})
+
+			Context("when an env file has unknown keys", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(prodEnv, []byte("---\ntargt: https://prod.example.com\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf"})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as a valid environment")))
+				})
+			})
+
+			Context("when the ignore file cannot be parsed", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(ignoreFile, []byte("---\nignore: {}\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf", "--ignore", ignoreFile})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as a valid ignore file")))
+				})
+			})
+
+			Context("when the staged config cannot be fetched", func() {
+				It("returns an error", func() {
+					clients["https://prod.example.com"].DoStub = nil
+					clients["https://prod.example.com"].DoReturns(nil, errors.New("boom"))
+
+					err := command.Execute([]string{"--env", sandboxEnv, "--env", prodEnv, "--product-name", "cf"})
+					Expect(err).To(MatchError("failed to fetch staged config of cf from https://prod.example.com: could not make request to staged-products endpoint: boom"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This command compares the staged config of a product on two Ops Managers and prints every property that differs",
+				ShortDescription: "compares the staged config of a product between two foundations",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
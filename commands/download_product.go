@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+)
+
+const defaultPivnetURL = "https://network.pivotal.io"
+
+type DownloadProduct struct {
+	client  *http.Client
+	logger  logger
+	Options struct {
+		Source              string `long:"source"                                  default:"pivnet" description:"type of product repository (options: pivnet, index)"`
+		RepositoryURL       string `long:"repository-url"        short:"u"                          description:"url of the Pivotal Network compatible api (default: https://network.pivotal.io), or of the index file when --source is index"`
+		PivnetToken         string `long:"pivnet-api-token"      short:"t"                          description:"api token for Pivotal Network"`
+		ProductSlug         string `long:"product-slug"          short:"p" required:"true"          description:"slug of the product in the repository"`
+		ProductVersionRegex string `long:"product-version-regex" short:"v" required:"true"          description:"regular expression matched against the product versions, the highest matching version is downloaded"`
+		FileGlob            string `long:"file-glob"             short:"f" required:"true"          description:"glob matching the name of the product file to download"`
+		StemcellIaas        string `long:"stemcell-iaas"         short:"s"                          description:"also download the stemcell the product depends on, for this iaas (for example aws, google, azure, vsphere, openstack)"`
+		CacheDir            string `long:"cache-dir"             short:"d" required:"true"          description:"directory in which downloaded files are cached"`
+	}
+}
+
+func NewDownloadProduct(client *http.Client, logger logger) DownloadProduct {
+	return DownloadProduct{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (dp DownloadProduct) Execute(args []string) error {
+	if _, err := jhanda.Parse(&dp.Options, args); err != nil {
+		return fmt.Errorf("could not parse download-product flags: %s", err)
+	}
+
+	repository, err := dp.repository()
+	if err != nil {
+		return err
+	}
+
+	versionRegex, err := regexp.Compile(dp.Options.ProductVersionRegex)
+	if err != nil {
+		return fmt.Errorf("invalid --product-version-regex: %s", err)
+	}
+
+	releases, err := repository.listReleases(dp.Options.ProductSlug)
+	if err != nil {
+		return fmt.Errorf("failed to list releases of %s: %s", dp.Options.ProductSlug, err)
+	}
+
+	var matching []productRelease
+	for _, release := range releases {
+		if versionRegex.MatchString(release.Version) {
+			matching = append(matching, release)
+		}
+	}
+
+	if len(matching) == 0 {
+		return fmt.Errorf("no release of %s matches the version regex %q", dp.Options.ProductSlug, dp.Options.ProductVersionRegex)
+	}
+
+	release := latestRelease(matching)
+
+	productPath, err := dp.fetch(repository, release, dp.Options.FileGlob)
+	if err != nil {
+		return err
+	}
+
+	dp.logger.Printf("product: %s", productPath)
+
+	if dp.Options.StemcellIaas == "" {
+		return nil
+	}
+
+	stemcells, err := repository.stemcellReleases(release)
+	if err != nil {
+		return fmt.Errorf("failed to find the stemcell of %s %s: %s", release.Slug, release.Version, err)
+	}
+
+	if len(stemcells) == 0 {
+		return fmt.Errorf("%s %s does not depend on a stemcell", release.Slug, release.Version)
+	}
+
+	stemcellPath, err := dp.fetch(repository, latestRelease(stemcells), fmt.Sprintf("*-%s-*", dp.Options.StemcellIaas))
+	if err != nil {
+		return err
+	}
+
+	dp.logger.Printf("stemcell: %s", stemcellPath)
+
+	return nil
+}
+
+func (dp DownloadProduct) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This command downloads the latest product file matching a version regex and glob from a product repository, and optionally its stemcell, into a local cache. Files that are already in the cache are not downloaded again.",
+		ShortDescription: "downloads a product and its stemcell from a product repository",
+		Flags:            dp.Options,
+	}
+}
+
+func (dp DownloadProduct) repository() (productRepository, error) {
+	switch dp.Options.Source {
+	case "pivnet":
+		baseURL := dp.Options.RepositoryURL
+		if baseURL == "" {
+			baseURL = defaultPivnetURL
+		}
+
+		return pivnetRepository{client: dp.client, baseURL: baseURL, token: dp.Options.PivnetToken}, nil
+	case "index":
+		if dp.Options.RepositoryURL == "" {
+			return nil, fmt.Errorf("--repository-url is required when --source is index")
+		}
+
+		return &indexRepository{client: dp.client, indexURL: dp.Options.RepositoryURL}, nil
+	default:
+		return nil, fmt.Errorf("--source must be one of pivnet or index, got %q", dp.Options.Source)
+	}
+}
+
+// fetch returns the path of the single file of the release that matches the
+// glob, downloading it into the cache unless it is already there.
+func (dp DownloadProduct) fetch(repository productRepository, release productRelease, glob string) (string, error) {
+	files, err := repository.listFiles(release)
+	if err != nil {
+		return "", fmt.Errorf("failed to list files of %s %s: %s", release.Slug, release.Version, err)
+	}
+
+	var matches []productFile
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name)
+
+		matched, err := path.Match(glob, file.Name)
+		if err != nil {
+			return "", fmt.Errorf("invalid glob %q: %s", glob, err)
+		}
+
+		if matched {
+			matches = append(matches, file)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no file of %s %s matches %q, available files: %s", release.Slug, release.Version, glob, strings.Join(names, ", "))
+	case 1:
+	default:
+		var matchNames []string
+		for _, match := range matches {
+			matchNames = append(matchNames, match.Name)
+		}
+		return "", fmt.Errorf("more than one file of %s %s matches %q: %s", release.Slug, release.Version, glob, strings.Join(matchNames, ", "))
+	}
+
+	file := matches[0]
+	if file.SHA256 == "" {
+		return "", fmt.Errorf("%s of %s %s has no sha256 in the repository, so it cannot be verified", file.Name, release.Slug, release.Version)
+	}
+
+	cachedPath := filepath.Join(dp.Options.CacheDir, strings.ToLower(file.SHA256), file.Name)
+	if _, err := os.Stat(cachedPath); err == nil {
+		dp.logger.Printf("%s %s: %s is already in the cache", release.Slug, release.Version, file.Name)
+		return cachedPath, nil
+	}
+
+	dp.logger.Printf("%s %s: downloading %s", release.Slug, release.Version, file.Name)
+
+	err = os.MkdirAll(filepath.Dir(cachedPath), 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %s", err)
+	}
+
+	download, err := ioutil.TempFile(dp.Options.CacheDir, ".download-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache file: %s", err) // un-tested
+	}
+	defer os.Remove(download.Name())
+
+	hash := sha256.New()
+	err = repository.download(release, file, io.MultiWriter(download, hash))
+	download.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %s", file.Name, err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(sum, file.SHA256) {
+		return "", fmt.Errorf("sha256 of downloaded %s is %s, expected %s", file.Name, sum, file.SHA256)
+	}
+
+	err = os.Rename(download.Name(), cachedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to move %s into the cache: %s", file.Name, err) // un-tested
+	}
+
+	return cachedPath, nil
+}
+
+func latestRelease(releases []productRelease) productRelease {
+	latest := releases[0]
+	for _, release := range releases[1:] {
+		if compareVersions(release.Version, latest.Version) > 0 {
+			latest = release
+		}
+	}
+
+	return latest
+}
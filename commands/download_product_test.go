@@ -0,0 +1,436 @@
+package commands_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/network"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func sha256Of(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("DownloadProduct", func() {
+	var (
+		logger   *fakes.Logger
+		command  commands.DownloadProduct
+		cacheDir string
+		server   *httptest.Server
+		requests []string
+		routes   map[string]string
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		command = commands.NewDownloadProduct(http.DefaultClient, logger)
+
+		var err error
+		cacheDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, req.Header.Get("Authorization")))
+
+			body, ok := routes[req.Method+" "+req.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Write([]byte(body))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(cacheDir)
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	Context("when the source is pivnet", func() {
+		BeforeEach(func() {
+			routes = map[string]string{
+				"GET /api/v2/products/cf/releases": `{"releases": [
+					{"id": 1, "version": "2.2.9"},
+					{"id": 2, "version": "2.2.10"},
+					{"id": 3, "version": "2.3.0"}
+				]}`,
+				"GET /api/v2/products/cf/releases/2/product_files": fmt.Sprintf(`{"product_files": [
+					{"aws_object_key": "product-files/cf/cf-2.2.10.pivotal", "sha256": "%s", "_links": {"download": {"href": "%s/api/v2/products/cf/releases/2/product_files/20/download"}}},
+					{"aws_object_key": "product-files/cf/srt-2.2.10.pivotal", "sha256": "%s", "_links": {"download": {"href": "%s/api/v2/products/cf/releases/2/product_files/21/download"}}}
+				]}`, sha256Of("some-cf-contents"), server.URL, sha256Of("some-srt-contents"), server.URL),
+				"GET /api/v2/products/cf/releases/2/dependencies": `{"dependencies": [
+					{"release": {"id": 7, "version": "97.9", "product": {"slug": "stemcells-ubuntu-xenial"}}},
+					{"release": {"id": 8, "version": "97.18", "product": {"slug": "stemcells-ubuntu-xenial"}}},
+					{"release": {"id": 9, "version": "1.0.0", "product": {"slug": "some-other-dependency"}}}
+				]}`,
+				"GET /api/v2/products/stemcells-ubuntu-xenial/releases/8/product_files": fmt.Sprintf(`{"product_files": [
+					{"aws_object_key": "product-files/stemcells/bosh-stemcell-97.18-aws-xen-hvm-ubuntu-xenial-go_agent.tgz", "sha256": "%s", "_links": {"download": {"href": "%s/api/v2/products/stemcells-ubuntu-xenial/releases/8/product_files/80/download"}}},
+					{"aws_object_key": "product-files/stemcells/bosh-stemcell-97.18-vsphere-esxi-ubuntu-xenial-go_agent.tgz", "sha256": "%s", "_links": {"download": {"href": "%s/api/v2/products/stemcells-ubuntu-xenial/releases/8/product_files/81/download"}}}
+				]}`, sha256Of("some-aws-stemcell"), server.URL, sha256Of("some-vsphere-stemcell"), server.URL),
+				"POST /api/v2/products/cf/releases/2/pivnet_resource_eula_acceptance":                      `{}`,
+				"POST /api/v2/products/cf/releases/2/product_files/20/download":                            "some-cf-contents",
+				"POST /api/v2/products/stemcells-ubuntu-xenial/releases/8/pivnet_resource_eula_acceptance": `{}`,
+				"POST /api/v2/products/stemcells-ubuntu-xenial/releases/8/product_files/80/download":       "some-aws-stemcell",
+			}
+		})
+
+		It("downloads the latest matching product and its stemcell into the cache", func() {
+			err := command.Execute([]string{
+				"--repository-url", server.URL,
+				"--pivnet-api-token", "some-token",
+				"--product-slug", "cf",
+				"--product-version-regex", `^2\.2\..*$`,
+				"--file-glob", "cf-*.pivotal",
+				"--stemcell-iaas", "aws",
+				"--cache-dir", cacheDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			productPath := filepath.Join(cacheDir, sha256Of("some-cf-contents"), "cf-2.2.10.pivotal")
+			contents, err := ioutil.ReadFile(productPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-cf-contents"))
+
+			stemcellPath := filepath.Join(cacheDir, sha256Of("some-aws-stemcell"), "bosh-stemcell-97.18-aws-xen-hvm-ubuntu-xenial-go_agent.tgz")
+			contents, err = ioutil.ReadFile(stemcellPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-aws-stemcell"))
+
+			Expect(requests).To(ContainElement("POST /api/v2/products/cf/releases/2/pivnet_resource_eula_acceptance Token some-token"))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"cf 2.2.10: downloading cf-2.2.10.pivotal",
+				fmt.Sprintf("product: %s", productPath),
+				"stemcells-ubuntu-xenial 97.18: downloading bosh-stemcell-97.18-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+				fmt.Sprintf("stemcell: %s", stemcellPath),
+			}))
+		})
+
+		It("does not download files that are already in the cache", func() {
+			args := []string{
+				"--repository-url", server.URL,
+				"--product-slug", "cf",
+				"--product-version-regex", `^2\.2\..*$`,
+				"--file-glob", "cf-*.pivotal",
+				"--cache-dir", cacheDir,
+			}
+
+			err := command.Execute(args)
+			Expect(err).NotTo(HaveOccurred())
+
+			requests = nil
+			err = command.Execute(args)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, request := range requests {
+				Expect(request).NotTo(ContainSubstring("download"))
+			}
+
+			Expect(loggedLines()).To(ContainElement("cf 2.2.10: cf-2.2.10.pivotal is already in the cache"))
+		})
+
+		Context("when the downloaded file does not match its sha256", func() {
+			It("returns an error and does not cache the file", func() {
+				routes["POST /api/v2/products/cf/releases/2/product_files/20/download"] = "some-corrupted-contents"
+
+				err := command.Execute([]string{
+					"--repository-url", server.URL,
+					"--product-slug", "cf",
+					"--product-version-regex", `^2\.2\..*$`,
+					"--file-glob", "cf-*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(fmt.Sprintf("sha256 of downloaded cf-2.2.10.pivotal is %s, expected %s", sha256Of("some-corrupted-contents"), sha256Of("some-cf-contents"))))
+
+				entries, err := ioutil.ReadDir(cacheDir)
+				Expect(err).NotTo(HaveOccurred())
+				for _, entry := range entries {
+					files, err := ioutil.ReadDir(filepath.Join(cacheDir, entry.Name()))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(BeEmpty())
+				}
+			})
+		})
+
+		Context("when no release matches the version regex", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--repository-url", server.URL,
+					"--product-slug", "cf",
+					"--product-version-regex", `^3\..*$`,
+					"--file-glob", "cf-*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(`no release of cf matches the version regex "^3\\..*$"`))
+			})
+		})
+
+		Context("when no file matches the glob", func() {
+			It("returns an error listing the available files", func() {
+				err := command.Execute([]string{
+					"--repository-url", server.URL,
+					"--product-slug", "cf",
+					"--product-version-regex", `^2\.2\..*$`,
+					"--file-glob", "*.tgz",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(`no file of cf 2.2.10 matches "*.tgz", available files: cf-2.2.10.pivotal, srt-2.2.10.pivotal`))
+			})
+		})
+
+		Context("when more than one file matches the glob", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--repository-url", server.URL,
+					"--product-slug", "cf",
+					"--product-version-regex", `^2\.2\..*$`,
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(`more than one file of cf 2.2.10 matches "*.pivotal": cf-2.2.10.pivotal, srt-2.2.10.pivotal`))
+			})
+		})
+
+		Context("when the repository returns an error", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--repository-url", server.URL,
+					"--product-slug", "some-unknown-product",
+					"--product-version-regex", ".*",
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring("failed to list releases of some-unknown-product: request to %s/api/v2/products/some-unknown-product/releases failed: 404 Not Found", server.URL)))
+			})
+		})
+	})
+
+	Context("when the source is an index", func() {
+		BeforeEach(func() {
+			routes = map[string]string{
+				"GET /repo/index.yml": fmt.Sprintf(`---
+products:
+- slug: p-redis
+  version: 1.14.2
+  stemcell:
+    slug: stemcells-ubuntu-xenial
+    version: "97.18"
+  files:
+  - name: p-redis-1.14.2.pivotal
+    url: p-redis/p-redis-1.14.2.pivotal
+    sha256: %s
+- slug: p-redis
+  version: 1.13.0
+  files: []
+- slug: stemcells-ubuntu-xenial
+  version: "97.18"
+  files:
+  - url: %s/stemcells/bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz
+    sha256: %s
+`, sha256Of("some-redis-contents"), server.URL, sha256Of("some-google-stemcell")),
+				"GET /repo/p-redis/p-redis-1.14.2.pivotal":                                 "some-redis-contents",
+				"GET /stemcells/bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz": "some-google-stemcell",
+			}
+		})
+
+		It("downloads the product and stemcell from the urls in the index", func() {
+			err := command.Execute([]string{
+				"--source", "index",
+				"--repository-url", server.URL + "/repo/index.yml",
+				"--product-slug", "p-redis",
+				"--product-version-regex", `^1\.14\..*$`,
+				"--file-glob", "*.pivotal",
+				"--stemcell-iaas", "google",
+				"--cache-dir", cacheDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(cacheDir, sha256Of("some-redis-contents"), "p-redis-1.14.2.pivotal"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-redis-contents"))
+
+			contents, err = ioutil.ReadFile(filepath.Join(cacheDir, sha256Of("some-google-stemcell"), "bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-google-stemcell"))
+		})
+
+		Context("when the product does not depend on a stemcell", func() {
+			It("returns an error", func() {
+				routes["GET /repo/index.yml"] = fmt.Sprintf(`---
+products:
+- slug: p-redis
+  version: 1.14.2
+  files:
+  - url: p-redis-1.14.2.pivotal
+    sha256: %s
+`, sha256Of("some-redis-contents"))
+				routes["GET /repo/p-redis-1.14.2.pivotal"] = "some-redis-contents"
+
+				err := command.Execute([]string{
+					"--source", "index",
+					"--repository-url", server.URL + "/repo/index.yml",
+					"--product-slug", "p-redis",
+					"--product-version-regex", ".*",
+					"--file-glob", "*.pivotal",
+					"--stemcell-iaas", "google",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError("p-redis 1.14.2 does not depend on a stemcell"))
+			})
+		})
+
+		Context("when a file name contains a path", func() {
+			It("returns an error without writing outside the cache", func() {
+				routes["GET /repo/index.yml"] = fmt.Sprintf(`---
+products:
+- slug: p-redis
+  version: 1.14.2
+  files:
+  - name: ../../p-redis-1.14.2.pivotal
+    url: p-redis-1.14.2.pivotal
+    sha256: %s
+`, sha256Of("some-redis-contents"))
+				routes["GET /repo/p-redis-1.14.2.pivotal"] = "some-redis-contents"
+
+				err := command.Execute([]string{
+					"--source", "index",
+					"--repository-url", server.URL + "/repo/index.yml",
+					"--product-slug", "p-redis",
+					"--product-version-regex", ".*",
+					"--file-glob", "*",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(fmt.Sprintf(`failed to list files of p-redis 1.14.2: invalid file name "../../p-redis-1.14.2.pivotal" in %s/repo/index.yml: it must not contain a path`, server.URL)))
+
+				_, err = os.Stat(filepath.Join(filepath.Dir(cacheDir), "p-redis-1.14.2.pivotal"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+
+		Context("when a file has no sha256", func() {
+			It("returns an error", func() {
+				routes["GET /repo/index.yml"] = `---
+products:
+- slug: p-redis
+  version: 1.14.2
+  files:
+  - url: p-redis-1.14.2.pivotal
+`
+
+				err := command.Execute([]string{
+					"--source", "index",
+					"--repository-url", server.URL + "/repo/index.yml",
+					"--product-slug", "p-redis",
+					"--product-version-regex", ".*",
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError("p-redis-1.14.2.pivotal of p-redis 1.14.2 has no sha256 in the repository, so it cannot be verified"))
+			})
+		})
+
+		Context("when the repository url is not provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--source", "index",
+					"--product-slug", "p-redis",
+					"--product-version-regex", ".*",
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError("--repository-url is required when --source is index"))
+			})
+		})
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse download-product flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the source is not supported", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--source", "ftp",
+					"--product-slug", "cf",
+					"--product-version-regex", ".*",
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(`--source must be one of pivnet or index, got "ftp"`))
+			})
+		})
+
+		Context("when the version regex is invalid", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--repository-url", server.URL,
+					"--product-slug", "cf",
+					"--product-version-regex", "(",
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring("invalid --product-version-regex")))
+			})
+		})
+
+		Context("when the repository certificate is not trusted", func() {
+			It("returns an error unless the client skips ssl validation", func() {
+				tlsServer := httptest.NewTLSServer(server.Config.Handler)
+				defer tlsServer.Close()
+
+				args := []string{
+					"--repository-url", tlsServer.URL,
+					"--product-slug", "some-unknown-product",
+					"--product-version-regex", ".*",
+					"--file-glob", "*.pivotal",
+					"--cache-dir", cacheDir,
+				}
+
+				err := commands.NewDownloadProduct(network.NewRemoteFileClient(false, time.Minute), logger).Execute(args)
+				Expect(err).To(MatchError(ContainSubstring("certificate")))
+
+				err = commands.NewDownloadProduct(network.NewRemoteFileClient(true, time.Minute), logger).Execute(args)
+				Expect(err).To(MatchError(ContainSubstring("failed to list releases of some-unknown-product: request to %s/api/v2/products/some-unknown-product/releases failed: 404 Not Found", tlsServer.URL)))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This command downloads the latest product file matching a version regex and glob from a product repository, and optionally its stemcell, into a local cache. Files that are already in the cache are not downloaded again.",
+				ShortDescription: "downloads a product and its stemcell from a product repository",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Environment describes how to reach and authenticate against an Ops Manager,
+// for commands that talk to more than the one given by the global flags.
+type Environment struct {
+	Target            string `yaml:"target"`
+	Username          string `yaml:"username"`
+	Password          string `yaml:"password"`
+	ClientID          string `yaml:"client-id"`
+	ClientSecret      string `yaml:"client-secret"`
+	SkipSSLValidation bool   `yaml:"skip-ssl-validation"`
+}
+
+func loadEnvironment(path string) (Environment, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Environment{}, err
+	}
+
+	var env Environment
+	err = yaml.UnmarshalStrict(contents, &env)
+	if err != nil {
+		return Environment{}, fmt.Errorf("%s could not be parsed as a valid environment: %s", path, err)
+	}
+
+	if env.Target == "" {
+		return Environment{}, fmt.Errorf("environment %s: target is required", path)
+	}
+
+	if (env.Username == "" || env.Password == "") && (env.ClientID == "" || env.ClientSecret == "") {
+		return Environment{}, fmt.Errorf("environment %s: either username and password or client-id and client-secret are required", path)
+	}
+
+	return env, nil
+}
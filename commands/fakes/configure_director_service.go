@@ -2,9 +2,9 @@
 package fakes
 
 import (
-	"encoding/json"
 	"sync"
 
+	"encoding/json"
 	"github.com/pivotal-cf/om/api"
 )
 
@@ -119,6 +119,39 @@ type ConfigureDirectorService struct {
 		result1 string
 		result2 error
 	}
+	ListVMTypesStub        func() ([]api.VMType, error)
+	listVMTypesMutex       sync.RWMutex
+	listVMTypesArgsForCall []struct{}
+	listVMTypesReturns     struct {
+		result1 []api.VMType
+		result2 error
+	}
+	listVMTypesReturnsOnCall map[int]struct {
+		result1 []api.VMType
+		result2 error
+	}
+	ListDiskTypesStub        func() ([]api.DiskType, error)
+	listDiskTypesMutex       sync.RWMutex
+	listDiskTypesArgsForCall []struct{}
+	listDiskTypesReturns     struct {
+		result1 []api.DiskType
+		result2 error
+	}
+	listDiskTypesReturnsOnCall map[int]struct {
+		result1 []api.DiskType
+		result2 error
+	}
+	ListStagedVMExtensionsStub        func() ([]api.VMExtension, error)
+	listStagedVMExtensionsMutex       sync.RWMutex
+	listStagedVMExtensionsArgsForCall []struct{}
+	listStagedVMExtensionsReturns     struct {
+		result1 []api.VMExtension
+		result2 error
+	}
+	listStagedVMExtensionsReturnsOnCall map[int]struct {
+		result1 []api.VMExtension
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -570,6 +603,135 @@ func (fake *ConfigureDirectorService) GetStagedProductManifestReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *ConfigureDirectorService) ListVMTypes() ([]api.VMType, error) {
+	fake.listVMTypesMutex.Lock()
+	ret, specificReturn := fake.listVMTypesReturnsOnCall[len(fake.listVMTypesArgsForCall)]
+	fake.listVMTypesArgsForCall = append(fake.listVMTypesArgsForCall, struct{}{})
+	fake.recordInvocation("ListVMTypes", []interface{}{})
+	fake.listVMTypesMutex.Unlock()
+	if fake.ListVMTypesStub != nil {
+		return fake.ListVMTypesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listVMTypesReturns.result1, fake.listVMTypesReturns.result2
+}
+
+func (fake *ConfigureDirectorService) ListVMTypesCallCount() int {
+	fake.listVMTypesMutex.RLock()
+	defer fake.listVMTypesMutex.RUnlock()
+	return len(fake.listVMTypesArgsForCall)
+}
+
+func (fake *ConfigureDirectorService) ListVMTypesReturns(result1 []api.VMType, result2 error) {
+	fake.ListVMTypesStub = nil
+	fake.listVMTypesReturns = struct {
+		result1 []api.VMType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureDirectorService) ListVMTypesReturnsOnCall(i int, result1 []api.VMType, result2 error) {
+	fake.ListVMTypesStub = nil
+	if fake.listVMTypesReturnsOnCall == nil {
+		fake.listVMTypesReturnsOnCall = make(map[int]struct {
+			result1 []api.VMType
+			result2 error
+		})
+	}
+	fake.listVMTypesReturnsOnCall[i] = struct {
+		result1 []api.VMType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureDirectorService) ListDiskTypes() ([]api.DiskType, error) {
+	fake.listDiskTypesMutex.Lock()
+	ret, specificReturn := fake.listDiskTypesReturnsOnCall[len(fake.listDiskTypesArgsForCall)]
+	fake.listDiskTypesArgsForCall = append(fake.listDiskTypesArgsForCall, struct{}{})
+	fake.recordInvocation("ListDiskTypes", []interface{}{})
+	fake.listDiskTypesMutex.Unlock()
+	if fake.ListDiskTypesStub != nil {
+		return fake.ListDiskTypesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listDiskTypesReturns.result1, fake.listDiskTypesReturns.result2
+}
+
+func (fake *ConfigureDirectorService) ListDiskTypesCallCount() int {
+	fake.listDiskTypesMutex.RLock()
+	defer fake.listDiskTypesMutex.RUnlock()
+	return len(fake.listDiskTypesArgsForCall)
+}
+
+func (fake *ConfigureDirectorService) ListDiskTypesReturns(result1 []api.DiskType, result2 error) {
+	fake.ListDiskTypesStub = nil
+	fake.listDiskTypesReturns = struct {
+		result1 []api.DiskType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureDirectorService) ListDiskTypesReturnsOnCall(i int, result1 []api.DiskType, result2 error) {
+	fake.ListDiskTypesStub = nil
+	if fake.listDiskTypesReturnsOnCall == nil {
+		fake.listDiskTypesReturnsOnCall = make(map[int]struct {
+			result1 []api.DiskType
+			result2 error
+		})
+	}
+	fake.listDiskTypesReturnsOnCall[i] = struct {
+		result1 []api.DiskType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureDirectorService) ListStagedVMExtensions() ([]api.VMExtension, error) {
+	fake.listStagedVMExtensionsMutex.Lock()
+	ret, specificReturn := fake.listStagedVMExtensionsReturnsOnCall[len(fake.listStagedVMExtensionsArgsForCall)]
+	fake.listStagedVMExtensionsArgsForCall = append(fake.listStagedVMExtensionsArgsForCall, struct{}{})
+	fake.recordInvocation("ListStagedVMExtensions", []interface{}{})
+	fake.listStagedVMExtensionsMutex.Unlock()
+	if fake.ListStagedVMExtensionsStub != nil {
+		return fake.ListStagedVMExtensionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listStagedVMExtensionsReturns.result1, fake.listStagedVMExtensionsReturns.result2
+}
+
+func (fake *ConfigureDirectorService) ListStagedVMExtensionsCallCount() int {
+	fake.listStagedVMExtensionsMutex.RLock()
+	defer fake.listStagedVMExtensionsMutex.RUnlock()
+	return len(fake.listStagedVMExtensionsArgsForCall)
+}
+
+func (fake *ConfigureDirectorService) ListStagedVMExtensionsReturns(result1 []api.VMExtension, result2 error) {
+	fake.ListStagedVMExtensionsStub = nil
+	fake.listStagedVMExtensionsReturns = struct {
+		result1 []api.VMExtension
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureDirectorService) ListStagedVMExtensionsReturnsOnCall(i int, result1 []api.VMExtension, result2 error) {
+	fake.ListStagedVMExtensionsStub = nil
+	if fake.listStagedVMExtensionsReturnsOnCall == nil {
+		fake.listStagedVMExtensionsReturnsOnCall = make(map[int]struct {
+			result1 []api.VMExtension
+			result2 error
+		})
+	}
+	fake.listStagedVMExtensionsReturnsOnCall[i] = struct {
+		result1 []api.VMExtension
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ConfigureDirectorService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -591,6 +753,12 @@ func (fake *ConfigureDirectorService) Invocations() map[string][][]interface{} {
 	defer fake.getStagedProductByNameMutex.RUnlock()
 	fake.getStagedProductManifestMutex.RLock()
 	defer fake.getStagedProductManifestMutex.RUnlock()
+	fake.listVMTypesMutex.RLock()
+	defer fake.listVMTypesMutex.RUnlock()
+	fake.listDiskTypesMutex.RLock()
+	defer fake.listDiskTypesMutex.RUnlock()
+	fake.listStagedVMExtensionsMutex.RLock()
+	defer fake.listStagedVMExtensionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -81,6 +81,88 @@ type ConfigureProductService struct {
 	updateStagedProductJobResourceConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ListStagedProductErrandsStub        func(productID string) (api.ErrandsListOutput, error)
+	listStagedProductErrandsMutex       sync.RWMutex
+	listStagedProductErrandsArgsForCall []struct {
+		productID string
+	}
+	listStagedProductErrandsReturns struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}
+	listStagedProductErrandsReturnsOnCall map[int]struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}
+	UpdateStagedProductErrandsStub        func(productID, errandName string, postDeployState, preDeleteState interface{}) error
+	updateStagedProductErrandsMutex       sync.RWMutex
+	updateStagedProductErrandsArgsForCall []struct {
+		productID       string
+		errandName      string
+		postDeployState interface{}
+		preDeleteState  interface{}
+	}
+	updateStagedProductErrandsReturns struct {
+		result1 error
+	}
+	updateStagedProductErrandsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateStagedProductSyslogConfigurationStub        func(api.UpdateStagedProductSyslogConfigurationInput) error
+	updateStagedProductSyslogConfigurationMutex       sync.RWMutex
+	updateStagedProductSyslogConfigurationArgsForCall []struct {
+		arg1 api.UpdateStagedProductSyslogConfigurationInput
+	}
+	updateStagedProductSyslogConfigurationReturns struct {
+		result1 error
+	}
+	updateStagedProductSyslogConfigurationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateStagedProductMaxInFlightStub        func(api.UpdateStagedProductMaxInFlightInput) error
+	updateStagedProductMaxInFlightMutex       sync.RWMutex
+	updateStagedProductMaxInFlightArgsForCall []struct {
+		arg1 api.UpdateStagedProductMaxInFlightInput
+	}
+	updateStagedProductMaxInFlightReturns struct {
+		result1 error
+	}
+	updateStagedProductMaxInFlightReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListVMTypesStub        func() ([]api.VMType, error)
+	listVMTypesMutex       sync.RWMutex
+	listVMTypesArgsForCall []struct{}
+	listVMTypesReturns     struct {
+		result1 []api.VMType
+		result2 error
+	}
+	listVMTypesReturnsOnCall map[int]struct {
+		result1 []api.VMType
+		result2 error
+	}
+	ListDiskTypesStub        func() ([]api.DiskType, error)
+	listDiskTypesMutex       sync.RWMutex
+	listDiskTypesArgsForCall []struct{}
+	listDiskTypesReturns     struct {
+		result1 []api.DiskType
+		result2 error
+	}
+	listDiskTypesReturnsOnCall map[int]struct {
+		result1 []api.DiskType
+		result2 error
+	}
+	ListStagedVMExtensionsStub        func() ([]api.VMExtension, error)
+	listStagedVMExtensionsMutex       sync.RWMutex
+	listStagedVMExtensionsArgsForCall []struct{}
+	listStagedVMExtensionsReturns     struct {
+		result1 []api.VMExtension
+		result2 error
+	}
+	listStagedVMExtensionsReturnsOnCall map[int]struct {
+		result1 []api.VMExtension
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -377,6 +459,333 @@ func (fake *ConfigureProductService) UpdateStagedProductJobResourceConfigReturns
 	}{result1}
 }
 
+func (fake *ConfigureProductService) ListStagedProductErrands(productID string) (api.ErrandsListOutput, error) {
+	fake.listStagedProductErrandsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductErrandsReturnsOnCall[len(fake.listStagedProductErrandsArgsForCall)]
+	fake.listStagedProductErrandsArgsForCall = append(fake.listStagedProductErrandsArgsForCall, struct {
+		productID string
+	}{productID})
+	fake.recordInvocation("ListStagedProductErrands", []interface{}{productID})
+	fake.listStagedProductErrandsMutex.Unlock()
+	if fake.ListStagedProductErrandsStub != nil {
+		return fake.ListStagedProductErrandsStub(productID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listStagedProductErrandsReturns.result1, fake.listStagedProductErrandsReturns.result2
+}
+
+func (fake *ConfigureProductService) ListStagedProductErrandsCallCount() int {
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	return len(fake.listStagedProductErrandsArgsForCall)
+}
+
+func (fake *ConfigureProductService) ListStagedProductErrandsArgsForCall(i int) string {
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	return fake.listStagedProductErrandsArgsForCall[i].productID
+}
+
+func (fake *ConfigureProductService) ListStagedProductErrandsReturns(result1 api.ErrandsListOutput, result2 error) {
+	fake.ListStagedProductErrandsStub = nil
+	fake.listStagedProductErrandsReturns = struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) ListStagedProductErrandsReturnsOnCall(i int, result1 api.ErrandsListOutput, result2 error) {
+	fake.ListStagedProductErrandsStub = nil
+	if fake.listStagedProductErrandsReturnsOnCall == nil {
+		fake.listStagedProductErrandsReturnsOnCall = make(map[int]struct {
+			result1 api.ErrandsListOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductErrandsReturnsOnCall[i] = struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductErrands(productID string, errandName string, postDeployState interface{}, preDeleteState interface{}) error {
+	fake.updateStagedProductErrandsMutex.Lock()
+	ret, specificReturn := fake.updateStagedProductErrandsReturnsOnCall[len(fake.updateStagedProductErrandsArgsForCall)]
+	fake.updateStagedProductErrandsArgsForCall = append(fake.updateStagedProductErrandsArgsForCall, struct {
+		productID       string
+		errandName      string
+		postDeployState interface{}
+		preDeleteState  interface{}
+	}{productID, errandName, postDeployState, preDeleteState})
+	fake.recordInvocation("UpdateStagedProductErrands", []interface{}{productID, errandName, postDeployState, preDeleteState})
+	fake.updateStagedProductErrandsMutex.Unlock()
+	if fake.UpdateStagedProductErrandsStub != nil {
+		return fake.UpdateStagedProductErrandsStub(productID, errandName, postDeployState, preDeleteState)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateStagedProductErrandsReturns.result1
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductErrandsCallCount() int {
+	fake.updateStagedProductErrandsMutex.RLock()
+	defer fake.updateStagedProductErrandsMutex.RUnlock()
+	return len(fake.updateStagedProductErrandsArgsForCall)
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductErrandsArgsForCall(i int) (string, string, interface{}, interface{}) {
+	fake.updateStagedProductErrandsMutex.RLock()
+	defer fake.updateStagedProductErrandsMutex.RUnlock()
+	return fake.updateStagedProductErrandsArgsForCall[i].productID, fake.updateStagedProductErrandsArgsForCall[i].errandName, fake.updateStagedProductErrandsArgsForCall[i].postDeployState, fake.updateStagedProductErrandsArgsForCall[i].preDeleteState
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductErrandsReturns(result1 error) {
+	fake.UpdateStagedProductErrandsStub = nil
+	fake.updateStagedProductErrandsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductErrandsReturnsOnCall(i int, result1 error) {
+	fake.UpdateStagedProductErrandsStub = nil
+	if fake.updateStagedProductErrandsReturnsOnCall == nil {
+		fake.updateStagedProductErrandsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateStagedProductErrandsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductSyslogConfiguration(arg1 api.UpdateStagedProductSyslogConfigurationInput) error {
+	fake.updateStagedProductSyslogConfigurationMutex.Lock()
+	ret, specificReturn := fake.updateStagedProductSyslogConfigurationReturnsOnCall[len(fake.updateStagedProductSyslogConfigurationArgsForCall)]
+	fake.updateStagedProductSyslogConfigurationArgsForCall = append(fake.updateStagedProductSyslogConfigurationArgsForCall, struct {
+		arg1 api.UpdateStagedProductSyslogConfigurationInput
+	}{arg1})
+	fake.recordInvocation("UpdateStagedProductSyslogConfiguration", []interface{}{arg1})
+	fake.updateStagedProductSyslogConfigurationMutex.Unlock()
+	if fake.UpdateStagedProductSyslogConfigurationStub != nil {
+		return fake.UpdateStagedProductSyslogConfigurationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateStagedProductSyslogConfigurationReturns.result1
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductSyslogConfigurationCallCount() int {
+	fake.updateStagedProductSyslogConfigurationMutex.RLock()
+	defer fake.updateStagedProductSyslogConfigurationMutex.RUnlock()
+	return len(fake.updateStagedProductSyslogConfigurationArgsForCall)
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductSyslogConfigurationArgsForCall(i int) api.UpdateStagedProductSyslogConfigurationInput {
+	fake.updateStagedProductSyslogConfigurationMutex.RLock()
+	defer fake.updateStagedProductSyslogConfigurationMutex.RUnlock()
+	return fake.updateStagedProductSyslogConfigurationArgsForCall[i].arg1
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductSyslogConfigurationReturns(result1 error) {
+	fake.UpdateStagedProductSyslogConfigurationStub = nil
+	fake.updateStagedProductSyslogConfigurationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductSyslogConfigurationReturnsOnCall(i int, result1 error) {
+	fake.UpdateStagedProductSyslogConfigurationStub = nil
+	if fake.updateStagedProductSyslogConfigurationReturnsOnCall == nil {
+		fake.updateStagedProductSyslogConfigurationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateStagedProductSyslogConfigurationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductMaxInFlight(arg1 api.UpdateStagedProductMaxInFlightInput) error {
+	fake.updateStagedProductMaxInFlightMutex.Lock()
+	ret, specificReturn := fake.updateStagedProductMaxInFlightReturnsOnCall[len(fake.updateStagedProductMaxInFlightArgsForCall)]
+	fake.updateStagedProductMaxInFlightArgsForCall = append(fake.updateStagedProductMaxInFlightArgsForCall, struct {
+		arg1 api.UpdateStagedProductMaxInFlightInput
+	}{arg1})
+	fake.recordInvocation("UpdateStagedProductMaxInFlight", []interface{}{arg1})
+	fake.updateStagedProductMaxInFlightMutex.Unlock()
+	if fake.UpdateStagedProductMaxInFlightStub != nil {
+		return fake.UpdateStagedProductMaxInFlightStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateStagedProductMaxInFlightReturns.result1
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductMaxInFlightCallCount() int {
+	fake.updateStagedProductMaxInFlightMutex.RLock()
+	defer fake.updateStagedProductMaxInFlightMutex.RUnlock()
+	return len(fake.updateStagedProductMaxInFlightArgsForCall)
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductMaxInFlightArgsForCall(i int) api.UpdateStagedProductMaxInFlightInput {
+	fake.updateStagedProductMaxInFlightMutex.RLock()
+	defer fake.updateStagedProductMaxInFlightMutex.RUnlock()
+	return fake.updateStagedProductMaxInFlightArgsForCall[i].arg1
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductMaxInFlightReturns(result1 error) {
+	fake.UpdateStagedProductMaxInFlightStub = nil
+	fake.updateStagedProductMaxInFlightReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureProductService) UpdateStagedProductMaxInFlightReturnsOnCall(i int, result1 error) {
+	fake.UpdateStagedProductMaxInFlightStub = nil
+	if fake.updateStagedProductMaxInFlightReturnsOnCall == nil {
+		fake.updateStagedProductMaxInFlightReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateStagedProductMaxInFlightReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureProductService) ListVMTypes() ([]api.VMType, error) {
+	fake.listVMTypesMutex.Lock()
+	ret, specificReturn := fake.listVMTypesReturnsOnCall[len(fake.listVMTypesArgsForCall)]
+	fake.listVMTypesArgsForCall = append(fake.listVMTypesArgsForCall, struct{}{})
+	fake.recordInvocation("ListVMTypes", []interface{}{})
+	fake.listVMTypesMutex.Unlock()
+	if fake.ListVMTypesStub != nil {
+		return fake.ListVMTypesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listVMTypesReturns.result1, fake.listVMTypesReturns.result2
+}
+
+func (fake *ConfigureProductService) ListVMTypesCallCount() int {
+	fake.listVMTypesMutex.RLock()
+	defer fake.listVMTypesMutex.RUnlock()
+	return len(fake.listVMTypesArgsForCall)
+}
+
+func (fake *ConfigureProductService) ListVMTypesReturns(result1 []api.VMType, result2 error) {
+	fake.ListVMTypesStub = nil
+	fake.listVMTypesReturns = struct {
+		result1 []api.VMType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) ListVMTypesReturnsOnCall(i int, result1 []api.VMType, result2 error) {
+	fake.ListVMTypesStub = nil
+	if fake.listVMTypesReturnsOnCall == nil {
+		fake.listVMTypesReturnsOnCall = make(map[int]struct {
+			result1 []api.VMType
+			result2 error
+		})
+	}
+	fake.listVMTypesReturnsOnCall[i] = struct {
+		result1 []api.VMType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) ListDiskTypes() ([]api.DiskType, error) {
+	fake.listDiskTypesMutex.Lock()
+	ret, specificReturn := fake.listDiskTypesReturnsOnCall[len(fake.listDiskTypesArgsForCall)]
+	fake.listDiskTypesArgsForCall = append(fake.listDiskTypesArgsForCall, struct{}{})
+	fake.recordInvocation("ListDiskTypes", []interface{}{})
+	fake.listDiskTypesMutex.Unlock()
+	if fake.ListDiskTypesStub != nil {
+		return fake.ListDiskTypesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listDiskTypesReturns.result1, fake.listDiskTypesReturns.result2
+}
+
+func (fake *ConfigureProductService) ListDiskTypesCallCount() int {
+	fake.listDiskTypesMutex.RLock()
+	defer fake.listDiskTypesMutex.RUnlock()
+	return len(fake.listDiskTypesArgsForCall)
+}
+
+func (fake *ConfigureProductService) ListDiskTypesReturns(result1 []api.DiskType, result2 error) {
+	fake.ListDiskTypesStub = nil
+	fake.listDiskTypesReturns = struct {
+		result1 []api.DiskType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) ListDiskTypesReturnsOnCall(i int, result1 []api.DiskType, result2 error) {
+	fake.ListDiskTypesStub = nil
+	if fake.listDiskTypesReturnsOnCall == nil {
+		fake.listDiskTypesReturnsOnCall = make(map[int]struct {
+			result1 []api.DiskType
+			result2 error
+		})
+	}
+	fake.listDiskTypesReturnsOnCall[i] = struct {
+		result1 []api.DiskType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) ListStagedVMExtensions() ([]api.VMExtension, error) {
+	fake.listStagedVMExtensionsMutex.Lock()
+	ret, specificReturn := fake.listStagedVMExtensionsReturnsOnCall[len(fake.listStagedVMExtensionsArgsForCall)]
+	fake.listStagedVMExtensionsArgsForCall = append(fake.listStagedVMExtensionsArgsForCall, struct{}{})
+	fake.recordInvocation("ListStagedVMExtensions", []interface{}{})
+	fake.listStagedVMExtensionsMutex.Unlock()
+	if fake.ListStagedVMExtensionsStub != nil {
+		return fake.ListStagedVMExtensionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listStagedVMExtensionsReturns.result1, fake.listStagedVMExtensionsReturns.result2
+}
+
+func (fake *ConfigureProductService) ListStagedVMExtensionsCallCount() int {
+	fake.listStagedVMExtensionsMutex.RLock()
+	defer fake.listStagedVMExtensionsMutex.RUnlock()
+	return len(fake.listStagedVMExtensionsArgsForCall)
+}
+
+func (fake *ConfigureProductService) ListStagedVMExtensionsReturns(result1 []api.VMExtension, result2 error) {
+	fake.ListStagedVMExtensionsStub = nil
+	fake.listStagedVMExtensionsReturns = struct {
+		result1 []api.VMExtension
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureProductService) ListStagedVMExtensionsReturnsOnCall(i int, result1 []api.VMExtension, result2 error) {
+	fake.ListStagedVMExtensionsStub = nil
+	if fake.listStagedVMExtensionsReturnsOnCall == nil {
+		fake.listStagedVMExtensionsReturnsOnCall = make(map[int]struct {
+			result1 []api.VMExtension
+			result2 error
+		})
+	}
+	fake.listStagedVMExtensionsReturnsOnCall[i] = struct {
+		result1 []api.VMExtension
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ConfigureProductService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -392,6 +801,20 @@ func (fake *ConfigureProductService) Invocations() map[string][][]interface{} {
 	defer fake.updateStagedProductNetworksAndAZsMutex.RUnlock()
 	fake.updateStagedProductJobResourceConfigMutex.RLock()
 	defer fake.updateStagedProductJobResourceConfigMutex.RUnlock()
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	fake.updateStagedProductErrandsMutex.RLock()
+	defer fake.updateStagedProductErrandsMutex.RUnlock()
+	fake.updateStagedProductSyslogConfigurationMutex.RLock()
+	defer fake.updateStagedProductSyslogConfigurationMutex.RUnlock()
+	fake.updateStagedProductMaxInFlightMutex.RLock()
+	defer fake.updateStagedProductMaxInFlightMutex.RUnlock()
+	fake.listVMTypesMutex.RLock()
+	defer fake.listVMTypesMutex.RUnlock()
+	fake.listDiskTypesMutex.RLock()
+	defer fake.listDiskTypesMutex.RUnlock()
+	fake.listStagedVMExtensionsMutex.RLock()
+	defer fake.listStagedVMExtensionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
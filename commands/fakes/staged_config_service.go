@@ -98,6 +98,45 @@ type StagedConfigService struct {
 		result1 map[string]string
 		result2 error
 	}
+	ListStagedProductErrandsStub        func(productID string) (api.ErrandsListOutput, error)
+	listStagedProductErrandsMutex       sync.RWMutex
+	listStagedProductErrandsArgsForCall []struct {
+		productID string
+	}
+	listStagedProductErrandsReturns struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}
+	listStagedProductErrandsReturnsOnCall map[int]struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}
+	GetStagedProductSyslogConfigurationStub        func(product string) (map[string]interface{}, error)
+	getStagedProductSyslogConfigurationMutex       sync.RWMutex
+	getStagedProductSyslogConfigurationArgsForCall []struct {
+		product string
+	}
+	getStagedProductSyslogConfigurationReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	getStagedProductSyslogConfigurationReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	GetStagedProductMaxInFlightStub        func(product string) (map[string]interface{}, error)
+	getStagedProductMaxInFlightMutex       sync.RWMutex
+	getStagedProductMaxInFlightArgsForCall []struct {
+		product string
+	}
+	getStagedProductMaxInFlightReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	getStagedProductMaxInFlightReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -452,6 +491,159 @@ func (fake *StagedConfigService) ListStagedProductJobsReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *StagedConfigService) ListStagedProductErrands(productID string) (api.ErrandsListOutput, error) {
+	fake.listStagedProductErrandsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductErrandsReturnsOnCall[len(fake.listStagedProductErrandsArgsForCall)]
+	fake.listStagedProductErrandsArgsForCall = append(fake.listStagedProductErrandsArgsForCall, struct {
+		productID string
+	}{productID})
+	fake.recordInvocation("ListStagedProductErrands", []interface{}{productID})
+	fake.listStagedProductErrandsMutex.Unlock()
+	if fake.ListStagedProductErrandsStub != nil {
+		return fake.ListStagedProductErrandsStub(productID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listStagedProductErrandsReturns.result1, fake.listStagedProductErrandsReturns.result2
+}
+
+func (fake *StagedConfigService) ListStagedProductErrandsCallCount() int {
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	return len(fake.listStagedProductErrandsArgsForCall)
+}
+
+func (fake *StagedConfigService) ListStagedProductErrandsArgsForCall(i int) string {
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	return fake.listStagedProductErrandsArgsForCall[i].productID
+}
+
+func (fake *StagedConfigService) ListStagedProductErrandsReturns(result1 api.ErrandsListOutput, result2 error) {
+	fake.ListStagedProductErrandsStub = nil
+	fake.listStagedProductErrandsReturns = struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StagedConfigService) ListStagedProductErrandsReturnsOnCall(i int, result1 api.ErrandsListOutput, result2 error) {
+	fake.ListStagedProductErrandsStub = nil
+	if fake.listStagedProductErrandsReturnsOnCall == nil {
+		fake.listStagedProductErrandsReturnsOnCall = make(map[int]struct {
+			result1 api.ErrandsListOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductErrandsReturnsOnCall[i] = struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StagedConfigService) GetStagedProductSyslogConfiguration(product string) (map[string]interface{}, error) {
+	fake.getStagedProductSyslogConfigurationMutex.Lock()
+	ret, specificReturn := fake.getStagedProductSyslogConfigurationReturnsOnCall[len(fake.getStagedProductSyslogConfigurationArgsForCall)]
+	fake.getStagedProductSyslogConfigurationArgsForCall = append(fake.getStagedProductSyslogConfigurationArgsForCall, struct {
+		product string
+	}{product})
+	fake.recordInvocation("GetStagedProductSyslogConfiguration", []interface{}{product})
+	fake.getStagedProductSyslogConfigurationMutex.Unlock()
+	if fake.GetStagedProductSyslogConfigurationStub != nil {
+		return fake.GetStagedProductSyslogConfigurationStub(product)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStagedProductSyslogConfigurationReturns.result1, fake.getStagedProductSyslogConfigurationReturns.result2
+}
+
+func (fake *StagedConfigService) GetStagedProductSyslogConfigurationCallCount() int {
+	fake.getStagedProductSyslogConfigurationMutex.RLock()
+	defer fake.getStagedProductSyslogConfigurationMutex.RUnlock()
+	return len(fake.getStagedProductSyslogConfigurationArgsForCall)
+}
+
+func (fake *StagedConfigService) GetStagedProductSyslogConfigurationArgsForCall(i int) string {
+	fake.getStagedProductSyslogConfigurationMutex.RLock()
+	defer fake.getStagedProductSyslogConfigurationMutex.RUnlock()
+	return fake.getStagedProductSyslogConfigurationArgsForCall[i].product
+}
+
+func (fake *StagedConfigService) GetStagedProductSyslogConfigurationReturns(result1 map[string]interface{}, result2 error) {
+	fake.GetStagedProductSyslogConfigurationStub = nil
+	fake.getStagedProductSyslogConfigurationReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StagedConfigService) GetStagedProductSyslogConfigurationReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.GetStagedProductSyslogConfigurationStub = nil
+	if fake.getStagedProductSyslogConfigurationReturnsOnCall == nil {
+		fake.getStagedProductSyslogConfigurationReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.getStagedProductSyslogConfigurationReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StagedConfigService) GetStagedProductMaxInFlight(product string) (map[string]interface{}, error) {
+	fake.getStagedProductMaxInFlightMutex.Lock()
+	ret, specificReturn := fake.getStagedProductMaxInFlightReturnsOnCall[len(fake.getStagedProductMaxInFlightArgsForCall)]
+	fake.getStagedProductMaxInFlightArgsForCall = append(fake.getStagedProductMaxInFlightArgsForCall, struct {
+		product string
+	}{product})
+	fake.recordInvocation("GetStagedProductMaxInFlight", []interface{}{product})
+	fake.getStagedProductMaxInFlightMutex.Unlock()
+	if fake.GetStagedProductMaxInFlightStub != nil {
+		return fake.GetStagedProductMaxInFlightStub(product)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStagedProductMaxInFlightReturns.result1, fake.getStagedProductMaxInFlightReturns.result2
+}
+
+func (fake *StagedConfigService) GetStagedProductMaxInFlightCallCount() int {
+	fake.getStagedProductMaxInFlightMutex.RLock()
+	defer fake.getStagedProductMaxInFlightMutex.RUnlock()
+	return len(fake.getStagedProductMaxInFlightArgsForCall)
+}
+
+func (fake *StagedConfigService) GetStagedProductMaxInFlightArgsForCall(i int) string {
+	fake.getStagedProductMaxInFlightMutex.RLock()
+	defer fake.getStagedProductMaxInFlightMutex.RUnlock()
+	return fake.getStagedProductMaxInFlightArgsForCall[i].product
+}
+
+func (fake *StagedConfigService) GetStagedProductMaxInFlightReturns(result1 map[string]interface{}, result2 error) {
+	fake.GetStagedProductMaxInFlightStub = nil
+	fake.getStagedProductMaxInFlightReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StagedConfigService) GetStagedProductMaxInFlightReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.GetStagedProductMaxInFlightStub = nil
+	if fake.getStagedProductMaxInFlightReturnsOnCall == nil {
+		fake.getStagedProductMaxInFlightReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.getStagedProductMaxInFlightReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *StagedConfigService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -469,6 +661,12 @@ func (fake *StagedConfigService) Invocations() map[string][][]interface{} {
 	defer fake.listDeployedProductsMutex.RUnlock()
 	fake.listStagedProductJobsMutex.RLock()
 	defer fake.listStagedProductJobsMutex.RUnlock()
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	fake.getStagedProductSyslogConfigurationMutex.RLock()
+	defer fake.getStagedProductSyslogConfigurationMutex.RUnlock()
+	fake.getStagedProductMaxInFlightMutex.RLock()
+	defer fake.getStagedProductMaxInFlightMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,262 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const redacted = "<redacted>"
+
+// sensitiveKeys are matched against map keys that are lower-cased and
+// stripped of "_" and "-"; any value below a matching key, or under a key
+// ending in "key", is never printed.
+var sensitiveKeys = []string{"password", "secret", "privatekey", "token", "credential", "passphrase"}
+
+type manifestDifference struct {
+	change string
+	path   string
+	from   interface{}
+	to     interface{}
+}
+
+// diffManifests returns the structural differences between two yaml documents.
+// Lists whose elements all have a name are aligned by name rather than by index,
+// so that reordering instance groups or jobs is not reported as a change.
+func diffManifests(from, to string) ([]manifestDifference, error) {
+	var fromContents, toContents interface{}
+
+	err := yaml.Unmarshal([]byte(from), &fromContents)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse manifest: %s", err)
+	}
+
+	err = yaml.Unmarshal([]byte(to), &toContents)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse manifest: %s", err)
+	}
+
+	if fromContents == nil {
+		fromContents = map[interface{}]interface{}{}
+	}
+
+	if toContents == nil {
+		toContents = map[interface{}]interface{}{}
+	}
+
+	return diffValues("", fromContents, toContents, false), nil
+}
+
+func diffValues(path string, from, to interface{}, sensitive bool) []manifestDifference {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		return []manifestDifference{{change: "+", path: path, to: redact(to, sensitive)}}
+	case to == nil:
+		return []manifestDifference{{change: "-", path: path, from: redact(from, sensitive)}}
+	}
+
+	fromMap, fromIsMap := from.(map[interface{}]interface{})
+	toMap, toIsMap := to.(map[interface{}]interface{})
+	if fromIsMap && toIsMap {
+		return diffMaps(path, fromMap, toMap, sensitive)
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if fromIsList && toIsList {
+		fromNamed, fromOK := namedElements(fromList)
+		toNamed, toOK := namedElements(toList)
+		if fromOK && toOK {
+			return diffNamedLists(path, fromList, toList, fromNamed, toNamed, sensitive)
+		}
+
+		var differences []manifestDifference
+		for i := 0; i < len(fromList) || i < len(toList); i++ {
+			var fromElement, toElement interface{}
+			if i < len(fromList) {
+				fromElement = fromList[i]
+			}
+			if i < len(toList) {
+				toElement = toList[i]
+			}
+			differences = append(differences, diffValues(fmt.Sprintf("%s[%d]", path, i), fromElement, toElement, sensitive)...)
+		}
+
+		return differences
+	}
+
+	if fmt.Sprintf("%#v", from) == fmt.Sprintf("%#v", to) {
+		return nil
+	}
+
+	return []manifestDifference{{change: "~", path: path, from: redact(from, sensitive), to: redact(to, sensitive)}}
+}
+
+func diffMaps(path string, from, to map[interface{}]interface{}, sensitive bool) []manifestDifference {
+	keys := map[string]interface{}{}
+	for key := range from {
+		keys[fmt.Sprint(key)] = key
+	}
+	for key := range to {
+		keys[fmt.Sprint(key)] = key
+	}
+
+	var names []string
+	for name := range keys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var differences []manifestDifference
+	for _, name := range names {
+		key := keys[name]
+
+		childPath := name
+		if path != "" {
+			childPath = path + "." + name
+		}
+
+		differences = append(differences, diffValues(childPath, from[key], to[key], sensitive || isSensitiveKey(name))...)
+	}
+
+	return differences
+}
+
+func diffNamedLists(path string, fromList, toList []interface{}, fromNamed, toNamed map[string]interface{}, sensitive bool) []manifestDifference {
+	var names []string
+	for _, element := range toList {
+		names = append(names, elementName(element))
+	}
+	for _, element := range fromList {
+		name := elementName(element)
+		if _, ok := toNamed[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	var differences []manifestDifference
+	for _, name := range names {
+		differences = append(differences, diffValues(fmt.Sprintf("%s[%s]", path, name), fromNamed[name], toNamed[name], sensitive)...)
+	}
+
+	return differences
+}
+
+// namedElements indexes a list by the name of each element, and reports
+// whether every element is a map with a unique name.
+func namedElements(list []interface{}) (map[string]interface{}, bool) {
+	if len(list) == 0 {
+		return map[string]interface{}{}, true
+	}
+
+	named := map[string]interface{}{}
+	for _, element := range list {
+		name := elementName(element)
+		if name == "" {
+			return nil, false
+		}
+
+		if _, ok := named[name]; ok {
+			return nil, false
+		}
+
+		named[name] = element
+	}
+
+	return named, true
+}
+
+func elementName(element interface{}) string {
+	elementMap, ok := element.(map[interface{}]interface{})
+	if !ok {
+		return ""
+	}
+
+	name, ok := elementMap["name"].(string)
+	if !ok {
+		return ""
+	}
+
+	return name
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	if strings.HasSuffix(key, "key") {
+		return true
+	}
+
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redact replaces sensitive values, values under sensitive keys and PEM
+// encoded strings, such as certificates and private keys, with a placeholder.
+func redact(value interface{}, sensitive bool) interface{} {
+	if sensitive {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "-----BEGIN") {
+			return redacted
+		}
+	case map[interface{}]interface{}:
+		redactedMap := map[interface{}]interface{}{}
+		for key, element := range v {
+			redactedMap[key] = redact(element, isSensitiveKey(fmt.Sprint(key)))
+		}
+		return redactedMap
+	case []interface{}:
+		redactedList := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			redactedList = append(redactedList, redact(element, false))
+		}
+		return redactedList
+	}
+
+	return value
+}
+
+// formatManifestDifference renders a difference as one line, followed by the
+// indented yaml of any added or removed maps and lists.
+func formatManifestDifference(difference manifestDifference) string {
+	switch difference.change {
+	case "~":
+		return fmt.Sprintf("~ %s: %s -> %s", difference.path, formatManifestValue(difference.from, ""), formatManifestValue(difference.to, ""))
+	case "+":
+		return fmt.Sprintf("+ %s:%s", difference.path, formatManifestValue(difference.to, " "))
+	default:
+		return fmt.Sprintf("- %s:%s", difference.path, formatManifestValue(difference.from, " "))
+	}
+}
+
+func formatManifestValue(value interface{}, scalarPrefix string) string {
+	switch value.(type) {
+	case map[interface{}]interface{}, []interface{}:
+		contents, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%s%v", scalarPrefix, value) // un-tested
+		}
+
+		var lines []string
+		for _, line := range strings.Split(strings.TrimRight(string(contents), "\n"), "\n") {
+			lines = append(lines, "    "+line)
+		}
+
+		return "\n" + strings.Join(lines, "\n")
+	default:
+		return fmt.Sprintf("%s%v", scalarPrefix, value)
+	}
+}
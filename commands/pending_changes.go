@@ -2,20 +2,27 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
 	"github.com/pivotal-cf/om/presenters"
 )
 
 type PendingChanges struct {
 	service   pendingChangesService
 	presenter presenters.Presenter
+	Options   struct {
+		Check  bool `long:"check"  description:"exit with an error if any product has pending changes"`
+		Detail bool `long:"detail" description:"show the version and stemcell changes and the errands that will run for each changed product"`
+	}
 }
 
 //go:generate counterfeiter -o ./fakes/pending_changes_service.go --fake-name PendingChangesService . pendingChangesService
 type pendingChangesService interface {
 	ListStagedPendingChanges() (api.PendingChangesOutput, error)
+	ListStagedProductErrands(productID string) (api.ErrandsListOutput, error)
 }
 
 func NewPendingChanges(presenter presenters.Presenter, service pendingChangesService) PendingChanges {
@@ -26,12 +33,39 @@ func NewPendingChanges(presenter presenters.Presenter, service pendingChangesSer
 }
 
 func (pc PendingChanges) Execute(args []string) error {
+	if _, err := jhanda.Parse(&pc.Options, args); err != nil {
+		return fmt.Errorf("could not parse pending-changes flags: %s", err)
+	}
+
 	output, err := pc.service.ListStagedPendingChanges()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve pending changes %s", err)
 	}
 
-	pc.presenter.PresentPendingChanges(output.ChangeList)
+	if pc.Options.Detail {
+		details, err := pc.pendingChangesDetail(output.ChangeList)
+		if err != nil {
+			return err
+		}
+
+		pc.presenter.PresentPendingChangesDetail(details)
+	} else {
+		pc.presenter.PresentPendingChanges(output.ChangeList)
+	}
+
+	if pc.Options.Check {
+		var changed []string
+		for _, change := range output.ChangeList {
+			if change.Action != "unchanged" {
+				changed = append(changed, change.Product)
+			}
+		}
+
+		if len(changed) > 0 {
+			return fmt.Errorf("there are pending changes for: %s", strings.Join(changed, ", "))
+		}
+	}
+
 	return nil
 }
 
@@ -39,5 +73,86 @@ func (pc PendingChanges) Usage() jhanda.Usage {
 	return jhanda.Usage{
 		Description:      "This authenticated command lists all pending changes.",
 		ShortDescription: "lists pending changes",
+		Flags:            pc.Options,
+	}
+}
+
+func (pc PendingChanges) pendingChangesDetail(changes []api.ProductChange) ([]models.PendingChangeDetail, error) {
+	details := []models.PendingChangeDetail{}
+
+	for _, change := range changes {
+		if change.Action == "unchanged" {
+			continue
+		}
+
+		detail := models.PendingChangeDetail{
+			Product: change.Product,
+			Action:  change.Action,
+			Errands: []string{},
+		}
+
+		if change.Deployed != nil {
+			detail.DeployedVersion = change.Deployed.ProductVersion
+			detail.DeployedStemcell = formatChangeStemcells(change.Deployed.Stemcells)
+		}
+
+		if change.Staged != nil {
+			detail.StagedVersion = change.Staged.ProductVersion
+			detail.StagedStemcell = formatChangeStemcells(change.Staged.Stemcells)
+		}
+
+		// A product being deleted is no longer staged, so its pre-delete
+		// errands are only known from the pending change itself.
+		if change.Action == "delete" {
+			for _, errand := range change.Errands {
+				if errandWillRun(errand.PreDelete) {
+					detail.Errands = append(detail.Errands, errand.Name)
+				}
+			}
+		} else if !isDirectorGUID(change.Product) {
+			// The director has no errands endpoint, so it never has errands to run.
+			errands, err := pc.service.ListStagedProductErrands(change.Product)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list errands for %s: %s", change.Product, err)
+			}
+
+			for _, errand := range errands.Errands {
+				if errandWillRun(errand.PostDeploy) {
+					detail.Errands = append(detail.Errands, errand.Name)
+				}
+			}
+		}
+
+		details = append(details, detail)
 	}
+
+	return details, nil
+}
+
+// isDirectorGUID reports whether a product GUID is the director's.
+func isDirectorGUID(guid string) bool {
+	return guid == "p-bosh" || strings.HasPrefix(guid, "p-bosh-")
+}
+
+// errandWillRun reports whether an errand state from the api runs the errand
+// when its product has changes. The "default" state leaves the choice to the
+// product, whose errands run unless they are disabled.
+func errandWillRun(state interface{}) bool {
+	switch s := state.(type) {
+	case bool:
+		return s
+	case string:
+		return s == "true" || s == "when-changed" || s == "default"
+	}
+
+	return false
+}
+
+func formatChangeStemcells(stemcells []api.ProductChangeStemcell) string {
+	var formatted []string
+	for _, stemcell := range stemcells {
+		formatted = append(formatted, fmt.Sprintf("%s/%s", stemcell.StemcellName, stemcell.StemcellVersion))
+	}
+
+	return strings.Join(formatted, ", ")
 }
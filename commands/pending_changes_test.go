@@ -9,6 +9,7 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/models"
 	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
 )
 
@@ -56,9 +57,151 @@ var _ = Describe("PendingChanges", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(presenter.PresentPendingChangesCallCount()).To(Equal(1))
+		Expect(pcService.ListStagedProductErrandsCallCount()).To(Equal(0))
+	})
+
+	Context("when --check is provided", func() {
+		It("returns an error naming the products with pending changes", func() {
+			pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{Product: "some-product", Action: "update"},
+					{Product: "some-unchanged-product", Action: "unchanged"},
+					{Product: "some-new-product", Action: "install"},
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--check"})
+			Expect(err).To(MatchError("there are pending changes for: some-product, some-new-product"))
+
+			Expect(presenter.PresentPendingChangesCallCount()).To(Equal(1))
+		})
+
+		It("succeeds when every product is unchanged", func() {
+			pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{Product: "some-product", Action: "unchanged"},
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--check"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when --detail is provided", func() {
+		BeforeEach(func() {
+			pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{
+						Product: "some-product",
+						Action:  "update",
+						Staged: &api.ProductChangeState{
+							ProductVersion: "1.1.0",
+							Stemcells:      []api.ProductChangeStemcell{{StemcellName: "ubuntu-xenial", StemcellVersion: "97.19"}},
+						},
+						Deployed: &api.ProductChangeState{
+							ProductVersion: "1.0.0",
+							Stemcells:      []api.ProductChangeStemcell{{StemcellName: "ubuntu-xenial", StemcellVersion: "97.18"}},
+						},
+					},
+					{Product: "some-unchanged-product", Action: "unchanged"},
+					{
+						Product: "some-deleted-product",
+						Action:  "delete",
+						Errands: []api.Errand{
+							{Name: "some-pre-delete-errand", PreDelete: true},
+							{Name: "some-disabled-errand", PreDelete: false},
+						},
+						Deployed: &api.ProductChangeState{ProductVersion: "3.0.0"},
+					},
+				},
+			}, nil)
+
+			pcService.ListStagedProductErrandsReturns(api.ErrandsListOutput{
+				Errands: []api.Errand{
+					{Name: "some-enabled-errand", PostDeploy: true},
+					{Name: "some-when-changed-errand", PostDeploy: "when-changed"},
+					{Name: "some-default-errand", PostDeploy: "default"},
+					{Name: "some-disabled-errand", PostDeploy: false},
+				},
+			}, nil)
+		})
+
+		It("presents the version, stemcell and errands of each changed product", func() {
+			err := command.Execute([]string{"--detail"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pcService.ListStagedProductErrandsCallCount()).To(Equal(1))
+			Expect(pcService.ListStagedProductErrandsArgsForCall(0)).To(Equal("some-product"))
+
+			Expect(presenter.PresentPendingChangesCallCount()).To(Equal(0))
+			Expect(presenter.PresentPendingChangesDetailCallCount()).To(Equal(1))
+			Expect(presenter.PresentPendingChangesDetailArgsForCall(0)).To(Equal([]models.PendingChangeDetail{
+				{
+					Product:          "some-product",
+					Action:           "update",
+					DeployedVersion:  "1.0.0",
+					StagedVersion:    "1.1.0",
+					DeployedStemcell: "ubuntu-xenial/97.18",
+					StagedStemcell:   "ubuntu-xenial/97.19",
+					Errands:          []string{"some-enabled-errand", "some-when-changed-errand", "some-default-errand"},
+				},
+				{
+					Product:         "some-deleted-product",
+					Action:          "delete",
+					DeployedVersion: "3.0.0",
+					Errands:         []string{"some-pre-delete-errand"},
+				},
+			}))
+		})
+
+		Context("when the director has pending changes", func() {
+			It("does not list errands for the director", func() {
+				pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+					ChangeList: []api.ProductChange{
+						{
+							Product:  "p-bosh-some-guid",
+							Action:   "update",
+							Staged:   &api.ProductChangeState{ProductVersion: "2.1-build.200"},
+							Deployed: &api.ProductChangeState{ProductVersion: "2.1-build.100"},
+						},
+					},
+				}, nil)
+
+				err := command.Execute([]string{"--detail"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pcService.ListStagedProductErrandsCallCount()).To(Equal(0))
+				Expect(presenter.PresentPendingChangesDetailArgsForCall(0)).To(Equal([]models.PendingChangeDetail{
+					{
+						Product:         "p-bosh-some-guid",
+						Action:          "update",
+						DeployedVersion: "2.1-build.100",
+						StagedVersion:   "2.1-build.200",
+						Errands:         []string{},
+					},
+				}))
+			})
+		})
+
+		Context("when listing the errands fails", func() {
+			It("returns an error", func() {
+				pcService.ListStagedProductErrandsReturns(api.ErrandsListOutput{}, errors.New("boom"))
+
+				err := command.Execute([]string{"--detail"})
+				Expect(err).To(MatchError("failed to list errands for some-product: boom"))
+			})
+		})
 	})
 
 	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse pending-changes flags: flag provided but not defined: -badflag"))
+			})
+		})
+
 		Context("when fetching the pending changes fails", func() {
 			It("returns an error", func() {
 				command := commands.NewPendingChanges(presenter, pcService)
@@ -77,6 +220,7 @@ var _ = Describe("PendingChanges", func() {
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This authenticated command lists all pending changes.",
 				ShortDescription: "lists pending changes",
+				Flags:            command.Options,
 			}))
 		})
 	})
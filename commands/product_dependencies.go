@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/extractor"
+)
+
+// installedProductVersions returns the name and version of every staged and
+// deployed product in the diagnostic report.
+func installedProductVersions(report api.DiagnosticReport) []extractor.ProductVersion {
+	var products []extractor.ProductVersion
+	for _, product := range append(report.StagedProducts, report.DeployedProducts...) {
+		products = append(products, extractor.ProductVersion{Name: product.Name, Version: product.Version})
+	}
+
+	return products
+}
+
+// checkProductDependencies returns an error listing each product required by
+// the metadata that is not among the given products at a matching version.
+func checkProductDependencies(metadata extractor.Metadata, products []extractor.ProductVersion) error {
+	missing, err := unmetProductDependencies(metadata, products)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%s %s requires products that are not staged or deployed:\n- %s", metadata.Name, metadata.Version, strings.Join(missing, "\n- "))
+	}
+
+	return nil
+}
+
+// unmetProductDependencies describes each requirement that none of the
+// products meet, along with the versions of the product that were found.
+func unmetProductDependencies(metadata extractor.Metadata, products []extractor.ProductVersion) ([]string, error) {
+	var missing []string
+	for _, required := range metadata.RequiresProductVersions {
+		var found []string
+		satisfied := false
+
+		for _, product := range products {
+			if product.Name != required.Name {
+				continue
+			}
+
+			matched, err := matchesVersionConstraint(required.Version, product.Version)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s requires %s: %s", metadata.Name, metadata.Version, required.Name, err)
+			}
+
+			if matched {
+				satisfied = true
+				break
+			}
+
+			if !contains(found, product.Version) {
+				found = append(found, product.Version)
+			}
+		}
+
+		if satisfied {
+			continue
+		}
+
+		if len(found) == 0 {
+			missing = append(missing, fmt.Sprintf("%s %s (not found)", required.Name, required.Version))
+		} else {
+			missing = append(missing, fmt.Sprintf("%s %s (found %s)", required.Name, required.Version, strings.Join(found, ", ")))
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/om/network"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// productRepository is a source of product and stemcell releases that can be
+// downloaded by download-product.
+type productRepository interface {
+	listReleases(slug string) ([]productRelease, error)
+	listFiles(release productRelease) ([]productFile, error)
+	stemcellReleases(release productRelease) ([]productRelease, error)
+	download(release productRelease, file productFile, w io.Writer) error
+}
+
+type productRelease struct {
+	Slug    string
+	Version string
+	ID      int
+}
+
+type productFile struct {
+	Name   string
+	SHA256 string
+	URL    string
+}
+
+// pivnetRepository talks to the Pivotal Network API, or to anything that
+// implements the same endpoints.
+type pivnetRepository struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+type pivnetRelease struct {
+	ID      int    `json:"id"`
+	Version string `json:"version"`
+	Product struct {
+		Slug string `json:"slug"`
+	} `json:"product"`
+}
+
+func (r pivnetRepository) listReleases(slug string) ([]productRelease, error) {
+	var response struct {
+		Releases []pivnetRelease `json:"releases"`
+	}
+
+	err := r.get(fmt.Sprintf("/api/v2/products/%s/releases", slug), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []productRelease
+	for _, release := range response.Releases {
+		releases = append(releases, productRelease{Slug: slug, Version: release.Version, ID: release.ID})
+	}
+
+	return releases, nil
+}
+
+func (r pivnetRepository) listFiles(release productRelease) ([]productFile, error) {
+	var response struct {
+		ProductFiles []struct {
+			AWSObjectKey string `json:"aws_object_key"`
+			SHA256       string `json:"sha256"`
+			Links        struct {
+				Download struct {
+					Href string `json:"href"`
+				} `json:"download"`
+			} `json:"_links"`
+		} `json:"product_files"`
+	}
+
+	err := r.get(fmt.Sprintf("/api/v2/products/%s/releases/%d/product_files", release.Slug, release.ID), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []productFile
+	for _, file := range response.ProductFiles {
+		files = append(files, productFile{
+			Name:   path.Base(file.AWSObjectKey),
+			SHA256: file.SHA256,
+			URL:    file.Links.Download.Href,
+		})
+	}
+
+	return files, nil
+}
+
+func (r pivnetRepository) stemcellReleases(release productRelease) ([]productRelease, error) {
+	var response struct {
+		Dependencies []struct {
+			Release pivnetRelease `json:"release"`
+		} `json:"dependencies"`
+	}
+
+	err := r.get(fmt.Sprintf("/api/v2/products/%s/releases/%d/dependencies", release.Slug, release.ID), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []productRelease
+	for _, dependency := range response.Dependencies {
+		if strings.HasPrefix(dependency.Release.Product.Slug, "stemcells") {
+			releases = append(releases, productRelease{
+				Slug:    dependency.Release.Product.Slug,
+				Version: dependency.Release.Version,
+				ID:      dependency.Release.ID,
+			})
+		}
+	}
+
+	return releases, nil
+}
+
+// download accepts the EULA of the release before downloading the file, as
+// Pivotal Network refuses downloads until it has been accepted.
+func (r pivnetRepository) download(release productRelease, file productFile, w io.Writer) error {
+	resp, err := r.do("POST", fmt.Sprintf("/api/v2/products/%s/releases/%d/pivnet_resource_eula_acceptance", release.Slug, release.ID))
+	if err != nil {
+		return fmt.Errorf("could not accept the EULA of %s %s: %s", release.Slug, release.Version, err)
+	}
+	resp.Body.Close()
+
+	resp, err = r.do("POST", file.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (r pivnetRepository) get(endpoint string, v interface{}) error {
+	resp, err := r.do("GET", endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("could not parse response from %s: %s", endpoint, err)
+	}
+
+	return nil
+}
+
+func (r pivnetRepository) do(method, endpoint string) (*http.Response, error) {
+	requestURL := endpoint
+	if !network.IsURL(endpoint) {
+		requestURL = strings.TrimSuffix(r.baseURL, "/") + endpoint
+	}
+
+	req, err := http.NewRequest(method, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Token "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not request %s: %s", requestURL, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("request to %s failed: %s %s", requestURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// indexRepository reads releases from a yaml index file served over plain
+// http(s), such as from an S3 bucket. File urls are relative to the index.
+type indexRepository struct {
+	client   *http.Client
+	indexURL string
+	index    *productIndex
+}
+
+type productIndex struct {
+	Products []productIndexRelease `yaml:"products"`
+}
+
+type productIndexRelease struct {
+	Slug     string `yaml:"slug"`
+	Version  string `yaml:"version"`
+	Stemcell *struct {
+		Slug    string `yaml:"slug"`
+		Version string `yaml:"version"`
+	} `yaml:"stemcell"`
+	Files []struct {
+		Name   string `yaml:"name"`
+		URL    string `yaml:"url"`
+		SHA256 string `yaml:"sha256"`
+	} `yaml:"files"`
+}
+
+func (r *indexRepository) listReleases(slug string) ([]productRelease, error) {
+	index, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []productRelease
+	for _, release := range index.Products {
+		if release.Slug == slug {
+			releases = append(releases, productRelease{Slug: slug, Version: release.Version})
+		}
+	}
+
+	return releases, nil
+}
+
+func (r *indexRepository) listFiles(release productRelease) ([]productFile, error) {
+	entry, err := r.find(release)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(r.indexURL)
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	var files []productFile
+	for _, file := range entry.Files {
+		fileURL, err := base.Parse(file.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url %q for %s in %s: %s", file.URL, file.Name, r.indexURL, err)
+		}
+
+		name := file.Name
+		if name == "" {
+			name = path.Base(fileURL.Path)
+		}
+
+		if name != path.Base(name) || name != filepath.Base(name) || strings.Contains(name, "..") {
+			return nil, fmt.Errorf("invalid file name %q in %s: it must not contain a path", name, r.indexURL)
+		}
+
+		files = append(files, productFile{Name: name, SHA256: file.SHA256, URL: fileURL.String()})
+	}
+
+	return files, nil
+}
+
+func (r *indexRepository) stemcellReleases(release productRelease) ([]productRelease, error) {
+	entry, err := r.find(release)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Stemcell == nil {
+		return nil, nil
+	}
+
+	return []productRelease{{Slug: entry.Stemcell.Slug, Version: entry.Stemcell.Version}}, nil
+}
+
+func (r *indexRepository) download(release productRelease, file productFile, w io.Writer) error {
+	resp, err := r.client.Get(file.URL)
+	if err != nil {
+		return fmt.Errorf("could not request %s: %s", file.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed: %s", file.URL, resp.Status)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (r *indexRepository) find(release productRelease) (productIndexRelease, error) {
+	index, err := r.load()
+	if err != nil {
+		return productIndexRelease{}, err
+	}
+
+	for _, entry := range index.Products {
+		if entry.Slug == release.Slug && entry.Version == release.Version {
+			return entry, nil
+		}
+	}
+
+	return productIndexRelease{}, fmt.Errorf("%s %s is not in %s", release.Slug, release.Version, r.indexURL)
+}
+
+func (r *indexRepository) load() (productIndex, error) {
+	if r.index != nil {
+		return *r.index, nil
+	}
+
+	resp, err := r.client.Get(r.indexURL)
+	if err != nil {
+		return productIndex{}, fmt.Errorf("could not request %s: %s", r.indexURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return productIndex{}, fmt.Errorf("request to %s failed: %s", r.indexURL, resp.Status)
+	}
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return productIndex{}, err // un-tested
+	}
+
+	var index productIndex
+	err = yaml.Unmarshal(contents, &index)
+	if err != nil {
+		return productIndex{}, fmt.Errorf("%s could not be parsed as a product index: %s", r.indexURL, err)
+	}
+
+	r.index = &index
+
+	return index, nil
+}
@@ -0,0 +1,671 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/network"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// directorConfigurationSections are the sections of the director block in the
+// state file, in the order they are passed to configure-director.
+var directorConfigurationSections = []string{
+	"iaas-configuration",
+	"director-configuration",
+	"security-configuration",
+	"syslog-configuration",
+	"az-configuration",
+	"networks-configuration",
+	"network-assignment",
+	"resource-configuration",
+}
+
+//go:generate counterfeiter -o ./fakes/reconcile_service.go --fake-name ReconcileService . reconcileService
+type reconcileService interface {
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+	ListAvailableProducts() (api.AvailableProductsOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+	ListStagedProductErrands(productID string) (api.ErrandsListOutput, error)
+	GetStagedProductProperties(product string) (map[string]api.ResponseProperty, error)
+	GetStagedProductNetworksAndAZs(product string) (map[string]interface{}, error)
+	ListStagedProductJobs(productGUID string) (map[string]string, error)
+	GetStagedProductJobResourceConfig(productGUID, jobGUID string) (api.JobProperties, error)
+	GetStagedProductSyslogConfiguration(product string) (map[string]interface{}, error)
+	GetStagedProductMaxInFlight(product string) (map[string]interface{}, error)
+	GetStagedDirectorProperties() (map[string]interface{}, error)
+	GetStagedDirectorAvailabilityZones() (map[string]interface{}, error)
+	GetStagedDirectorNetworks() (map[string]interface{}, error)
+	GetStagedDirectorNetworkAndAZ() (map[string]interface{}, error)
+}
+
+type Reconcile struct {
+	service  reconcileService
+	commands jhanda.CommandSet
+	stdin    io.Reader
+	logger   logger
+	Options  struct {
+		StateFile   string `long:"state"        short:"s" required:"true" description:"path to yml file describing the desired foundation (see docs/reconcile/README.md for format)"`
+		AutoApprove bool   `long:"auto-approve"                           description:"apply the plan without asking for confirmation"`
+	}
+}
+
+type foundationState struct {
+	Director  map[string]interface{} `yaml:"director"`
+	Stemcells []foundationStemcell   `yaml:"stemcells"`
+	Products  []foundationProduct    `yaml:"products"`
+}
+
+type foundationStemcell struct {
+	File string `yaml:"file"`
+}
+
+type foundationProduct struct {
+	Name    string                  `yaml:"name"`
+	Version string                  `yaml:"version"`
+	File    string                  `yaml:"file"`
+	Config  string                  `yaml:"config"`
+	Errands map[string]errandConfig `yaml:"errands"`
+}
+
+type reconcileStep struct {
+	description string
+	command     string
+	args        []string
+}
+
+func NewReconcile(service reconcileService, commands jhanda.CommandSet, stdin io.Reader, logger logger) Reconcile {
+	return Reconcile{
+		service:  service,
+		commands: commands,
+		stdin:    stdin,
+		logger:   logger,
+	}
+}
+
+func (r Reconcile) Execute(args []string) error {
+	if _, err := jhanda.Parse(&r.Options, args); err != nil {
+		return fmt.Errorf("could not parse reconcile flags: %s", err)
+	}
+
+	stateContents, err := ioutil.ReadFile(r.Options.StateFile)
+	if err != nil {
+		return err
+	}
+
+	var state foundationState
+	err = yaml.UnmarshalStrict(stateContents, &state)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed as a valid foundation state: %s", r.Options.StateFile, err)
+	}
+
+	report := &driftReport{}
+
+	steps, err := r.plan(state, filepath.Dir(r.Options.StateFile), report)
+	if err != nil {
+		return err
+	}
+
+	if len(report.skipped) > 0 {
+		r.logger.Printf("skipped %d values that cannot be compared, they are only applied when another value of the same config differs:", len(report.skipped))
+		for _, skipped := range report.skipped {
+			r.logger.Printf("  %s", skipped)
+		}
+	}
+
+	if len(steps) == 0 {
+		r.logger.Printf("foundation is up to date, nothing to be done")
+		return nil
+	}
+
+	r.logger.Printf("reconcile will perform the following steps:")
+	for i, step := range steps {
+		r.logger.Printf("  %d. %s", i+1, step.description)
+	}
+
+	if !r.Options.AutoApprove {
+		r.logger.Printf("do you want to perform these steps? only 'yes' will be accepted:")
+
+		answer, err := bufio.NewReader(r.stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("could not read confirmation: %s", err) // un-tested
+		}
+
+		if strings.TrimSpace(answer) != "yes" {
+			r.logger.Printf("reconcile cancelled, nothing was changed")
+			return nil
+		}
+	}
+
+	for i, step := range steps {
+		r.logger.Printf("step %d of %d: %s", i+1, len(steps), step.description)
+
+		err = r.commands.Execute(step.command, step.args)
+		if err != nil {
+			return fmt.Errorf("failed to %s: %s", step.description, err)
+		}
+	}
+
+	r.logger.Printf("finished reconciling foundation")
+
+	return nil
+}
+
+func (r Reconcile) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command compares a foundation state file against the Ops Manager, prints the steps needed to converge them and then runs those steps.",
+		ShortDescription: "converges the Ops Manager on a foundation state file",
+		Flags:            r.Options,
+	}
+}
+
+// plan returns the steps needed to converge the Ops Manager on the state.
+// Configuration steps are only planned when the staged configuration differs
+// from the state, values that cannot be compared are added to the report.
+func (r Reconcile) plan(state foundationState, baseDir string, report *driftReport) ([]reconcileStep, error) {
+	err := validateFoundationState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosticReport, err := r.service.GetDiagnosticReport()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diagnostic report: %s", err)
+	}
+
+	availableProducts, err := r.service.ListAvailableProducts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available products: %s", err)
+	}
+
+	stagedProducts, err := r.service.ListStagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged products: %s", err)
+	}
+
+	var (
+		uploads   []reconcileStep
+		stemcells []reconcileStep
+		stages    []reconcileStep
+		configs   []reconcileStep
+		errands   []reconcileStep
+		unstages  []reconcileStep
+	)
+
+	stagedGUIDs := map[string]string{}
+	for _, stagedProduct := range stagedProducts.Products {
+		stagedGUIDs[stagedProduct.Type] = stagedProduct.GUID
+	}
+
+	desired := map[string]bool{}
+	for _, product := range state.Products {
+		desired[product.Name] = true
+
+		available := false
+		for _, availableProduct := range availableProducts.ProductsList {
+			if availableProduct.Name == product.Name && availableProduct.Version == product.Version {
+				available = true
+				break
+			}
+		}
+
+		if !available {
+			if product.File == "" {
+				return nil, fmt.Errorf("product %s %s has not been uploaded and no file was given in the foundation state", product.Name, product.Version)
+			}
+
+			file := resolvePath(baseDir, product.File)
+			uploads = append(uploads, reconcileStep{
+				description: fmt.Sprintf("upload-product %s %s from %s", product.Name, product.Version, file),
+				command:     "upload-product",
+				args:        []string{"--product", file},
+			})
+		}
+
+		staged := false
+		for _, stagedProduct := range diagnosticReport.StagedProducts {
+			if stagedProduct.Name == product.Name && stagedProduct.Version == product.Version {
+				staged = true
+				break
+			}
+		}
+
+		if !staged {
+			stages = append(stages, reconcileStep{
+				description: fmt.Sprintf("stage-product %s %s", product.Name, product.Version),
+				command:     "stage-product",
+				args:        []string{"--product-name", product.Name, "--product-version", product.Version},
+			})
+		}
+
+		productGUID := stagedGUIDs[product.Name]
+		if !staged {
+			productGUID = ""
+		}
+
+		if product.Config != "" {
+			config := resolvePath(baseDir, product.Config)
+
+			drifted, err := r.productConfigDrifted(report, product.Name, productGUID, config)
+			if err != nil {
+				return nil, err
+			}
+
+			if drifted {
+				configs = append(configs, reconcileStep{
+					description: fmt.Sprintf("configure-product %s from %s", product.Name, config),
+					command:     "configure-product",
+					args:        []string{"--product-name", product.Name, "--config", config},
+				})
+			}
+		}
+
+		var stagedErrands map[string]api.Errand
+		if productGUID != "" && len(product.Errands) > 0 {
+			output, err := r.service.ListStagedProductErrands(productGUID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list errands of product %s: %s", product.Name, err)
+			}
+
+			stagedErrands = map[string]api.Errand{}
+			for _, errand := range output.Errands {
+				stagedErrands[errand.Name] = errand
+			}
+		}
+
+		for _, errandName := range sortedErrandNames(product.Errands) {
+			errandArgs, err := setErrandStateArgs(product.Name, errandName, product.Errands[errandName])
+			if err != nil {
+				return nil, err
+			}
+
+			if stagedErrand, ok := stagedErrands[errandName]; ok && sameErrandStates(product.Errands[errandName], stagedErrand) {
+				continue
+			}
+
+			errands = append(errands, reconcileStep{
+				description: fmt.Sprintf("set-errand-state %s %s", product.Name, errandName),
+				command:     "set-errand-state",
+				args:        errandArgs,
+			})
+		}
+	}
+
+	for _, stemcell := range state.Stemcells {
+		file := resolvePath(baseDir, stemcell.File)
+		if !contains(diagnosticReport.Stemcells, fileName(file)) {
+			stemcells = append(stemcells, reconcileStep{
+				description: fmt.Sprintf("upload-stemcell %s", file),
+				command:     "upload-stemcell",
+				args:        []string{"--stemcell", file},
+			})
+		}
+	}
+
+	for _, stagedProduct := range stagedProducts.Products {
+		if stagedProduct.Type == "p-bosh" || desired[stagedProduct.Type] {
+			continue
+		}
+
+		unstages = append(unstages, reconcileStep{
+			description: fmt.Sprintf("unstage-product %s", stagedProduct.Type),
+			command:     "unstage-product",
+			args:        []string{"--product-name", stagedProduct.Type},
+		})
+	}
+
+	var steps []reconcileStep
+	steps = append(steps, uploads...)
+	steps = append(steps, stemcells...)
+	steps = append(steps, stages...)
+
+	if len(state.Director) > 0 {
+		drifted, err := r.directorDrifted(report, state.Director, stagedGUIDs["p-bosh"])
+		if err != nil {
+			return nil, err
+		}
+
+		if drifted {
+			var directorArgs []string
+			for _, section := range directorConfigurationSections {
+				if state.Director[section] == nil {
+					continue
+				}
+
+				properties, err := getJSONProperties(state.Director[section])
+				if err != nil {
+					return nil, fmt.Errorf("could not convert director %s to json: %s", section, err) // un-tested
+				}
+
+				directorArgs = append(directorArgs, "--"+section, properties)
+			}
+
+			steps = append(steps, reconcileStep{
+				description: "configure-director",
+				command:     "configure-director",
+				args:        directorArgs,
+			})
+		}
+	}
+
+	steps = append(steps, configs...)
+	steps = append(steps, errands...)
+	steps = append(steps, unstages...)
+
+	return steps, nil
+}
+
+// productConfigDrifted reports whether the staged product differs from the
+// config file. A product that is not staged yet always needs configuring.
+func (r Reconcile) productConfigDrifted(report *driftReport, productName, productGUID, configFile string) (bool, error) {
+	if productGUID == "" {
+		return true, nil
+	}
+
+	configContents, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return false, fmt.Errorf("could not read config for product %s: %s", productName, err)
+	}
+
+	var config map[string]interface{}
+	err = yaml.Unmarshal(configContents, &config)
+	if err != nil {
+		return false, fmt.Errorf("%s could not be parsed as valid configuration: %s", configFile, err)
+	}
+
+	productReport := &driftReport{}
+	err = checkProductDrift(r.service, productReport, productName, productGUID, config)
+	if err != nil {
+		return false, fmt.Errorf("failed to compare product %s: %s", productName, err)
+	}
+
+	err = r.checkProductSettingsDrift(productReport, productGUID, config)
+	if err != nil {
+		return false, fmt.Errorf("failed to compare product %s: %s", productName, err)
+	}
+
+	report.merge(productName, productReport)
+
+	return len(productReport.differences) > 0, nil
+}
+
+// checkProductSettingsDrift compares the sections of a product config that
+// check-drift leaves out: syslog-properties, max-in-flight and errand-config.
+func (r Reconcile) checkProductSettingsDrift(report *driftReport, productGUID string, config map[string]interface{}) error {
+	if config["syslog-properties"] != nil {
+		syslogProperties, err := r.service.GetStagedProductSyslogConfiguration(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch staged syslog properties: %s", err)
+		}
+
+		err = report.compare("syslog-properties", config["syslog-properties"], syslogProperties)
+		if err != nil {
+			return err // un-tested
+		}
+	}
+
+	if config["max-in-flight"] != nil {
+		jobs, err := r.service.ListStagedProductJobs(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch jobs: %s", err)
+		}
+
+		maxInFlightByGUID, err := r.service.GetStagedProductMaxInFlight(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch staged max in flight: %s", err)
+		}
+
+		maxInFlight := map[string]interface{}{}
+		for name, jobGUID := range jobs {
+			if value, ok := maxInFlightByGUID[jobGUID]; ok {
+				maxInFlight[name] = value
+			}
+		}
+
+		err = report.compare("max-in-flight", config["max-in-flight"], maxInFlight)
+		if err != nil {
+			return err // un-tested
+		}
+	}
+
+	if config["errand-config"] != nil {
+		errandConfigs, err := getErrandConfigs(config["errand-config"])
+		if err != nil {
+			return err
+		}
+
+		output, err := r.service.ListStagedProductErrands(productGUID)
+		if err != nil {
+			return fmt.Errorf("failed to list errands: %s", err)
+		}
+
+		stagedErrands := map[string]api.Errand{}
+		for _, errand := range output.Errands {
+			stagedErrands[errand.Name] = errand
+		}
+
+		for _, name := range sortedErrandNames(errandConfigs) {
+			errand := stagedErrands[name]
+			desired := errandConfigs[name]
+
+			if !sameErrandState(desired.PostDeployState, errand.PostDeploy) {
+				report.addDifference("errand-config."+name+".post-deploy-state", desired.PostDeployState, errand.PostDeploy)
+			}
+
+			if !sameErrandState(desired.PreDeleteState, errand.PreDelete) {
+				report.addDifference("errand-config."+name+".pre-delete-state", desired.PreDeleteState, errand.PreDelete)
+			}
+		}
+	}
+
+	return nil
+}
+
+// directorDrifted reports whether the staged director differs from the
+// director block of the state file.
+func (r Reconcile) directorDrifted(report *driftReport, director map[string]interface{}, directorGUID string) (bool, error) {
+	directorReport := &driftReport{}
+
+	var properties map[string]interface{}
+	for _, section := range []string{"iaas-configuration", "director-configuration", "security-configuration", "syslog-configuration"} {
+		if director[section] == nil {
+			continue
+		}
+
+		if properties == nil {
+			var err error
+			properties, err = r.service.GetStagedDirectorProperties()
+			if err != nil {
+				return false, fmt.Errorf("failed to fetch staged director properties: %s", err)
+			}
+		}
+
+		err := directorReport.compare(section, director[section], properties[strings.Replace(section, "-", "_", -1)])
+		if err != nil {
+			return false, err // un-tested
+		}
+	}
+
+	stagedSections := []struct {
+		section string
+		key     string
+		get     func() (map[string]interface{}, error)
+	}{
+		{"az-configuration", "availability_zones", r.service.GetStagedDirectorAvailabilityZones},
+		{"networks-configuration", "", r.service.GetStagedDirectorNetworks},
+		{"network-assignment", "network_and_az", r.service.GetStagedDirectorNetworkAndAZ},
+	}
+
+	for _, staged := range stagedSections {
+		if director[staged.section] == nil {
+			continue
+		}
+
+		configuration, err := staged.get()
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch staged director %s: %s", staged.section, err)
+		}
+
+		var actual interface{} = configuration
+		if staged.key != "" {
+			actual = configuration[staged.key]
+		}
+
+		err = directorReport.compare(staged.section, director[staged.section], actual)
+		if err != nil {
+			return false, err // un-tested
+		}
+	}
+
+	if director["resource-configuration"] != nil {
+		if directorGUID == "" {
+			return true, nil
+		}
+
+		err := checkResourcesDrift(r.service, directorReport, "resource-configuration", "p-bosh", directorGUID, director["resource-configuration"])
+		if err != nil {
+			return false, fmt.Errorf("failed to compare director: %s", err)
+		}
+	}
+
+	report.merge("director", directorReport)
+
+	return len(directorReport.differences) > 0, nil
+}
+
+// sameErrandStates reports whether every state given for an errand in the
+// state file is already staged.
+func sameErrandStates(config errandConfig, errand api.Errand) bool {
+	return sameErrandState(config.PostDeployState, errand.PostDeploy) && sameErrandState(config.PreDeleteState, errand.PreDelete)
+}
+
+func sameErrandState(desired, staged interface{}) bool {
+	if desired == nil {
+		return true
+	}
+
+	flag, err := errandStateFlag(desired)
+	if err != nil {
+		return false // un-tested, the states are validated before they are compared
+	}
+
+	return fmt.Sprint(userToOMInputs[flag]) == fmt.Sprint(staged)
+}
+
+func validateFoundationState(state foundationState) error {
+	var errs []string
+
+	for section := range state.Director {
+		if !contains(directorConfigurationSections, section) {
+			errs = append(errs, fmt.Sprintf("director section %q is not supported%s", section, didYouMean(section, directorConfigurationSections)))
+		}
+	}
+
+	names := map[string]bool{}
+	for i, product := range state.Products {
+		if product.Name == "" || product.Version == "" {
+			errs = append(errs, fmt.Sprintf("product at index %d must have a name and version", i))
+			continue
+		}
+
+		if names[product.Name] {
+			errs = append(errs, fmt.Sprintf("product %q is defined more than once", product.Name))
+		}
+		names[product.Name] = true
+	}
+
+	for i, stemcell := range state.Stemcells {
+		if stemcell.File == "" {
+			errs = append(errs, fmt.Sprintf("stemcell at index %d has no file", i))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid foundation state: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func setErrandStateArgs(productName, errandName string, config errandConfig) ([]string, error) {
+	args := []string{"--product-name", productName, "--errand-name", errandName}
+
+	if config.PostDeployState != nil {
+		state, err := errandStateFlag(config.PostDeployState)
+		if err != nil {
+			return nil, fmt.Errorf("invalid post-deploy-state for errand %s of product %s: %s", errandName, productName, err)
+		}
+		args = append(args, "--post-deploy-state", state)
+	}
+
+	if config.PreDeleteState != nil {
+		state, err := errandStateFlag(config.PreDeleteState)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pre-delete-state for errand %s of product %s: %s", errandName, productName, err)
+		}
+		args = append(args, "--pre-delete-state", state)
+	}
+
+	return args, nil
+}
+
+// errandStateFlag turns an errand state from the state file into the value
+// expected by set-errand-state, so that yaml booleans can be used as well.
+func errandStateFlag(state interface{}) (string, error) {
+	switch s := state.(type) {
+	case bool:
+		if s {
+			return "enabled", nil
+		}
+		return "disabled", nil
+	case string:
+		if _, ok := userToOMInputs[s]; ok {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("%v is not one of enabled, disabled, when-changed or default", state)
+}
+
+func sortedErrandNames(errands map[string]errandConfig) []string {
+	var names []string
+	for name := range errands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// resolvePath resolves a file of the state file relative to the directory of
+// the state file. URLs and absolute paths are returned unchanged.
+func resolvePath(baseDir, file string) string {
+	if network.IsURL(file) || filepath.IsAbs(file) {
+		return file
+	}
+
+	return filepath.Join(baseDir, file)
+}
+
+// fileName returns the name of a resolved file, which for a URL is the last
+// element of its path.
+func fileName(file string) string {
+	if network.IsURL(file) {
+		u, err := url.Parse(file)
+		if err == nil {
+			return path.Base(u.Path)
+		}
+	}
+
+	return filepath.Base(file)
+}
@@ -0,0 +1,613 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordedCommand struct {
+	name string
+	args []string
+}
+
+type recordingCommand struct {
+	name     string
+	calls    *[]recordedCommand
+	executed error
+}
+
+func (c recordingCommand) Execute(args []string) error {
+	*c.calls = append(*c.calls, recordedCommand{name: c.name, args: args})
+	return c.executed
+}
+
+func (c recordingCommand) Usage() jhanda.Usage {
+	return jhanda.Usage{}
+}
+
+const foundationStateFile = `---
+director:
+  iaas-configuration:
+    project: some-project
+  networks-configuration:
+    networks:
+    - name: some-network
+stemcells:
+- file: stemcells/bosh-stemcell-3468.21-google-kvm-ubuntu-trusty-go_agent.tgz
+- file: /stemcells/bosh-stemcell-3445.24-google-kvm-ubuntu-trusty-go_agent.tgz
+products:
+- name: cf
+  version: 2.0.1
+  file: products/cf-2.0.1.pivotal
+  config: config/cf.yml
+  errands:
+    smoke_tests:
+      post-deploy-state: false
+    push-apps-manager:
+      post-deploy-state: when-changed
+      pre-delete-state: true
+- name: p-redis
+  version: 1.11.0
+  config: config/redis.yml
+`
+
+const redisConfigFile = `---
+product-properties:
+  .properties.maxmemory:
+    value: 512
+  .properties.password:
+    value: some-password
+`
+
+var _ = Describe("Reconcile", func() {
+	var (
+		service   *fakes.ReconcileService
+		logger    *fakes.Logger
+		calls     []recordedCommand
+		failing   map[string]error
+		stdin     *strings.Reader
+		stateDir  string
+		stateFile string
+	)
+
+	newCommand := func() commands.Reconcile {
+		commandSet := jhanda.CommandSet{}
+		for _, name := range []string{"upload-product", "upload-stemcell", "stage-product", "configure-director", "configure-product", "set-errand-state", "unstage-product"} {
+			commandSet[name] = recordingCommand{name: name, calls: &calls, executed: failing[name]}
+		}
+
+		return commands.NewReconcile(service, commandSet, stdin, logger)
+	}
+
+	logLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		service = &fakes.ReconcileService{}
+		logger = &fakes.Logger{}
+		calls = nil
+		failing = map[string]error{}
+		stdin = strings.NewReader("")
+
+		var err error
+		stateDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		stateFile = filepath.Join(stateDir, "foundation.yml")
+		err = ioutil.WriteFile(stateFile, []byte(foundationStateFile), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = os.MkdirAll(filepath.Join(stateDir, "config"), 0755)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(stateDir, "config", "redis.yml"), []byte(redisConfigFile), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+			".properties.maxmemory": {Value: 256},
+			".properties.password":  {Value: map[string]interface{}{"secret": "***"}, IsCredential: true},
+		}, nil)
+
+		service.GetDiagnosticReportReturns(api.DiagnosticReport{
+			Stemcells: []string{"bosh-stemcell-3445.24-google-kvm-ubuntu-trusty-go_agent.tgz"},
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.0-build.255"},
+				{Name: "cf", Version: "1.12.8"},
+				{Name: "p-redis", Version: "1.11.0"},
+				{Name: "p-mysql", Version: "1.10.3"},
+			},
+		}, nil)
+		service.ListAvailableProductsReturns(api.AvailableProductsOutput{
+			ProductsList: []api.ProductInfo{
+				{Name: "cf", Version: "1.12.8"},
+				{Name: "p-redis", Version: "1.11.0"},
+				{Name: "p-mysql", Version: "1.10.3"},
+			},
+		}, nil)
+		service.ListStagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{GUID: "p-bosh-guid", Type: "p-bosh"},
+				{GUID: "cf-guid", Type: "cf"},
+				{GUID: "p-redis-guid", Type: "p-redis"},
+				{GUID: "p-mysql-guid", Type: "p-mysql"},
+			},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(stateDir)
+	})
+
+	Describe("Execute", func() {
+		It("prints the plan and runs each step in dependency order", func() {
+			err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetStagedProductPropertiesArgsForCall(0)).To(Equal("p-redis-guid"))
+			Expect(service.ListStagedProductErrandsCallCount()).To(Equal(0))
+
+			Expect(calls).To(Equal([]recordedCommand{
+				{name: "upload-product", args: []string{"--product", filepath.Join(stateDir, "products/cf-2.0.1.pivotal")}},
+				{name: "upload-stemcell", args: []string{"--stemcell", filepath.Join(stateDir, "stemcells/bosh-stemcell-3468.21-google-kvm-ubuntu-trusty-go_agent.tgz")}},
+				{name: "stage-product", args: []string{"--product-name", "cf", "--product-version", "2.0.1"}},
+				{name: "configure-director", args: []string{
+					"--iaas-configuration", `{"project":"some-project"}`,
+					"--networks-configuration", `{"networks":[{"name":"some-network"}]}`,
+				}},
+				{name: "configure-product", args: []string{"--product-name", "cf", "--config", filepath.Join(stateDir, "config/cf.yml")}},
+				{name: "configure-product", args: []string{"--product-name", "p-redis", "--config", filepath.Join(stateDir, "config/redis.yml")}},
+				{name: "set-errand-state", args: []string{"--product-name", "cf", "--errand-name", "push-apps-manager", "--post-deploy-state", "when-changed", "--pre-delete-state", "enabled"}},
+				{name: "set-errand-state", args: []string{"--product-name", "cf", "--errand-name", "smoke_tests", "--post-deploy-state", "disabled"}},
+				{name: "unstage-product", args: []string{"--product-name", "p-mysql"}},
+			}))
+
+			lines := logLines()
+			Expect(lines[0]).To(Equal("skipped 1 values that cannot be compared, they are only applied when another value of the same config differs:"))
+			Expect(lines[1]).To(Equal("  p-redis: product-properties..properties.password (secret)"))
+			Expect(lines[2]).To(Equal("reconcile will perform the following steps:"))
+			Expect(lines[3]).To(Equal(fmt.Sprintf("  1. upload-product cf 2.0.1 from %s", filepath.Join(stateDir, "products/cf-2.0.1.pivotal"))))
+			Expect(lines[11]).To(Equal("  9. unstage-product p-mysql"))
+			Expect(lines[12]).To(Equal(fmt.Sprintf("step 1 of 9: upload-product cf 2.0.1 from %s", filepath.Join(stateDir, "products/cf-2.0.1.pivotal"))))
+			Expect(lines[len(lines)-1]).To(Equal("finished reconciling foundation"))
+		})
+
+		It("passes stemcell URLs through unchanged and compares them by file name", func() {
+			err := ioutil.WriteFile(stateFile, []byte(`---
+stemcells:
+- file: https://example.com/stemcells/bosh-stemcell-3445.24-google-kvm-ubuntu-trusty-go_agent.tgz?token=some-token
+- file: https://example.com/stemcells/bosh-stemcell-3468.21-google-kvm-ubuntu-trusty-go_agent.tgz
+`), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(calls[0]).To(Equal(recordedCommand{
+				name: "upload-stemcell",
+				args: []string{"--stemcell", "https://example.com/stemcells/bosh-stemcell-3468.21-google-kvm-ubuntu-trusty-go_agent.tgz"},
+			}))
+			Expect(calls[1].name).To(Equal("unstage-product"))
+		})
+
+		Context("when the foundation is up to date", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(stateFile, []byte(`---
+director:
+  iaas-configuration:
+    project: some-project
+  az-configuration:
+  - name: us-central1-a
+  networks-configuration:
+    networks:
+    - name: some-network
+  network-assignment:
+    network:
+      name: some-network
+  resource-configuration:
+    director:
+      instance_type:
+        id: large
+products:
+- name: p-redis
+  version: 1.11.0
+  config: config/redis.yml
+  errands:
+    smoke-tests:
+      post-deploy-state: when-changed
+    delete-all-service-instances:
+      pre-delete-state: true
+- name: p-mysql
+  version: 1.10.3
+- name: cf
+  version: 1.12.8
+`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				service.GetStagedDirectorPropertiesReturns(map[string]interface{}{
+					"iaas_configuration":     map[string]interface{}{"project": "some-project", "default_deployment_tag": "some-tag"},
+					"director_configuration": map[string]interface{}{"ntp_servers_string": "some-ntp"},
+				}, nil)
+				service.GetStagedDirectorAvailabilityZonesReturns(map[string]interface{}{
+					"availability_zones": []interface{}{map[string]interface{}{"guid": "some-guid", "name": "us-central1-a"}},
+				}, nil)
+				service.GetStagedDirectorNetworksReturns(map[string]interface{}{
+					"icmp_checks_enabled": false,
+					"networks":            []interface{}{map[string]interface{}{"guid": "some-guid", "name": "some-network"}},
+				}, nil)
+				service.GetStagedDirectorNetworkAndAZReturns(map[string]interface{}{
+					"network_and_az": map[string]interface{}{"network": map[string]interface{}{"name": "some-network"}},
+				}, nil)
+				service.ListStagedProductJobsReturns(map[string]string{"director": "director-guid"}, nil)
+				service.GetStagedProductJobResourceConfigReturns(api.JobProperties{
+					InstanceType: api.InstanceType{ID: "large"},
+				}, nil)
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.maxmemory": {Value: 512},
+					".properties.password":  {Value: map[string]interface{}{"secret": "***"}, IsCredential: true},
+				}, nil)
+				service.ListStagedProductErrandsReturns(api.ErrandsListOutput{
+					Errands: []api.Errand{
+						{Name: "smoke-tests", PostDeploy: "when-changed"},
+						{Name: "delete-all-service-instances", PreDelete: true},
+					},
+				}, nil)
+			})
+
+			It("does nothing", func() {
+				err := newCommand().Execute([]string{"--state", stateFile})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(calls).To(BeEmpty())
+				Expect(service.ListStagedProductJobsArgsForCall(0)).To(Equal("p-bosh-guid"))
+				Expect(service.ListStagedProductErrandsArgsForCall(0)).To(Equal("p-redis-guid"))
+				Expect(logLines()).To(Equal([]string{
+					"skipped 1 values that cannot be compared, they are only applied when another value of the same config differs:",
+					"  p-redis: product-properties..properties.password (secret)",
+					"foundation is up to date, nothing to be done",
+				}))
+			})
+
+			It("only plans the steps whose staged state differs", func() {
+				service.GetStagedDirectorNetworkAndAZReturns(map[string]interface{}{
+					"network_and_az": map[string]interface{}{"network": map[string]interface{}{"name": "other-network"}},
+				}, nil)
+				service.ListStagedProductErrandsReturns(api.ErrandsListOutput{
+					Errands: []api.Errand{
+						{Name: "smoke-tests", PostDeploy: false},
+						{Name: "delete-all-service-instances", PreDelete: true},
+					},
+				}, nil)
+
+				err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(calls).To(HaveLen(2))
+				Expect(calls[0].name).To(Equal("configure-director"))
+				Expect(calls[1]).To(Equal(recordedCommand{
+					name: "set-errand-state",
+					args: []string{"--product-name", "p-redis", "--errand-name", "smoke-tests", "--post-deploy-state", "when-changed"},
+				}))
+			})
+
+			It("configures a product whose staged properties differ", func() {
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.maxmemory": {Value: 1024},
+				}, nil)
+
+				err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(calls).To(Equal([]recordedCommand{
+					{name: "configure-product", args: []string{"--product-name", "p-redis", "--config", filepath.Join(stateDir, "config/redis.yml")}},
+				}))
+			})
+
+			Context("when the product config has syslog-properties, max-in-flight or errand-config", func() {
+				writeRedisConfig := func(section string) {
+					err := ioutil.WriteFile(filepath.Join(stateDir, "config", "redis.yml"), []byte(redisConfigFile+section), 0644)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				configureProduct := func() recordedCommand {
+					return recordedCommand{
+						name: "configure-product",
+						args: []string{"--product-name", "p-redis", "--config", filepath.Join(stateDir, "config/redis.yml")},
+					}
+				}
+
+				BeforeEach(func() {
+					service.ListStagedProductJobsReturns(map[string]string{"director": "director-guid", "redis-server": "redis-server-guid"}, nil)
+					service.GetStagedProductSyslogConfigurationReturns(map[string]interface{}{"enabled": true, "address": "example.com"}, nil)
+					service.GetStagedProductMaxInFlightReturns(map[string]interface{}{"redis-server-guid": "20%"}, nil)
+				})
+
+				It("does nothing when the staged settings match", func() {
+					writeRedisConfig(`
+syslog-properties:
+  enabled: true
+  address: example.com
+max-in-flight:
+  redis-server: 20%
+errand-config:
+  smoke-tests:
+    post-deploy-state: when-changed
+`)
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(calls).To(BeEmpty())
+					Expect(service.GetStagedProductSyslogConfigurationArgsForCall(0)).To(Equal("p-redis-guid"))
+					Expect(service.GetStagedProductMaxInFlightArgsForCall(0)).To(Equal("p-redis-guid"))
+				})
+
+				It("configures a product whose staged syslog-properties differ", func() {
+					writeRedisConfig(`
+syslog-properties:
+  enabled: true
+  address: other.example.com
+`)
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(calls).To(Equal([]recordedCommand{configureProduct()}))
+				})
+
+				It("configures a product whose staged max-in-flight differs", func() {
+					writeRedisConfig(`
+max-in-flight:
+  redis-server: 1
+`)
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(calls).To(Equal([]recordedCommand{configureProduct()}))
+				})
+
+				It("configures a product whose staged errand-config differs", func() {
+					writeRedisConfig(`
+errand-config:
+  delete-all-service-instances:
+    pre-delete-state: false
+`)
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(calls).To(Equal([]recordedCommand{configureProduct()}))
+				})
+
+				It("returns an error when the staged syslog-properties cannot be fetched", func() {
+					writeRedisConfig(`
+syslog-properties:
+  enabled: true
+`)
+					service.GetStagedProductSyslogConfigurationReturns(nil, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("failed to compare product p-redis: failed to fetch staged syslog properties: boom"))
+				})
+
+				It("returns an error when the staged max-in-flight cannot be fetched", func() {
+					writeRedisConfig(`
+max-in-flight:
+  redis-server: 1
+`)
+					service.GetStagedProductMaxInFlightReturns(nil, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("failed to compare product p-redis: failed to fetch staged max in flight: boom"))
+				})
+			})
+
+			Context("failure cases", func() {
+				It("returns an error when the staged director cannot be fetched", func() {
+					service.GetStagedDirectorNetworksReturns(nil, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("failed to fetch staged director networks-configuration: boom"))
+				})
+
+				It("returns an error when the staged director properties cannot be fetched", func() {
+					service.GetStagedDirectorPropertiesReturns(nil, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("failed to fetch staged director properties: boom"))
+				})
+
+				It("returns an error when the staged errands cannot be listed", func() {
+					service.ListStagedProductErrandsReturns(api.ErrandsListOutput{}, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("failed to list errands of product p-redis: boom"))
+				})
+
+				It("returns an error when the staged product cannot be compared", func() {
+					service.GetStagedProductPropertiesReturns(nil, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("failed to compare product p-redis: failed to fetch staged properties: boom"))
+				})
+
+				It("returns an error when a product config cannot be read", func() {
+					Expect(os.Remove(filepath.Join(stateDir, "config", "redis.yml"))).To(Succeed())
+
+					err := newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError(ContainSubstring("could not read config for product p-redis: open")))
+				})
+			})
+		})
+
+		Context("when --auto-approve is not provided", func() {
+			It("runs the plan when the user answers yes", func() {
+				stdin = strings.NewReader("yes\n")
+
+				err := newCommand().Execute([]string{"--state", stateFile})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(calls).To(HaveLen(9))
+				Expect(logLines()).To(ContainElement("do you want to perform these steps? only 'yes' will be accepted:"))
+			})
+
+			It("does not change anything for any other answer", func() {
+				stdin = strings.NewReader("y\n")
+
+				err := newCommand().Execute([]string{"--state", stateFile})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(calls).To(BeEmpty())
+				lines := logLines()
+				Expect(lines[len(lines)-1]).To(Equal("reconcile cancelled, nothing was changed"))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := newCommand().Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse reconcile flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the state file does not exist", func() {
+				It("returns an error", func() {
+					err := newCommand().Execute([]string{"--state", "some/non-existent/foundation.yml"})
+					Expect(err).To(MatchError("open some/non-existent/foundation.yml: no such file or directory"))
+				})
+			})
+
+			Context("when the state file contains unknown keys", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(stateFile, []byte("---\nproduct:\n- name: cf\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError(ContainSubstring("could not be parsed as a valid foundation state")))
+				})
+			})
+
+			Context("when the state file is invalid", func() {
+				It("returns an error before talking to the Ops Manager", func() {
+					err := ioutil.WriteFile(stateFile, []byte(`---
+director:
+  iaas-configuraton: {}
+stemcells:
+- {}
+products:
+- name: cf
+- name: p-redis
+  version: 1.11.0
+- name: p-redis
+  version: 1.11.0
+`), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError(`invalid foundation state: ` +
+						`director section "iaas-configuraton" is not supported (did you mean "iaas-configuration"?), ` +
+						`product at index 0 must have a name and version, ` +
+						`product "p-redis" is defined more than once, ` +
+						`stemcell at index 0 has no file`))
+
+					Expect(service.GetDiagnosticReportCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when an errand state is invalid", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(stateFile, []byte(`---
+products:
+- name: cf
+  version: 1.12.8
+  errands:
+    smoke_tests:
+      post-deploy-state: sometimes
+`), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("invalid post-deploy-state for errand smoke_tests of product cf: sometimes is not one of enabled, disabled, when-changed or default"))
+				})
+			})
+
+			Context("when a product that has not been uploaded has no file", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(stateFile, []byte("---\nproducts:\n- name: cf\n  version: 2.0.1\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = newCommand().Execute([]string{"--state", stateFile})
+					Expect(err).To(MatchError("product cf 2.0.1 has not been uploaded and no file was given in the foundation state"))
+				})
+			})
+
+			Context("when the diagnostic report cannot be fetched", func() {
+				It("returns an error", func() {
+					service.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).To(MatchError("failed to get diagnostic report: boom"))
+				})
+			})
+
+			Context("when the available products cannot be listed", func() {
+				It("returns an error", func() {
+					service.ListAvailableProductsReturns(api.AvailableProductsOutput{}, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).To(MatchError("failed to list available products: boom"))
+				})
+			})
+
+			Context("when the staged products cannot be listed", func() {
+				It("returns an error", func() {
+					service.ListStagedProductsReturns(api.StagedProductsOutput{}, errors.New("boom"))
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).To(MatchError("failed to list staged products: boom"))
+				})
+			})
+
+			Context("when a step fails", func() {
+				It("stops and returns an error", func() {
+					failing["stage-product"] = errors.New("boom")
+
+					err := newCommand().Execute([]string{"--state", stateFile, "--auto-approve"})
+					Expect(err).To(MatchError(`failed to stage-product cf 2.0.1: could not execute "stage-product": boom`))
+
+					Expect(calls).To(HaveLen(3))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := newCommand()
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command compares a foundation state file against the Ops Manager, prints the steps needed to converge them and then runs those steps.",
+				ShortDescription: "converges the Ops Manager on a foundation state file",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type resourceTypesService interface {
+	ListVMTypes() ([]api.VMType, error)
+	ListDiskTypes() ([]api.DiskType, error)
+	ListStagedVMExtensions() ([]api.VMExtension, error)
+}
+
+// validateResourceConfig checks the user provided resource config before
+// anything is changed on the Ops Manager: every job must exist in the product,
+// and any instance_type, persistent_disk or additional_vm_extensions must refer
+// to a known vm type, disk type or vm extension.
+func validateResourceConfig(service resourceTypesService, productName string, jobs map[string]string, config map[string]json.RawMessage) error {
+	var jobNames []string
+	for name := range jobs {
+		jobNames = append(jobNames, name)
+	}
+
+	var names []string
+	for name := range config {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var (
+		errs          []string
+		instanceTypes = map[string]string{}
+		diskSizes     = map[string]string{}
+		vmExtensions  = map[string][]string{}
+	)
+	for _, name := range names {
+		if _, ok := jobs[name]; !ok {
+			errs = append(errs, fmt.Sprintf("product %q does not contain a job named %q%s", productName, name, didYouMean(name, jobNames)))
+			continue
+		}
+
+		var jobConfig struct {
+			InstanceType           *api.InstanceType `json:"instance_type"`
+			PersistentDisk         *api.Disk         `json:"persistent_disk"`
+			AdditionalVMExtensions []string          `json:"additional_vm_extensions"`
+		}
+		err := json.Unmarshal(config[name], &jobConfig)
+		if err != nil {
+			return fmt.Errorf("could not decode resource-configuration json for job %q: %s", name, err)
+		}
+
+		if jobConfig.InstanceType != nil && jobConfig.InstanceType.ID != "automatic" {
+			instanceTypes[name] = jobConfig.InstanceType.ID
+		}
+
+		if jobConfig.PersistentDisk != nil && jobConfig.PersistentDisk.Size != "automatic" {
+			diskSizes[name] = jobConfig.PersistentDisk.Size
+		}
+
+		if len(jobConfig.AdditionalVMExtensions) > 0 {
+			vmExtensions[name] = jobConfig.AdditionalVMExtensions
+		}
+	}
+
+	if len(instanceTypes) > 0 {
+		vmTypes, err := service.ListVMTypes()
+		if err != nil {
+			return fmt.Errorf("failed to fetch vm types: %s", err)
+		}
+
+		var vmTypeNames []string
+		for _, vmType := range vmTypes {
+			vmTypeNames = append(vmTypeNames, vmType.Name)
+		}
+
+		for _, name := range names {
+			instanceType, ok := instanceTypes[name]
+			if ok && !contains(vmTypeNames, instanceType) {
+				errs = append(errs, fmt.Sprintf("instance_type %q for job %q is not a known vm type%s", instanceType, name, didYouMean(instanceType, vmTypeNames)))
+			}
+		}
+	}
+
+	if len(diskSizes) > 0 {
+		diskTypes, err := service.ListDiskTypes()
+		if err != nil {
+			return fmt.Errorf("failed to fetch disk types: %s", err)
+		}
+
+		var diskTypeNames []string
+		for _, diskType := range diskTypes {
+			diskTypeNames = append(diskTypeNames, diskType.Name)
+		}
+
+		for _, name := range names {
+			diskSize, ok := diskSizes[name]
+			if ok && !contains(diskTypeNames, diskSize) {
+				errs = append(errs, fmt.Sprintf("persistent_disk size_mb %q for job %q is not a known disk type", diskSize, name))
+			}
+		}
+	}
+
+	if len(vmExtensions) > 0 {
+		stagedVMExtensions, err := service.ListStagedVMExtensions()
+		if err != nil {
+			return fmt.Errorf("failed to fetch vm extensions: %s", err)
+		}
+
+		var vmExtensionNames []string
+		for _, vmExtension := range stagedVMExtensions {
+			vmExtensionNames = append(vmExtensionNames, vmExtension.Name)
+		}
+
+		for _, name := range names {
+			for _, vmExtension := range vmExtensions[name] {
+				if !contains(vmExtensionNames, vmExtension) {
+					errs = append(errs, fmt.Sprintf("additional_vm_extension %q for job %q is not a known vm extension%s", vmExtension, name, didYouMean(vmExtension, vmExtensionNames)))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid resource configuration: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// didYouMean returns a suggestion for the closest candidate to name,
+// or an empty string when none of the candidates are close enough.
+func didYouMean(name string, candidates []string) string {
+	sorted := append([]string{}, candidates...)
+	sort.Strings(sorted)
+
+	var closest string
+	maxDistance := len(name)/3 + 1
+	for _, candidate := range sorted {
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if distance <= maxDistance {
+			closest = candidate
+			maxDistance = distance - 1
+		}
+	}
+
+	if closest == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" (did you mean %q?)", closest)
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -8,11 +8,13 @@ import (
 )
 
 type StageProduct struct {
-	logger  logger
-	service stageProductService
-	Options struct {
-		Product string `long:"product-name"    short:"p" required:"true" description:"name of product"`
-		Version string `long:"product-version" short:"v" required:"true" description:"version of product"`
+	logger            logger
+	service           stageProductService
+	metadataExtractor metadataExtractor
+	Options           struct {
+		Product     string `long:"product-name"    short:"p" required:"true" description:"name of product"`
+		Version     string `long:"product-version" short:"v" required:"true" description:"version of product"`
+		ProductFile string `long:"product-file"    short:"f"                 description:"path or http(s) url of the product file, used to check that the products it requires are staged or deployed"`
 	}
 }
 
@@ -24,10 +26,11 @@ type stageProductService interface {
 	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
-func NewStageProduct(service stageProductService, logger logger) StageProduct {
+func NewStageProduct(metadataExtractor metadataExtractor, service stageProductService, logger logger) StageProduct {
 	return StageProduct{
-		logger:  logger,
-		service: service,
+		logger:            logger,
+		service:           service,
+		metadataExtractor: metadataExtractor,
 	}
 }
 
@@ -69,6 +72,13 @@ func (sp StageProduct) Execute(args []string) error {
 		return fmt.Errorf("failed to stage product: cannot find product %s %s", sp.Options.Product, sp.Options.Version)
 	}
 
+	if sp.Options.ProductFile != "" {
+		err = sp.checkDependencies(diagnosticReport)
+		if err != nil {
+			return fmt.Errorf("failed to stage product: %s", err)
+		}
+	}
+
 	sp.logger.Printf("staging %s %s", sp.Options.Product, sp.Options.Version)
 
 	err = sp.service.Stage(api.StageProductInput{
@@ -84,6 +94,19 @@ func (sp StageProduct) Execute(args []string) error {
 	return nil
 }
 
+func (sp StageProduct) checkDependencies(report api.DiagnosticReport) error {
+	metadata, err := sp.metadataExtractor.ExtractMetadata(sp.Options.ProductFile)
+	if err != nil {
+		return fmt.Errorf("cannot extract product metadata: %s", err)
+	}
+
+	if metadata.Name != sp.Options.Product || metadata.Version != sp.Options.Version {
+		return fmt.Errorf("%s is %s %s, not %s %s", sp.Options.ProductFile, metadata.Name, metadata.Version, sp.Options.Product, sp.Options.Version)
+	}
+
+	return checkProductDependencies(metadata, installedProductVersions(report))
+}
+
 func (sp StageProduct) Usage() jhanda.Usage {
 	return jhanda.Usage{
 		Description:      "This command attempts to stage a product in the Ops Manager",
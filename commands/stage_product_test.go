@@ -8,6 +8,7 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -15,19 +16,21 @@ import (
 
 var _ = Describe("StageProduct", func() {
 	var (
-		fakeService *fakes.StageProductService
-		logger      *fakes.Logger
+		fakeService       *fakes.StageProductService
+		metadataExtractor *fakes.MetadataExtractor
+		logger            *fakes.Logger
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.StageProductService{}
+		metadataExtractor = &fakes.MetadataExtractor{}
 		logger = &fakes.Logger{}
 	})
 
 	It("stages a product", func() {
 		fakeService.CheckProductAvailabilityReturns(true, nil)
 
-		command := commands.NewStageProduct(fakeService, logger)
+		command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 
 		fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
 			api.DeployedProductOutput{
@@ -63,7 +66,7 @@ var _ = Describe("StageProduct", func() {
 		It("stages the product", func() {
 			fakeService.CheckProductAvailabilityReturns(true, nil)
 
-			command := commands.NewStageProduct(fakeService, logger)
+			command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 
 			fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
 				api.DeployedProductOutput{
@@ -112,7 +115,7 @@ var _ = Describe("StageProduct", func() {
 				},
 			}, nil)
 
-			command := commands.NewStageProduct(fakeService, logger)
+			command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 
 			err := command.Execute([]string{
 				"--product-name", "some-product",
@@ -127,10 +130,113 @@ var _ = Describe("StageProduct", func() {
 		})
 	})
 
+	Context("when the product file is provided", func() {
+		var command commands.StageProduct
+
+		BeforeEach(func() {
+			command = commands.NewStageProduct(metadataExtractor, fakeService, logger)
+
+			fakeService.CheckProductAvailabilityReturns(true, nil)
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "p-bosh", Version: "2.2.3"},
+					{Name: "cf", Version: "2.1.7"},
+				},
+				DeployedProducts: []api.DiagnosticProduct{
+					{Name: "p-bosh", Version: "2.2.3"},
+					{Name: "p-mysql", Version: "2.3.1"},
+				},
+			}, nil)
+		})
+
+		It("stages the product when its required products are staged or deployed", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "p-redis",
+				Version: "1.14.2",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "p-bosh", Version: "~> 2.2"},
+					{Name: "cf", Version: ">= 2.1, < 2.3"},
+					{Name: "p-mysql", Version: "~> 2.3.0"},
+				},
+			}, nil)
+
+			err := command.Execute([]string{
+				"--product-name", "p-redis",
+				"--product-version", "1.14.2",
+				"--product-file", "/path/to/p-redis.pivotal",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractMetadataArgsForCall(0)).To(Equal("/path/to/p-redis.pivotal"))
+			Expect(fakeService.StageCallCount()).To(Equal(1))
+		})
+
+		It("returns an error listing the missing products without staging", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "p-redis",
+				Version: "1.14.2",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "p-bosh", Version: "~> 2.2"},
+					{Name: "cf", Version: "~> 2.2"},
+					{Name: "p-mysql", Version: "~> 2.2.0"},
+					{Name: "p-rabbitmq", Version: ">= 1.10"},
+				},
+			}, nil)
+
+			err := command.Execute([]string{
+				"--product-name", "p-redis",
+				"--product-version", "1.14.2",
+				"--product-file", "/path/to/p-redis.pivotal",
+			})
+			Expect(err).To(MatchError(`failed to stage product: p-redis 1.14.2 requires products that are not staged or deployed:
+- cf ~> 2.2 (found 2.1.7)
+- p-mysql ~> 2.2.0 (found 2.3.1)
+- p-rabbitmq >= 1.10 (not found)`))
+			Expect(fakeService.StageCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the product file is a different product", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{Name: "p-redis", Version: "1.13.0"}, nil)
+
+			err := command.Execute([]string{
+				"--product-name", "p-redis",
+				"--product-version", "1.14.2",
+				"--product-file", "/path/to/p-redis.pivotal",
+			})
+			Expect(err).To(MatchError("failed to stage product: /path/to/p-redis.pivotal is p-redis 1.13.0, not p-redis 1.14.2"))
+		})
+
+		It("returns an error when the metadata cannot be extracted", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+			err := command.Execute([]string{
+				"--product-name", "p-redis",
+				"--product-version", "1.14.2",
+				"--product-file", "/path/to/p-redis.pivotal",
+			})
+			Expect(err).To(MatchError("failed to stage product: cannot extract product metadata: some error"))
+		})
+
+		It("returns an error when a version constraint is invalid", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:                    "p-redis",
+				Version:                 "1.14.2",
+				RequiresProductVersions: []extractor.ProductVersion{{Name: "cf", Version: "~> two"}},
+			}, nil)
+
+			err := command.Execute([]string{
+				"--product-name", "p-redis",
+				"--product-version", "1.14.2",
+				"--product-file", "/path/to/p-redis.pivotal",
+			})
+			Expect(err).To(MatchError(`failed to stage product: p-redis 1.14.2 requires cf: invalid version constraint "~> two"`))
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse stage-product flags: flag provided but not defined: -badflag"))
 			})
@@ -138,7 +244,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the product-name flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 				err := command.Execute([]string{"--product-version", "1.0"})
 				Expect(err).To(MatchError("could not parse stage-product flags: missing required flag \"--product-name\""))
 			})
@@ -146,7 +252,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the product-version flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 				err := command.Execute([]string{"--product-name", "some-product"})
 				Expect(err).To(MatchError("could not parse stage-product flags: missing required flag \"--product-version\""))
 			})
@@ -158,7 +264,7 @@ var _ = Describe("StageProduct", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -174,7 +280,7 @@ var _ = Describe("StageProduct", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -186,7 +292,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the product cannot be staged", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 				fakeService.CheckProductAvailabilityReturns(true, nil)
 				fakeService.StageReturns(errors.New("some product error"))
 
@@ -197,7 +303,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the diagnostic report cannot be fetched", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 				fakeService.CheckProductAvailabilityReturns(true, nil)
 				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("bad diagnostic report"))
 
@@ -214,7 +320,7 @@ var _ = Describe("StageProduct", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(metadataExtractor, fakeService, logger)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -228,7 +334,7 @@ var _ = Describe("StageProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewStageProduct(nil, nil)
+			command := commands.NewStageProduct(nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command attempts to stage a product in the Ops Manager",
 				ShortDescription: "stages a given product in the Ops Manager targeted",
@@ -26,6 +26,9 @@ type stagedConfigService interface {
 	GetStagedProductProperties(product string) (map[string]api.ResponseProperty, error)
 	ListDeployedProducts() ([]api.DeployedProductOutput, error)
 	ListStagedProductJobs(productGUID string) (map[string]string, error)
+	ListStagedProductErrands(productID string) (api.ErrandsListOutput, error)
+	GetStagedProductSyslogConfiguration(product string) (map[string]interface{}, error)
+	GetStagedProductMaxInFlight(product string) (map[string]interface{}, error)
 }
 
 func NewStagedConfig(service stagedConfigService, logger logger) StagedConfig {
@@ -65,28 +68,54 @@ func (ec StagedConfig) Execute(args []string) error {
 		}
 	}
 
-	findOutput, err := ec.service.GetStagedProductByName(ec.Options.Product)
+	config, err := fetchStagedConfig(ec.service, ec.Options.Product, ec.Options.IncludeCredentials)
 	if err != nil {
 		return err
 	}
+
+	output, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal config: %s", err) // un-tested
+	}
+	ec.logger.Println(string(output))
+
+	return nil
+}
+
+type stagedProductConfig struct {
+	Properties               map[string]interface{}       `yaml:"product-properties"`
+	NetworkProperties        map[string]interface{}       `yaml:"network-properties"`
+	ResourceConfigProperties map[string]api.JobProperties `yaml:"resource-config"`
+	SyslogProperties         map[string]interface{}       `yaml:"syslog-properties,omitempty"`
+	MaxInFlight              map[string]interface{}       `yaml:"max-in-flight,omitempty"`
+	ErrandConfigs            map[string]errandConfig      `yaml:"errand-config,omitempty"`
+}
+
+// fetchStagedConfig reads the configuration of a staged product in the format
+// accepted by configure-product.
+func fetchStagedConfig(service stagedConfigService, productName string, includeCredentials bool) (stagedProductConfig, error) {
+	findOutput, err := service.GetStagedProductByName(productName)
+	if err != nil {
+		return stagedProductConfig{}, err
+	}
 	productGUID := findOutput.Product.GUID
 
-	properties, err := ec.service.GetStagedProductProperties(productGUID)
+	properties, err := service.GetStagedProductProperties(productGUID)
 	if err != nil {
-		return err
+		return stagedProductConfig{}, err
 	}
 
 	configurableProperties := map[string]interface{}{}
 
 	for name, property := range properties {
 		if property.Configurable && property.Value != nil {
-			if property.IsCredential && ec.Options.IncludeCredentials {
-				output, err := ec.service.GetDeployedProductCredential(api.GetDeployedProductCredentialInput{
+			if property.IsCredential && includeCredentials {
+				output, err := service.GetDeployedProductCredential(api.GetDeployedProductCredentialInput{
 					DeployedGUID:        productGUID,
 					CredentialReference: name,
 				})
 				if err != nil {
-					return err
+					return stagedProductConfig{}, err
 				}
 				configurableProperties[name] = map[string]interface{}{"value": output.Credential.Value}
 			} else {
@@ -95,42 +124,63 @@ func (ec StagedConfig) Execute(args []string) error {
 		}
 	}
 
-	networks, err := ec.service.GetStagedProductNetworksAndAZs(productGUID)
+	networks, err := service.GetStagedProductNetworksAndAZs(productGUID)
 	if err != nil {
-		return err
+		return stagedProductConfig{}, err
 	}
 
-	jobs, err := ec.service.ListStagedProductJobs(productGUID)
+	jobs, err := service.ListStagedProductJobs(productGUID)
 	if err != nil {
-		return err
+		return stagedProductConfig{}, err
 	}
 
 	resourceConfig := map[string]api.JobProperties{}
 
 	for name, jobGUID := range jobs {
-		jobProperties, err := ec.service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
+		jobProperties, err := service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
 		if err != nil {
-			return err
+			return stagedProductConfig{}, err
 		}
 
 		resourceConfig[name] = jobProperties
 	}
 
-	config := struct {
-		Properties               map[string]interface{}       `yaml:"product-properties"`
-		NetworkProperties        map[string]interface{}       `yaml:"network-properties"`
-		ResourceConfigProperties map[string]api.JobProperties `yaml:"resource-config"`
-	}{
-		Properties:               configurableProperties,
-		NetworkProperties:        networks,
-		ResourceConfigProperties: resourceConfig,
+	syslogProperties, err := service.GetStagedProductSyslogConfiguration(productGUID)
+	if err != nil {
+		return stagedProductConfig{}, err
 	}
 
-	output, err := yaml.Marshal(config)
+	maxInFlightByGUID, err := service.GetStagedProductMaxInFlight(productGUID)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal config: %s", err) // un-tested
+		return stagedProductConfig{}, err
 	}
-	ec.logger.Println(string(output))
 
-	return nil
+	maxInFlight := map[string]interface{}{}
+	for name, jobGUID := range jobs {
+		if value, ok := maxInFlightByGUID[jobGUID]; ok {
+			maxInFlight[name] = value
+		}
+	}
+
+	errandsListOutput, err := service.ListStagedProductErrands(productGUID)
+	if err != nil {
+		return stagedProductConfig{}, err
+	}
+
+	errandConfigs := map[string]errandConfig{}
+	for _, errand := range errandsListOutput.Errands {
+		errandConfigs[errand.Name] = errandConfig{
+			PostDeployState: errand.PostDeploy,
+			PreDeleteState:  errand.PreDelete,
+		}
+	}
+
+	return stagedProductConfig{
+		Properties:               configurableProperties,
+		NetworkProperties:        networks,
+		ResourceConfigProperties: resourceConfig,
+		SyslogProperties:         syslogProperties,
+		MaxInFlight:              maxInFlight,
+		ErrandConfigs:            errandConfigs,
+	}, nil
 }
@@ -73,6 +73,19 @@ var _ = Describe("StagedConfig", func() {
 			},
 			Instances: 1,
 		}, nil)
+		fakeService.GetStagedProductSyslogConfigurationReturns(map[string]interface{}{
+			"enabled": true,
+			"address": "example.com",
+		}, nil)
+		fakeService.GetStagedProductMaxInFlightReturns(map[string]interface{}{
+			"some-job-guid": "20%",
+		}, nil)
+		fakeService.ListStagedProductErrandsReturns(api.ErrandsListOutput{
+			Errands: []api.Errand{
+				{Name: "first-errand", PostDeploy: "when-changed"},
+				{Name: "second-errand", PostDeploy: false, PreDelete: true},
+			},
+		}, nil)
 	})
 
 	Describe("Execute", func() {
@@ -100,6 +113,15 @@ var _ = Describe("StagedConfig", func() {
 			Expect(productGuid).To(Equal("some-product-guid"))
 			Expect(jobsGuid).To(Equal("some-job-guid"))
 
+			Expect(fakeService.GetStagedProductSyslogConfigurationCallCount()).To(Equal(1))
+			Expect(fakeService.GetStagedProductSyslogConfigurationArgsForCall(0)).To(Equal("some-product-guid"))
+
+			Expect(fakeService.GetStagedProductMaxInFlightCallCount()).To(Equal(1))
+			Expect(fakeService.GetStagedProductMaxInFlightArgsForCall(0)).To(Equal("some-product-guid"))
+
+			Expect(fakeService.ListStagedProductErrandsCallCount()).To(Equal(1))
+			Expect(fakeService.ListStagedProductErrandsArgsForCall(0)).To(Equal("some-product-guid"))
+
 			Expect(logger.PrintlnCallCount()).To(Equal(1))
 			output := logger.PrintlnArgsForCall(0)
 			Expect(output).To(ContainElement(MatchYAML(`---
@@ -117,6 +139,17 @@ resource-config:
     instances: 1
     instance_type:
       id: automatic
+syslog-properties:
+  enabled: true
+  address: example.com
+max-in-flight:
+  some-job: 20%
+errand-config:
+  first-errand:
+    post-deploy-state: when-changed
+  second-errand:
+    post-deploy-state: false
+    pre-delete-state: true
 `)))
 		})
 	})
@@ -171,6 +204,17 @@ resource-config:
     instances: 1
     instance_type:
       id: automatic
+syslog-properties:
+  enabled: true
+  address: example.com
+max-in-flight:
+  some-job: 20%
+errand-config:
+  first-errand:
+    post-deploy-state: when-changed
+  second-errand:
+    post-deploy-state: false
+    pre-delete-state: true
 `)))
 		})
 
@@ -313,6 +357,48 @@ resource-config:
 			})
 		})
 
+		Context("when looking up the syslog configuration fails", func() {
+			BeforeEach(func() {
+				fakeService.GetStagedProductSyslogConfigurationReturns(nil, errors.New("some-error"))
+			})
+
+			It("returns an error", func() {
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+				})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when looking up the max in flight fails", func() {
+			BeforeEach(func() {
+				fakeService.GetStagedProductMaxInFlightReturns(nil, errors.New("some-error"))
+			})
+
+			It("returns an error", func() {
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+				})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when listing errands fails", func() {
+			BeforeEach(func() {
+				fakeService.ListStagedProductErrandsReturns(api.ErrandsListOutput{}, errors.New("some-error"))
+			})
+
+			It("returns an error", func() {
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+				})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
 	})
 
 	Describe("Usage", func() {
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type StagedOpsmanConfig struct {
+	service stagedOpsmanConfigService
+	logger  logger
+}
+
+//go:generate counterfeiter -o ./fakes/staged_opsman_config_service.go --fake-name StagedOpsmanConfigService . stagedOpsmanConfigService
+type stagedOpsmanConfigService interface {
+	GetOpsManagerSetting(setting string) (map[string]interface{}, error)
+}
+
+func NewStagedOpsmanConfig(service stagedOpsmanConfigService, logger logger) StagedOpsmanConfig {
+	return StagedOpsmanConfig{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (soc StagedOpsmanConfig) Execute(args []string) error {
+	config := map[string]interface{}{}
+	for _, setting := range opsmanSettings {
+		if !setting.readable {
+			continue
+		}
+
+		current, err := soc.service.GetOpsManagerSetting(setting.endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve %s settings: %s", setting.key, err)
+		}
+
+		var value interface{} = current
+		if setting.wrapper != "" {
+			value = current[setting.wrapper]
+		}
+
+		if value != nil {
+			config[setting.key] = value
+		}
+	}
+
+	output, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal config: %s", err) // un-tested
+	}
+	soc.logger.Println(string(output))
+
+	return nil
+}
+
+func (soc StagedOpsmanConfig) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command generates a config from the current Ops Manager settings that can be passed in to om configure-opsman. Secrets, such as the SSL private key and SMTP password, and the Pivotal Network token are not returned by Ops Manager and are left out.",
+		ShortDescription: "generates a config from the Ops Manager settings",
+	}
+}
@@ -0,0 +1,82 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StagedOpsmanConfig", func() {
+	var (
+		service *fakes.StagedOpsmanConfigService
+		logger  *fakes.Logger
+		command commands.StagedOpsmanConfig
+	)
+
+	BeforeEach(func() {
+		service = &fakes.StagedOpsmanConfigService{}
+		logger = &fakes.Logger{}
+		command = commands.NewStagedOpsmanConfig(service, logger)
+
+		settings := map[string]map[string]interface{}{
+			"ssl_certificate": {
+				"ssl_certificate": map[string]interface{}{"certificate": "some-certificate"},
+			},
+			"banner": {
+				"ui_banner_contents": "authorized use only",
+			},
+			"smtp":   {},
+			"syslog": {"syslog": map[string]interface{}{"enabled": false}},
+			"rbac":   {"rbac_saml_admin_group": "opsman-admins"},
+		}
+
+		service.GetOpsManagerSettingStub = func(setting string) (map[string]interface{}, error) {
+			return settings[setting], nil
+		}
+	})
+
+	Describe("Execute", func() {
+		It("writes a config file of the readable settings", func() {
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.GetOpsManagerSettingCallCount()).To(Equal(5))
+
+			output := logger.PrintlnArgsForCall(0)
+			Expect(output).To(ContainElement(MatchYAML(`
+banner:
+  ui_banner_contents: authorized use only
+rbac:
+  rbac_saml_admin_group: opsman-admins
+ssl-certificate:
+  certificate: some-certificate
+syslog:
+  enabled: false
+`)))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when a setting cannot be retrieved", func() {
+				service.GetOpsManagerSettingStub = nil
+				service.GetOpsManagerSettingReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve ssl-certificate settings: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command generates a config from the current Ops Manager settings that can be passed in to om configure-opsman. Secrets, such as the SSL private key and SMTP password, and the Pivotal Network token are not returned by Ops Manager and are left out.",
+				ShortDescription: "generates a config from the Ops Manager settings",
+			}))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type StemcellAssignments struct {
+	presenter presenters.Presenter
+	service   stemcellAssignmentsService
+}
+
+//go:generate counterfeiter -o ./fakes/stemcell_assignments_service.go --fake-name StemcellAssignmentsService . stemcellAssignmentsService
+type stemcellAssignmentsService interface {
+	ListStemcellAssignments() (api.StemcellAssignmentsOutput, error)
+}
+
+func NewStemcellAssignments(presenter presenters.Presenter, service stemcellAssignmentsService) StemcellAssignments {
+	return StemcellAssignments{
+		presenter: presenter,
+		service:   service,
+	}
+}
+
+func (sa StemcellAssignments) Execute(args []string) error {
+	output, err := sa.service.ListStemcellAssignments()
+	if err != nil {
+		return fmt.Errorf("failed to list stemcell assignments: %s", err)
+	}
+
+	sa.presenter.PresentStemcellAssignments(output.Products)
+
+	return nil
+}
+
+func (sa StemcellAssignments) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command lists the staged and deployed stemcell of each product, and the uploaded stemcells it can use.",
+		ShortDescription: "lists the stemcells assigned to each product",
+	}
+}
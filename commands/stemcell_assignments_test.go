@@ -0,0 +1,71 @@
+package commands_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+)
+
+var _ = Describe("StemcellAssignments", func() {
+	var (
+		presenter   *presenterfakes.Presenter
+		fakeService *fakes.StemcellAssignmentsService
+		command     commands.StemcellAssignments
+	)
+
+	BeforeEach(func() {
+		presenter = &presenterfakes.Presenter{}
+		fakeService = &fakes.StemcellAssignmentsService{}
+		command = commands.NewStemcellAssignments(presenter, fakeService)
+	})
+
+	It("lists the stemcell assignments of each product", func() {
+		products := []api.ProductStemcells{
+			{
+				GUID:                    "cf-guid",
+				ProductName:             "cf",
+				StagedStemcellVersion:   "97.19",
+				RequiredStemcellVersion: "97.18",
+				RequiredStemcellOS:      "ubuntu-xenial",
+				AvailableVersions:       []string{"97.18", "97.19"},
+			},
+		}
+
+		fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{
+			Products: products,
+		}, nil)
+
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(presenter.PresentStemcellAssignmentsCallCount()).To(Equal(1))
+		Expect(presenter.PresentStemcellAssignmentsArgsForCall(0)).To(Equal(products))
+	})
+
+	Context("failure cases", func() {
+		Context("when the stemcell assignments cannot be listed", func() {
+			It("returns an error", func() {
+				fakeService.ListStemcellAssignmentsReturns(api.StemcellAssignmentsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list stemcell assignments: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command lists the staged and deployed stemcell of each product, and the uploaded stemcells it can use.",
+				ShortDescription: "lists the stemcells assigned to each product",
+			}))
+		})
+	})
+})
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pivotal-cf/om/extractor"
+)
+
+// stemcellFilePattern anchors on the os rather than counting dashes, as the
+// infrastructure part of the name has two or three words, such as google-kvm
+// and aws-xen-hvm.
+var stemcellFilePattern = regexp.MustCompile(`^(?:light-)?bosh-stemcell-(\d[^-]*)-.+?-(ubuntu-[^-]+|windows[^-]*|centos-[^-]+)-go_agent.*\.tgz$`)
+
+// parseStemcellFile returns the os and version of a stemcell from its file
+// name, such as light-bosh-stemcell-97.18-google-kvm-ubuntu-xenial-go_agent.tgz.
+func parseStemcellFile(fileName string) (string, string, bool) {
+	matches := stemcellFilePattern.FindStringSubmatch(path.Base(fileName))
+	if matches == nil {
+		return "", "", false
+	}
+
+	return matches[2], matches[1], true
+}
+
+// suitableStemcells returns the stemcell files that meet the criteria.
+func suitableStemcells(criteria extractor.StemcellCriteria, fileNames []string) []string {
+	var suitable []string
+	for _, fileName := range fileNames {
+		stemcellOS, version, ok := parseStemcellFile(fileName)
+		if !ok || stemcellOS != criteria.OS {
+			continue
+		}
+
+		if criteria.EnablePatchSecurityUpdates {
+			if !stemcellSatisfies(criteria.Version, version) {
+				continue
+			}
+		} else if compareVersions(version, criteria.Version) != 0 {
+			continue
+		}
+
+		suitable = append(suitable, fileName)
+	}
+
+	return suitable
+}
+
+func hasStemcellCriteria(criteria extractor.StemcellCriteria) bool {
+	return criteria.OS != "" || criteria.Version != ""
+}
+
+func describeStemcellCriteria(criteria extractor.StemcellCriteria) string {
+	if criteria.EnablePatchSecurityUpdates {
+		major := strings.SplitN(criteria.Version, ".", 2)[0]
+		return fmt.Sprintf("%s %s or a later %s.x", criteria.OS, criteria.Version, major)
+	}
+
+	return fmt.Sprintf("%s %s", criteria.OS, criteria.Version)
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+type StemcellRequirements struct {
+	metadataExtractor metadataExtractor
+	service           stemcellRequirementsService
+	logger            logger
+	Options           struct {
+		Product string `long:"product" short:"p" required:"true" description:"path or http(s) url of product"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/stemcell_requirements_service.go --fake-name StemcellRequirementsService . stemcellRequirementsService
+type stemcellRequirementsService interface {
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+}
+
+func NewStemcellRequirements(metadataExtractor metadataExtractor, service stemcellRequirementsService, logger logger) StemcellRequirements {
+	return StemcellRequirements{
+		metadataExtractor: metadataExtractor,
+		service:           service,
+		logger:            logger,
+	}
+}
+
+func (sr StemcellRequirements) Execute(args []string) error {
+	if _, err := jhanda.Parse(&sr.Options, args); err != nil {
+		return fmt.Errorf("could not parse stemcell-requirements flags: %s", err)
+	}
+
+	metadata, err := sr.metadataExtractor.ExtractMetadata(sr.Options.Product)
+	if err != nil {
+		return fmt.Errorf("failed to extract product metadata: %s", err)
+	}
+
+	criteria := metadata.StemcellCriteria
+	if !hasStemcellCriteria(criteria) {
+		sr.logger.Printf("%s %s does not declare stemcell criteria", metadata.Name, metadata.Version)
+		return nil
+	}
+
+	sr.logger.Printf("product: %s %s", metadata.Name, metadata.Version)
+	sr.logger.Printf("stemcell: %s", describeStemcellCriteria(criteria))
+	sr.logger.Printf("requires cpi: %t", criteria.RequiresCPI)
+
+	report, err := sr.service.GetDiagnosticReport()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve uploaded stemcells: %s", err)
+	}
+
+	suitable := suitableStemcells(criteria, report.Stemcells)
+	if len(suitable) == 0 {
+		return fmt.Errorf("no uploaded stemcell meets the stemcell criteria of %s %s, upload %s with upload-stemcell", metadata.Name, metadata.Version, describeStemcellCriteria(criteria))
+	}
+
+	sr.logger.Printf("suitable stemcells already uploaded:")
+	for _, stemcell := range suitable {
+		sr.logger.Printf("  %s", stemcell)
+	}
+
+	return nil
+}
+
+func (sr StemcellRequirements) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command reads the stemcell criteria from the metadata of a product file, and checks whether a stemcell that meets them has been uploaded to the Ops Manager.",
+		ShortDescription: "checks that a stemcell suitable for a product has been uploaded",
+		Flags:            sr.Options,
+	}
+}
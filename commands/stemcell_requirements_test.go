@@ -0,0 +1,199 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+)
+
+var _ = Describe("StemcellRequirements", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		fakeService       *fakes.StemcellRequirementsService
+		logger            *fakes.Logger
+		command           commands.StemcellRequirements
+	)
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		fakeService = &fakes.StemcellRequirementsService{}
+		logger = &fakes.Logger{}
+		command = commands.NewStemcellRequirements(metadataExtractor, fakeService, logger)
+
+		metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+			Name:    "cf",
+			Version: "2.2.1",
+			StemcellCriteria: extractor.StemcellCriteria{
+				OS:                         "ubuntu-xenial",
+				Version:                    "97.18",
+				EnablePatchSecurityUpdates: true,
+			},
+		}, nil)
+
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			Stemcells: []string{
+				"light-bosh-stemcell-3586.40-google-kvm-ubuntu-trusty-go_agent.tgz",
+				"light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz",
+				"light-bosh-stemcell-97.28-google-kvm-ubuntu-xenial-go_agent.tgz",
+				"bosh-stemcell-170.1-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+				"some-unrecognised-file.tgz",
+			},
+		}, nil)
+	})
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	It("reports the stemcell criteria and the suitable uploaded stemcells", func() {
+		err := command.Execute([]string{"--product", "/path/to/cf.pivotal"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(metadataExtractor.ExtractMetadataArgsForCall(0)).To(Equal("/path/to/cf.pivotal"))
+		Expect(loggedLines()).To(Equal([]string{
+			"product: cf 2.2.1",
+			"stemcell: ubuntu-xenial 97.18 or a later 97.x",
+			"requires cpi: false",
+			"suitable stemcells already uploaded:",
+			"  light-bosh-stemcell-97.28-google-kvm-ubuntu-xenial-go_agent.tgz",
+		}))
+	})
+
+	Context("when patch security updates are not enabled", func() {
+		It("only accepts the exact stemcell version", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "cf",
+				Version: "2.2.1",
+				StemcellCriteria: extractor.StemcellCriteria{
+					OS:      "ubuntu-xenial",
+					Version: "97.28",
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/cf.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(loggedLines()).To(ContainElement("stemcell: ubuntu-xenial 97.28"))
+			Expect(loggedLines()).To(ContainElement("  light-bosh-stemcell-97.28-google-kvm-ubuntu-xenial-go_agent.tgz"))
+		})
+	})
+
+	Context("when the stemcells are for infrastructures with longer names", func() {
+		It("recognises the os and version of each stemcell", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{
+					"bosh-stemcell-97.28-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+					"light-bosh-stemcell-97.30-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+					"bosh-stemcell-97.28-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+					"bosh-stemcell-97.28-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+					"bosh-stemcell-1803.5-aws-xen-hvm-windows1803-go_agent.tgz",
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/cf.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(loggedLines()).To(Equal([]string{
+				"product: cf 2.2.1",
+				"stemcell: ubuntu-xenial 97.18 or a later 97.x",
+				"requires cpi: false",
+				"suitable stemcells already uploaded:",
+				"  bosh-stemcell-97.28-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+				"  light-bosh-stemcell-97.30-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+				"  bosh-stemcell-97.28-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+			}))
+		})
+
+		It("recognises windows stemcells", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "pas-windows",
+				Version: "2.2.1",
+				StemcellCriteria: extractor.StemcellCriteria{
+					OS:      "windows1803",
+					Version: "1803.5",
+				},
+			}, nil)
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{"bosh-stemcell-1803.5-aws-xen-hvm-windows1803-go_agent.tgz"},
+			}, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/pas-windows.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(loggedLines()).To(ContainElement("  bosh-stemcell-1803.5-aws-xen-hvm-windows1803-go_agent.tgz"))
+		})
+	})
+
+	Context("when the product does not declare stemcell criteria", func() {
+		It("says so without checking the uploaded stemcells", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{Name: "p-bosh-backup", Version: "1.0.0"}, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/p-bosh-backup.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(loggedLines()).To(Equal([]string{"p-bosh-backup 1.0.0 does not declare stemcell criteria"}))
+			Expect(fakeService.GetDiagnosticReportCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse stemcell-requirements flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the metadata cannot be extracted", func() {
+			It("returns an error", func() {
+				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product", "/path/to/cf.pivotal"})
+				Expect(err).To(MatchError("failed to extract product metadata: some error"))
+			})
+		})
+
+		Context("when the uploaded stemcells cannot be listed", func() {
+			It("returns an error", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product", "/path/to/cf.pivotal"})
+				Expect(err).To(MatchError("failed to retrieve uploaded stemcells: some error"))
+			})
+		})
+
+		Context("when no suitable stemcell has been uploaded", func() {
+			It("returns an error", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+					Stemcells: []string{"light-bosh-stemcell-97.9-google-kvm-ubuntu-xenial-go_agent.tgz"},
+				}, nil)
+
+				err := command.Execute([]string{"--product", "/path/to/cf.pivotal"})
+				Expect(err).To(MatchError("no uploaded stemcell meets the stemcell criteria of cf 2.2.1, upload ubuntu-xenial 97.18 or a later 97.x with upload-stemcell"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command reads the stemcell criteria from the metadata of a product file, and checks whether a stemcell that meets them has been uploaded to the Ops Manager.",
+				ShortDescription: "checks that a stemcell suitable for a product has been uploaded",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type UAAClients struct {
+	presenter presenters.Presenter
+	service   uaaClientsService
+}
+
+//go:generate counterfeiter -o ./fakes/uaa_clients_service.go --fake-name UAAClientsService . uaaClientsService
+type uaaClientsService interface {
+	ListUAAClients() ([]api.UAAClient, error)
+}
+
+func NewUAAClients(presenter presenters.Presenter, service uaaClientsService) UAAClients {
+	return UAAClients{
+		presenter: presenter,
+		service:   service,
+	}
+}
+
+func (uc UAAClients) Execute(args []string) error {
+	clients, err := uc.service.ListUAAClients()
+	if err != nil {
+		return fmt.Errorf("failed to list uaa clients: %s", err)
+	}
+
+	uc.presenter.PresentUAAClients(clients)
+
+	return nil
+}
+
+func (uc UAAClients) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command lists the clients of the Ops Manager uaa, with their grant types, scopes and authorities.",
+		ShortDescription: "lists uaa clients",
+	}
+}
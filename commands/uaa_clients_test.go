@@ -0,0 +1,62 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UAAClients", func() {
+	var (
+		presenter *presenterfakes.Presenter
+		service   *fakes.UAAClientsService
+		command   commands.UAAClients
+	)
+
+	BeforeEach(func() {
+		presenter = &presenterfakes.Presenter{}
+		service = &fakes.UAAClientsService{}
+		command = commands.NewUAAClients(presenter, service)
+	})
+
+	Describe("Execute", func() {
+		It("presents the uaa clients", func() {
+			clients := []api.UAAClient{
+				{ClientID: "opsman", AuthorizedGrantTypes: []string{"password"}},
+				{ClientID: "pipeline", Authorities: []string{"opsman.admin"}},
+			}
+			service.ListUAAClientsReturns(clients, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(presenter.PresentUAAClientsCallCount()).To(Equal(1))
+			Expect(presenter.PresentUAAClientsArgsForCall(0)).To(Equal(clients))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the clients cannot be listed", func() {
+				service.ListUAAClientsReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list uaa clients: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command lists the clients of the Ops Manager uaa, with their grant types, scopes and authorities.",
+				ShortDescription: "lists uaa clients",
+			}))
+		})
+	})
+})
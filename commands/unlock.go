@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+//go:generate counterfeiter -o ./fakes/unlock_service.go --fake-name UnlockService . unlockService
+type unlockService interface {
+	Unlock(api.UnlockInput) error
+	EnsureAvailability(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
+}
+
+type Unlock struct {
+	service      unlockService
+	logger       logger
+	waitDuration int
+	Options      struct {
+		DecryptionPassphrase string `long:"decryption-passphrase" short:"dp" required:"true" description:"passphrase used to encrypt the installation"`
+		Timeout              int    `long:"timeout"                          default:"600"   description:"seconds to wait for the authentication system to start"`
+	}
+}
+
+func NewUnlock(service unlockService, logger logger, waitDuration int) Unlock {
+	return Unlock{
+		service:      service,
+		logger:       logger,
+		waitDuration: waitDuration,
+	}
+}
+
+func (u Unlock) Execute(args []string) error {
+	if _, err := jhanda.Parse(&u.Options, args); err != nil {
+		return fmt.Errorf("could not parse unlock flags: %s", err)
+	}
+
+	ensureAvailabilityOutput, err := u.service.EnsureAvailability(api.EnsureAvailabilityInput{})
+	if err != nil {
+		return fmt.Errorf("could not determine initial configuration status: %s", err)
+	}
+
+	switch ensureAvailabilityOutput.Status {
+	case api.EnsureAvailabilityStatusUnknown:
+		return errors.New("could not determine initial configuration status: received unexpected status")
+	case api.EnsureAvailabilityStatusUnstarted:
+		return errors.New("Ops Manager has not been set up yet, run configure-authentication first")
+	case api.EnsureAvailabilityStatusComplete:
+		u.logger.Printf("Ops Manager is already unlocked")
+		return nil
+	case api.EnsureAvailabilityStatusLocked:
+		u.logger.Printf("unlocking Ops Manager...")
+		err = u.service.Unlock(api.UnlockInput{DecryptionPassphrase: u.Options.DecryptionPassphrase})
+		if err != nil {
+			return err
+		}
+	}
+
+	u.logger.Printf("waiting for authentication system to start...")
+	deadline := time.Now().Add(time.Duration(u.Options.Timeout) * time.Second)
+	for ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusComplete {
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("authentication system did not start within %d seconds", u.Options.Timeout)
+		}
+
+		time.Sleep(time.Duration(u.waitDuration) * time.Second)
+
+		ensureAvailabilityOutput, err = u.service.EnsureAvailability(api.EnsureAvailabilityInput{})
+		if err != nil {
+			return fmt.Errorf("could not determine final configuration status: %s", err)
+		}
+	}
+
+	u.logger.Printf("Ops Manager is unlocked")
+
+	return nil
+}
+
+func (u Unlock) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This unauthenticated command unlocks an Ops Manager that has been restarted with the decryption passphrase, and waits for its authentication system to start.",
+		ShortDescription: "unlocks Ops Manager after a restart",
+		Flags:            u.Options,
+	}
+}
@@ -0,0 +1,156 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unlock", func() {
+	var (
+		service *fakes.UnlockService
+		logger  *fakes.Logger
+		command commands.Unlock
+	)
+
+	BeforeEach(func() {
+		service = &fakes.UnlockService{}
+		logger = &fakes.Logger{}
+		command = commands.NewUnlock(service, logger, 0)
+	})
+
+	ensureAvailabilityReturns := func(statuses ...string) {
+		service.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+			return api.EnsureAvailabilityOutput{Status: statuses[service.EnsureAvailabilityCallCount()-1]}, nil
+		}
+	}
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	Describe("Execute", func() {
+		It("unlocks Ops Manager and waits for authentication to be available", func() {
+			ensureAvailabilityReturns(
+				api.EnsureAvailabilityStatusLocked,
+				api.EnsureAvailabilityStatusPending,
+				api.EnsureAvailabilityStatusPending,
+				api.EnsureAvailabilityStatusComplete,
+			)
+
+			err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.UnlockCallCount()).To(Equal(1))
+			Expect(service.UnlockArgsForCall(0)).To(Equal(api.UnlockInput{DecryptionPassphrase: "some-passphrase"}))
+			Expect(service.EnsureAvailabilityCallCount()).To(Equal(4))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"unlocking Ops Manager...",
+				"waiting for authentication system to start...",
+				"Ops Manager is unlocked",
+			}))
+		})
+
+		It("waits without unlocking when the authentication system is already starting", func() {
+			ensureAvailabilityReturns(
+				api.EnsureAvailabilityStatusPending,
+				api.EnsureAvailabilityStatusComplete,
+			)
+
+			err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.UnlockCallCount()).To(Equal(0))
+			Expect(service.EnsureAvailabilityCallCount()).To(Equal(2))
+		})
+
+		It("does nothing when Ops Manager is already unlocked", func() {
+			ensureAvailabilityReturns(api.EnsureAvailabilityStatusComplete)
+
+			err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.UnlockCallCount()).To(Equal(0))
+			Expect(loggedLines()).To(Equal([]string{"Ops Manager is already unlocked"}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the decryption passphrase is missing", func() {
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("could not parse unlock flags: missing required flag \"--decryption-passphrase\""))
+			})
+
+			It("returns an error when the initial status cannot be determined", func() {
+				service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+				Expect(err).To(MatchError("could not determine initial configuration status: some error"))
+			})
+
+			It("returns an error when the initial status is unknown", func() {
+				ensureAvailabilityReturns(api.EnsureAvailabilityStatusUnknown)
+
+				err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+				Expect(err).To(MatchError("could not determine initial configuration status: received unexpected status"))
+			})
+
+			It("returns an error when Ops Manager has not been set up", func() {
+				ensureAvailabilityReturns(api.EnsureAvailabilityStatusUnstarted)
+
+				err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+				Expect(err).To(MatchError("Ops Manager has not been set up yet, run configure-authentication first"))
+			})
+
+			It("returns an error when unlocking fails", func() {
+				ensureAvailabilityReturns(api.EnsureAvailabilityStatusLocked)
+				service.UnlockReturns(errors.New("could not unlock Ops Manager: the decryption passphrase is incorrect"))
+
+				err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+				Expect(err).To(MatchError("could not unlock Ops Manager: the decryption passphrase is incorrect"))
+			})
+
+			It("returns an error when the final status cannot be determined", func() {
+				service.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+					if service.EnsureAvailabilityCallCount() == 1 {
+						return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusLocked}, nil
+					}
+					return api.EnsureAvailabilityOutput{}, errors.New("some error")
+				}
+
+				err := command.Execute([]string{"--decryption-passphrase", "some-passphrase"})
+				Expect(err).To(MatchError("could not determine final configuration status: some error"))
+			})
+
+			It("returns an error when the authentication system does not start before the timeout", func() {
+				service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusPending}, nil)
+
+				err := command.Execute([]string{"--decryption-passphrase", "some-passphrase", "--timeout", "0"})
+				Expect(err).To(MatchError("authentication system did not start within 0 seconds"))
+				Expect(service.EnsureAvailabilityCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This unauthenticated command unlocks an Ops Manager that has been restarted with the decryption passphrase, and waits for its authentication system to start.",
+				ShortDescription: "unlocks Ops Manager after a restart",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
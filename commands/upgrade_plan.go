@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type UpgradePlan struct {
+	metadataExtractor metadataExtractor
+	service           upgradePlanService
+	presenter         presenters.Presenter
+	Options           struct {
+		Products []string `long:"product" short:"p" description:"path or http(s) url of a product file to consider, which also allows its dependencies and stemcell to be checked (can be given more than once)"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/upgrade_plan_service.go --fake-name UpgradePlanService . upgradePlanService
+type upgradePlanService interface {
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+	ListAvailableProducts() (api.AvailableProductsOutput, error)
+}
+
+// upgradeCandidate is a newer version of a deployed product. Its metadata is
+// only known when it was given as a product file.
+type upgradeCandidate struct {
+	version  string
+	metadata *extractor.Metadata
+	uploaded bool
+}
+
+func NewUpgradePlan(metadataExtractor metadataExtractor, service upgradePlanService, presenter presenters.Presenter) UpgradePlan {
+	return UpgradePlan{
+		metadataExtractor: metadataExtractor,
+		service:           service,
+		presenter:         presenter,
+	}
+}
+
+func (up UpgradePlan) Execute(args []string) error {
+	if _, err := jhanda.Parse(&up.Options, args); err != nil {
+		return fmt.Errorf("could not parse upgrade-plan flags: %s", err)
+	}
+
+	report, err := up.service.GetDiagnosticReport()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve deployed products: %s", err)
+	}
+
+	available, err := up.service.ListAvailableProducts()
+	if err != nil {
+		return fmt.Errorf("failed to list available products: %s", err)
+	}
+
+	current := map[string]string{}
+	for _, product := range report.DeployedProducts {
+		current[product.Name] = product.Version
+	}
+
+	candidates := map[string][]upgradeCandidate{}
+	addCandidate := func(name string, candidate upgradeCandidate) {
+		currentVersion, deployed := current[name]
+		if !deployed || compareVersions(candidate.version, currentVersion) <= 0 {
+			return
+		}
+
+		for i, existing := range candidates[name] {
+			if existing.version == candidate.version {
+				candidates[name][i].uploaded = existing.uploaded || candidate.uploaded
+				if candidate.metadata != nil {
+					candidates[name][i].metadata = candidate.metadata
+				}
+				return
+			}
+		}
+
+		candidates[name] = append(candidates[name], candidate)
+	}
+
+	for _, product := range available.ProductsList {
+		addCandidate(product.Name, upgradeCandidate{version: product.Version, uploaded: true})
+	}
+
+	for _, productFile := range up.Options.Products {
+		metadata, err := up.metadataExtractor.ExtractMetadata(productFile)
+		if err != nil {
+			return fmt.Errorf("failed to extract product metadata from %s: %s", productFile, err)
+		}
+
+		addCandidate(metadata.Name, upgradeCandidate{version: metadata.Version, metadata: &metadata})
+	}
+
+	var names []string
+	for name := range candidates {
+		sort.Slice(candidates[name], func(i, j int) bool {
+			return compareVersions(candidates[name][i].version, candidates[name][j].version) > 0
+		})
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Start from the newest version of every product, and step a product
+	// back a version whenever its requirements are not met by the versions
+	// planned for the others, until nothing changes.
+	choice := map[string]int{}
+	for changed := true; changed; {
+		changed = false
+		planned := plannedProductVersions(current, candidates, choice)
+
+		for _, name := range names {
+			if choice[name] >= len(candidates[name]) {
+				continue
+			}
+
+			candidate := candidates[name][choice[name]]
+			if candidate.metadata == nil {
+				continue
+			}
+
+			missing, err := unmetProductDependencies(*candidate.metadata, planned)
+			if err != nil {
+				return err
+			}
+
+			if len(missing) > 0 {
+				choice[name]++
+				changed = true
+			}
+		}
+	}
+
+	planned := plannedProductVersions(current, candidates, choice)
+
+	var steps []models.UpgradeStep
+	var blocked []models.UpgradeStep
+	for _, name := range names {
+		if choice[name] >= len(candidates[name]) {
+			newest := candidates[name][0]
+
+			step := models.UpgradeStep{
+				Product:        name,
+				CurrentVersion: current[name],
+				TargetVersion:  newest.version,
+				Requires:       requiredProducts(newest.metadata),
+				Blocked:        true,
+			}
+
+			missing, _ := unmetProductDependencies(*newest.metadata, planned)
+			for _, requirement := range missing {
+				step.Notes = append(step.Notes, fmt.Sprintf("requires %s", requirement))
+			}
+
+			blocked = append(blocked, step)
+			continue
+		}
+
+		candidate := candidates[name][choice[name]]
+		step := models.UpgradeStep{
+			Product:        name,
+			CurrentVersion: current[name],
+			TargetVersion:  candidate.version,
+			Requires:       requiredProducts(candidate.metadata),
+		}
+
+		if !candidate.uploaded {
+			step.Notes = append(step.Notes, "upload the product first")
+		}
+
+		if candidate.metadata == nil {
+			step.Notes = append(step.Notes, "dependencies and stemcell unknown, pass the product file with --product")
+		} else if hasStemcellCriteria(candidate.metadata.StemcellCriteria) {
+			step.Stemcell = describeStemcellCriteria(candidate.metadata.StemcellCriteria)
+			if len(suitableStemcells(candidate.metadata.StemcellCriteria, report.Stemcells)) == 0 {
+				step.Notes = append(step.Notes, "no uploaded stemcell meets its stemcell criteria")
+			}
+		}
+
+		steps = append(steps, step)
+	}
+
+	steps = orderUpgrades(steps, candidates, choice)
+	for i := range steps {
+		steps[i].Order = i + 1
+	}
+
+	up.presenter.PresentUpgradePlan(append(steps, blocked...))
+
+	return nil
+}
+
+func (up UpgradePlan) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command works out which deployed products can be upgraded to the available products or given product files, to which versions, in which order, and which stemcells they need.",
+		ShortDescription: "plans the upgrade of deployed products",
+		Flags:            up.Options,
+	}
+}
+
+// plannedProductVersions returns the version of every deployed product after
+// the chosen upgrades, along with the products the upgrades provide.
+func plannedProductVersions(current map[string]string, candidates map[string][]upgradeCandidate, choice map[string]int) []extractor.ProductVersion {
+	var products []extractor.ProductVersion
+	for name, version := range current {
+		if choice[name] < len(candidates[name]) {
+			candidate := candidates[name][choice[name]]
+			version = candidate.version
+
+			if candidate.metadata != nil {
+				products = append(products, candidate.metadata.ProvidesProductVersions...)
+			}
+		}
+
+		products = append(products, extractor.ProductVersion{Name: name, Version: version})
+	}
+
+	return products
+}
+
+// orderUpgrades puts each upgrade after the upgrades of the products it
+// requires. Products that depend on each other keep their alphabetical order.
+func orderUpgrades(steps []models.UpgradeStep, candidates map[string][]upgradeCandidate, choice map[string]int) []models.UpgradeStep {
+	upgrading := map[string]bool{}
+	for _, step := range steps {
+		upgrading[step.Product] = true
+	}
+
+	dependsOn := func(step models.UpgradeStep) []string {
+		var names []string
+		metadata := candidates[step.Product][choice[step.Product]].metadata
+		if metadata == nil {
+			return nil
+		}
+
+		for _, required := range metadata.RequiresProductVersions {
+			if upgrading[required.Name] && required.Name != step.Product {
+				names = append(names, required.Name)
+			}
+		}
+
+		return names
+	}
+
+	var ordered []models.UpgradeStep
+	done := map[string]bool{}
+	for len(ordered) < len(steps) {
+		progressed := false
+		for _, step := range steps {
+			if done[step.Product] {
+				continue
+			}
+
+			ready := true
+			for _, name := range dependsOn(step) {
+				if !done[name] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				ordered = append(ordered, step)
+				done[step.Product] = true
+				progressed = true
+			}
+		}
+
+		if !progressed {
+			for _, step := range steps {
+				if !done[step.Product] {
+					ordered = append(ordered, step)
+					done[step.Product] = true
+				}
+			}
+		}
+	}
+
+	return ordered
+}
+
+func requiredProducts(metadata *extractor.Metadata) []string {
+	if metadata == nil {
+		return nil
+	}
+
+	var required []string
+	for _, product := range metadata.RequiresProductVersions {
+		required = append(required, fmt.Sprintf("%s %s", product.Name, product.Version))
+	}
+
+	return required
+}
@@ -0,0 +1,263 @@
+package commands_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/models"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+)
+
+var _ = Describe("UpgradePlan", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		fakeService       *fakes.UpgradePlanService
+		presenter         *presenterfakes.Presenter
+		command           commands.UpgradePlan
+		metadata          map[string]extractor.Metadata
+	)
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		fakeService = &fakes.UpgradePlanService{}
+		presenter = &presenterfakes.Presenter{}
+		command = commands.NewUpgradePlan(metadataExtractor, fakeService, presenter)
+
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			Stemcells: []string{"light-bosh-stemcell-97.28-google-kvm-ubuntu-xenial-go_agent.tgz"},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.2-build.296"},
+				{Name: "cf", Version: "2.1.7"},
+				{Name: "p-redis", Version: "1.13.0"},
+				{Name: "p-mysql", Version: "2.3.1"},
+			},
+		}, nil)
+
+		fakeService.ListAvailableProductsReturns(api.AvailableProductsOutput{
+			ProductsList: []api.ProductInfo{
+				{Name: "cf", Version: "2.1.7"},
+				{Name: "cf", Version: "2.2.4"},
+				{Name: "cf", Version: "2.2.10"},
+				{Name: "p-redis", Version: "1.14.2"},
+				{Name: "p-redis", Version: "1.13.0"},
+				{Name: "p-isolation-segment", Version: "2.2.4"},
+			},
+		}, nil)
+
+		metadata = map[string]extractor.Metadata{
+			"cf-2.2.10.pivotal": {
+				Name:             "cf",
+				Version:          "2.2.10",
+				StemcellCriteria: extractor.StemcellCriteria{OS: "ubuntu-xenial", Version: "97.18", EnablePatchSecurityUpdates: true},
+			},
+			"p-redis-1.15.0.pivotal": {
+				Name:    "p-redis",
+				Version: "1.15.0",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "cf", Version: "~> 2.3"},
+				},
+			},
+			"p-redis-1.14.2.pivotal": {
+				Name:    "p-redis",
+				Version: "1.14.2",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "cf", Version: "~> 2.2"},
+				},
+				StemcellCriteria: extractor.StemcellCriteria{OS: "ubuntu-xenial", Version: "170.1", EnablePatchSecurityUpdates: true},
+			},
+			"p-mysql-2.4.0.pivotal": {
+				Name:    "p-mysql",
+				Version: "2.4.0",
+				RequiresProductVersions: []extractor.ProductVersion{
+					{Name: "p-bosh", Version: "~> 2.3"},
+				},
+			},
+		}
+		metadataExtractor.ExtractMetadataStub = func(path string) (extractor.Metadata, error) {
+			return metadata[path], nil
+		}
+	})
+
+	It("plans the upgrade of the deployed products to the newest available versions", func() {
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(presenter.PresentUpgradePlanCallCount()).To(Equal(1))
+		Expect(presenter.PresentUpgradePlanArgsForCall(0)).To(Equal([]models.UpgradeStep{
+			{
+				Order:          1,
+				Product:        "cf",
+				CurrentVersion: "2.1.7",
+				TargetVersion:  "2.2.10",
+				Notes:          []string{"dependencies and stemcell unknown, pass the product file with --product"},
+			},
+			{
+				Order:          2,
+				Product:        "p-redis",
+				CurrentVersion: "1.13.0",
+				TargetVersion:  "1.14.2",
+				Notes:          []string{"dependencies and stemcell unknown, pass the product file with --product"},
+			},
+		}))
+	})
+
+	It("uses the product files to order the upgrades, check their stemcells and skip incompatible versions", func() {
+		err := command.Execute([]string{
+			"--product", "p-redis-1.15.0.pivotal",
+			"--product", "p-redis-1.14.2.pivotal",
+			"--product", "cf-2.2.10.pivotal",
+			"--product", "p-mysql-2.4.0.pivotal",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(presenter.PresentUpgradePlanArgsForCall(0)).To(Equal([]models.UpgradeStep{
+			{
+				Order:          1,
+				Product:        "cf",
+				CurrentVersion: "2.1.7",
+				TargetVersion:  "2.2.10",
+				Stemcell:       "ubuntu-xenial 97.18 or a later 97.x",
+			},
+			{
+				Order:          2,
+				Product:        "p-redis",
+				CurrentVersion: "1.13.0",
+				TargetVersion:  "1.14.2",
+				Stemcell:       "ubuntu-xenial 170.1 or a later 170.x",
+				Requires:       []string{"cf ~> 2.2"},
+				Notes:          []string{"no uploaded stemcell meets its stemcell criteria"},
+			},
+			{
+				Product:        "p-mysql",
+				CurrentVersion: "2.3.1",
+				TargetVersion:  "2.4.0",
+				Requires:       []string{"p-bosh ~> 2.3"},
+				Blocked:        true,
+				Notes:          []string{"requires p-bosh ~> 2.3 (found 2.2-build.296)"},
+			},
+		}))
+	})
+
+	It("checks stemcells of infrastructures with longer names", func() {
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			Stemcells: []string{
+				"bosh-stemcell-97.28-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+				"bosh-stemcell-170.2-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.2-build.296"},
+				{Name: "cf", Version: "2.1.7"},
+				{Name: "p-redis", Version: "1.13.0"},
+			},
+		}, nil)
+
+		err := command.Execute([]string{
+			"--product", "p-redis-1.14.2.pivotal",
+			"--product", "cf-2.2.10.pivotal",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		steps := presenter.PresentUpgradePlanArgsForCall(0)
+		Expect(steps).To(HaveLen(2))
+		for _, step := range steps {
+			Expect(step.Notes).To(BeEmpty())
+		}
+	})
+
+	It("orders each upgrade after the upgrades of the products it requires", func() {
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "apm", Version: "1.5.0"},
+				{Name: "cf", Version: "2.1.7"},
+				{Name: "p-redis", Version: "1.13.0"},
+			},
+		}, nil)
+		metadata["apm-1.6.0.pivotal"] = extractor.Metadata{
+			Name:                    "apm",
+			Version:                 "1.6.0",
+			RequiresProductVersions: []extractor.ProductVersion{{Name: "p-redis", Version: ">= 1.14"}},
+		}
+
+		err := command.Execute([]string{
+			"--product", "apm-1.6.0.pivotal",
+			"--product", "p-redis-1.14.2.pivotal",
+			"--product", "cf-2.2.10.pivotal",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var order []string
+		for _, step := range presenter.PresentUpgradePlanArgsForCall(0) {
+			order = append(order, step.Product)
+		}
+		Expect(order).To(Equal([]string{"cf", "p-redis", "apm"}))
+	})
+
+	It("notes when a product file has not been uploaded", func() {
+		metadata["p-redis-1.15.0.pivotal"] = extractor.Metadata{Name: "p-redis", Version: "1.15.0"}
+
+		err := command.Execute([]string{"--product", "p-redis-1.15.0.pivotal"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(presenter.PresentUpgradePlanArgsForCall(0)).To(ContainElement(models.UpgradeStep{
+			Order:          2,
+			Product:        "p-redis",
+			CurrentVersion: "1.13.0",
+			TargetVersion:  "1.15.0",
+			Notes:          []string{"upload the product first"},
+		}))
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse upgrade-plan flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the diagnostic report cannot be fetched", func() {
+			It("returns an error", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve deployed products: some error"))
+			})
+		})
+
+		Context("when the available products cannot be listed", func() {
+			It("returns an error", func() {
+				fakeService.ListAvailableProductsReturns(api.AvailableProductsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list available products: some error"))
+			})
+		})
+
+		Context("when the metadata of a product file cannot be extracted", func() {
+			It("returns an error", func() {
+				metadataExtractor.ExtractMetadataStub = nil
+				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product", "cf-2.2.10.pivotal"})
+				Expect(err).To(MatchError("failed to extract product metadata from cf-2.2.10.pivotal: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command works out which deployed products can be upgraded to the available products or given product files, to which versions, in which order, and which stemcells they need.",
+				ShortDescription: "plans the upgrade of deployed products",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
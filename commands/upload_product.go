@@ -1,20 +1,30 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/network"
 )
 
 type UploadProduct struct {
-	multipart multipart
-	logger    logger
-	service   uploadProductService
-	Options   struct {
-		Product         string `long:"product"          short:"p"  required:"true" description:"path to product"`
+	multipart        multipart
+	logger           logger
+	service          uploadProductService
+	retryWaitSeconds int
+	Options          struct {
+		Product         string `long:"product"          short:"p"  required:"true" description:"path or http(s) url of product"`
 		PollingInterval int    `long:"polling-interval" short:"pi"                 description:"interval (in seconds) at which to print status" default:"1"`
+		SHA256          string `long:"sha256"                                      description:"expected sha256 of the product file, checked before uploading"`
+		Retries         int    `long:"retries"                                     description:"number of times to retry the upload after a temporary failure" default:"3"`
 	}
 	metadataExtractor metadataExtractor
 }
@@ -23,6 +33,7 @@ type UploadProduct struct {
 type uploadProductService interface {
 	UploadAvailableProduct(api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error)
 	CheckProductAvailability(string, string) (bool, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
 //go:generate counterfeiter -o ./fakes/metadata_extractor.go --fake-name MetadataExtractor . metadataExtractor
@@ -30,12 +41,13 @@ type metadataExtractor interface {
 	ExtractMetadata(string) (extractor.Metadata, error)
 }
 
-func NewUploadProduct(multipart multipart, metadataExtractor metadataExtractor, service uploadProductService, logger logger) UploadProduct {
+func NewUploadProduct(multipart multipart, metadataExtractor metadataExtractor, service uploadProductService, logger logger, retryWaitSeconds int) UploadProduct {
 	return UploadProduct{
 		multipart:         multipart,
 		metadataExtractor: metadataExtractor,
 		logger:            logger,
 		service:           service,
+		retryWaitSeconds:  retryWaitSeconds,
 	}
 }
 
@@ -52,6 +64,23 @@ func (up UploadProduct) Execute(args []string) error {
 		return fmt.Errorf("could not parse upload-product flags: %s", err)
 	}
 
+	if up.Options.SHA256 != "" {
+		if network.IsURL(up.Options.Product) {
+			return fmt.Errorf("--sha256 cannot be used when --product is a url")
+		}
+
+		sum, err := fileSHA256(up.Options.Product)
+		if err != nil {
+			return fmt.Errorf("failed to compute sha256 of product: %s", err)
+		}
+
+		if !strings.EqualFold(sum, up.Options.SHA256) {
+			return fmt.Errorf("sha256 of %s is %s, expected %s", up.Options.Product, sum, up.Options.SHA256)
+		}
+
+		up.logger.Printf("verified sha256 of product")
+	}
+
 	metadata, err := up.metadataExtractor.ExtractMetadata(up.Options.Product)
 	if err != nil {
 		return fmt.Errorf("failed to extract product metadata: %s", err)
@@ -64,6 +93,7 @@ func (up UploadProduct) Execute(args []string) error {
 
 	if prodAvailable {
 		up.logger.Printf("product %s %s is already uploaded, nothing to be done.", metadata.Name, metadata.Version)
+		up.warnIfNoSuitableStemcell(metadata)
 		return nil
 	}
 
@@ -73,24 +103,69 @@ func (up UploadProduct) Execute(args []string) error {
 		return fmt.Errorf("failed to load product: %s", err)
 	}
 
-	submission, err := up.multipart.Finalize()
+	up.logger.Printf("beginning product upload to Ops Manager")
+
+	for attempt := 0; ; attempt++ {
+		submission, err := up.multipart.Finalize()
+		if err != nil {
+			return fmt.Errorf("failed to create multipart form: %s", err)
+		}
+
+		_, err = up.service.UploadAvailableProduct(api.UploadAvailableProductInput{
+			ContentLength:   submission.Length,
+			Product:         submission.Content,
+			ContentType:     submission.ContentType,
+			PollingInterval: up.Options.PollingInterval,
+		})
+		if err == nil {
+			break
+		}
+
+		if !api.IsTemporary(err) || attempt >= up.Options.Retries {
+			return fmt.Errorf("failed to upload product: %s", err)
+		}
+
+		wait := time.Duration(up.retryWaitSeconds<<uint(attempt)) * time.Second
+		up.logger.Printf("upload failed with a temporary error, retrying in %s (%d of %d): %s", wait, attempt+1, up.Options.Retries, err)
+		time.Sleep(wait)
+	}
+
+	up.logger.Printf("finished upload")
+	up.warnIfNoSuitableStemcell(metadata)
+
+	return nil
+}
+
+// warnIfNoSuitableStemcell only warns, as the stemcell is often uploaded
+// after the product.
+func (up UploadProduct) warnIfNoSuitableStemcell(metadata extractor.Metadata) {
+	if !hasStemcellCriteria(metadata.StemcellCriteria) {
+		return
+	}
+
+	report, err := up.service.GetDiagnosticReport()
 	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %s", err)
+		up.logger.Printf("warning: could not check for a stemcell suitable for %s %s: %s", metadata.Name, metadata.Version, err)
+		return
 	}
 
-	up.logger.Printf("beginning product upload to Ops Manager")
+	if len(suitableStemcells(metadata.StemcellCriteria, report.Stemcells)) == 0 {
+		up.logger.Printf("warning: no uploaded stemcell meets the stemcell criteria of %s %s, upload %s with upload-stemcell before applying changes", metadata.Name, metadata.Version, describeStemcellCriteria(metadata.StemcellCriteria))
+	}
+}
 
-	_, err = up.service.UploadAvailableProduct(api.UploadAvailableProductInput{
-		ContentLength:   submission.Length,
-		Product:         submission.Content,
-		ContentType:     submission.ContentType,
-		PollingInterval: up.Options.PollingInterval,
-	})
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("failed to upload product: %s", err)
+		return "", err
 	}
+	defer file.Close()
 
-	up.logger.Printf("finished upload")
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err // un-tested
+	}
 
-	return nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/pivotal-cf/jhanda"
@@ -40,7 +41,7 @@ var _ = Describe("UploadProduct", func() {
 		}
 		multipart.FinalizeReturns(submission, nil)
 
-		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 
 		err := command.Execute([]string{
 			"--product", "/path/to/some-product.tgz",
@@ -71,7 +72,7 @@ var _ = Describe("UploadProduct", func() {
 
 	Context("when the polling interval is provided", func() {
 		It("passes the value to the products service", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 			err := command.Execute([]string{
 				"--product", "/path/to/some-product.tgz",
 				"--polling-interval", "48",
@@ -81,9 +82,109 @@ var _ = Describe("UploadProduct", func() {
 		})
 	})
 
+	Context("when the upload fails with a temporary error", func() {
+		It("finalizes the form again and retries the upload", func() {
+			fakeService.UploadAvailableProductReturnsOnCall(0, api.UploadAvailableProductOutput{}, netError{errors.New("connection reset")})
+			fakeService.UploadAvailableProductReturnsOnCall(1, api.UploadAvailableProductOutput{}, netError{errors.New("bad gateway")})
+			fakeService.UploadAvailableProductReturnsOnCall(2, api.UploadAvailableProductOutput{}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			err := command.Execute([]string{
+				"--product", "/path/to/some-product.tgz",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(multipart.AddFileCallCount()).To(Equal(1))
+			Expect(multipart.FinalizeCallCount()).To(Equal(3))
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(3))
+
+			format, v := logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("upload failed with a temporary error, retrying in 0s (1 of 3): connection reset"))
+
+			format, v = logger.PrintfArgsForCall(3)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("upload failed with a temporary error, retrying in 0s (2 of 3): bad gateway"))
+		})
+
+		It("gives up after the given number of retries", func() {
+			fakeService.UploadAvailableProductReturns(api.UploadAvailableProductOutput{}, netError{errors.New("connection reset")})
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			err := command.Execute([]string{
+				"--product", "/path/to/some-product.tgz",
+				"--retries", "1",
+			})
+			Expect(err).To(MatchError("failed to upload product: connection reset"))
+
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the sha256 is provided", func() {
+		var productFile string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.WriteString("some product contents")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			productFile = file.Name()
+		})
+
+		AfterEach(func() {
+			os.Remove(productFile)
+		})
+
+		It("uploads the product when the sha256 matches", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			err := command.Execute([]string{
+				"--product", productFile,
+				"--sha256", "C4E2D2A93560F5D3E7893194FF60D1A5587FD87386EDEE35CECA2FB2302C68AD",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("verified sha256 of product"))
+		})
+
+		It("returns an error without uploading when the sha256 does not match", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			err := command.Execute([]string{
+				"--product", productFile,
+				"--sha256", "some-other-sha",
+			})
+			Expect(err).To(MatchError(fmt.Sprintf("sha256 of %s is c4e2d2a93560f5d3e7893194ff60d1a5587fd87386edee35ceca2fb2302c68ad, expected some-other-sha", productFile)))
+
+			Expect(metadataExtractor.ExtractMetadataCallCount()).To(Equal(0))
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the product is a url", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			err := command.Execute([]string{
+				"--product", "https://example.com/some-product.pivotal",
+				"--sha256", "some-sha",
+			})
+			Expect(err).To(MatchError("--sha256 cannot be used when --product is a url"))
+		})
+
+		It("returns an error when the product cannot be read", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			err := command.Execute([]string{
+				"--product", "/some/non-existent/product.pivotal",
+				"--sha256", "some-sha",
+			})
+			Expect(err).To(MatchError("failed to compute sha256 of product: open /some/non-existent/product.pivotal: no such file or directory"))
+		})
+	})
+
 	Context("when the same product is already present", func() {
 		It("does nothing and exits gracefully", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
 				Name:    "cf",
 				Version: "1.5.0",
@@ -107,10 +208,71 @@ var _ = Describe("UploadProduct", func() {
 		})
 	})
 
+	Context("when the product declares stemcell criteria", func() {
+		var command commands.UploadProduct
+
+		BeforeEach(func() {
+			command = commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "cf",
+				Version: "2.2.1",
+				StemcellCriteria: extractor.StemcellCriteria{
+					OS:                         "ubuntu-xenial",
+					Version:                    "97.18",
+					EnablePatchSecurityUpdates: true,
+				},
+			}, nil)
+		})
+
+		It("does not warn when a suitable stemcell has been uploaded", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{"light-bosh-stemcell-97.28-google-kvm-ubuntu-xenial-go_agent.tgz"},
+			}, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.GetDiagnosticReportCallCount()).To(Equal(1))
+			Expect(logger.PrintfCallCount()).To(Equal(3))
+		})
+
+		It("warns when no suitable stemcell has been uploaded", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{"light-bosh-stemcell-3586.40-google-kvm-ubuntu-trusty-go_agent.tgz"},
+			}, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, v := logger.PrintfArgsForCall(3)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("warning: no uploaded stemcell meets the stemcell criteria of cf 2.2.1, upload ubuntu-xenial 97.18 or a later 97.x with upload-stemcell before applying changes"))
+		})
+
+		It("warns when the product is already uploaded", func() {
+			fakeService.CheckProductAvailabilityReturns(true, nil)
+
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, v := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(HavePrefix("warning: no uploaded stemcell meets the stemcell criteria of cf 2.2.1"))
+		})
+
+		It("warns when the uploaded stemcells cannot be listed", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, v := logger.PrintfArgsForCall(3)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("warning: could not check for a stemcell suitable for cf 2.2.1: some error"))
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse upload-product flags: flag provided but not defined: -badflag"))
 			})
@@ -118,7 +280,7 @@ var _ = Describe("UploadProduct", func() {
 
 		Context("when the product flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("could not parse upload-product flags: missing required flag \"--product\""))
 			})
@@ -127,7 +289,7 @@ var _ = Describe("UploadProduct", func() {
 		Context("when extracting the product metadata returns an error", func() {
 			It("returns an error", func() {
 				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to extract product metadata: some error"))
 			})
@@ -136,15 +298,25 @@ var _ = Describe("UploadProduct", func() {
 		Context("when checking for product availability returns an error", func() {
 			It("returns an error", func() {
 				fakeService.CheckProductAvailabilityReturns(true, errors.New("some error"))
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to check product availability: some error"))
 			})
 		})
 
+		Context("when finalizing the form fails", func() {
+			It("returns an error", func() {
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
+				multipart.FinalizeReturns(formcontent.ContentSubmission{}, errors.New("bad form"))
+
+				err := command.Execute([]string{"--product", "/some/path"})
+				Expect(err).To(MatchError("failed to create multipart form: bad form"))
+			})
+		})
+
 		Context("when adding the file fails", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 				multipart.AddFileReturns(errors.New("bad file"))
 
 				err := command.Execute([]string{"--product", "/some/path"})
@@ -154,18 +326,19 @@ var _ = Describe("UploadProduct", func() {
 
 		Context("when the product cannot be uploaded", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, 0)
 				fakeService.UploadAvailableProductReturns(api.UploadAvailableProductOutput{}, errors.New("some product error"))
 
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to upload product: some product error"))
+				Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
 			})
 		})
 	})
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewUploadProduct(nil, nil, nil, nil)
+			command := commands.NewUploadProduct(nil, nil, nil, nil, 0)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command attempts to upload a product to the Ops Manager",
 				ShortDescription: "uploads a given product to the Ops Manager targeted",
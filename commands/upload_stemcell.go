@@ -2,11 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/formcontent"
+	"github.com/pivotal-cf/om/network"
 	"strconv"
 )
 
@@ -15,7 +18,7 @@ type UploadStemcell struct {
 	logger    logger
 	service   uploadStemcellService
 	Options struct {
-		Stemcell string `long:"stemcell" short:"s" required:"true" description:"path to stemcell"`
+		Stemcell string `long:"stemcell" short:"s" required:"true" description:"path or http(s) url of stemcell"`
 		Force    bool   `long:"force"    short:"f"                 description:"upload stemcell even if it already exists on the target Ops Manager"`
 		Floating bool   `long:"floating" default:"true"            description:"assigns the stemcell to all compatible products "`
 	}
@@ -68,7 +71,7 @@ func (us UploadStemcell) Execute(args []string) error {
 		}
 
 		for _, stemcell := range report.Stemcells {
-			if stemcell == filepath.Base(us.Options.Stemcell) {
+			if stemcell == sourceFileName(us.Options.Stemcell) {
 				us.logger.Printf("stemcell has already been uploaded")
 				return nil
 			}
@@ -105,3 +108,16 @@ func (us UploadStemcell) Execute(args []string) error {
 
 	return nil
 }
+
+// sourceFileName returns the file name of a local path or of an http(s) url,
+// ignoring any query string.
+func sourceFileName(source string) string {
+	if network.IsURL(source) {
+		sourceURL, err := url.Parse(source)
+		if err == nil {
+			return path.Base(sourceURL.Path)
+		}
+	}
+
+	return filepath.Base(source)
+}
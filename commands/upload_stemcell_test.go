@@ -144,6 +144,24 @@ var _ = Describe("UploadStemcell", func() {
 			})
 		})
 
+		Context("and the stemcell is a url", func() {
+			It("compares the file name of the url without its query", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+					Stemcells: []string{"stemcell.tgz"},
+				}, nil)
+
+				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+
+				err := command.Execute([]string{
+					"--stemcell", "https://example.com/stemcells/stemcell.tgz?signature=some-signature",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(multipart.AddFileCallCount()).To(Equal(0))
+				Expect(fakeService.UploadStemcellCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("and force is specified", func() {
 			It("uploads the stemcell", func() {
 				submission := formcontent.ContentSubmission{
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var varPattern = regexp.MustCompile(`\(\(([-\w\./]+)\)\)`)
+
+// loadVars merges the top level keys of the given vars files, with later files
+// taking precedence.
+func loadVars(varsFiles []string) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+
+	for _, varsFile := range varsFiles {
+		contents, err := ioutil.ReadFile(varsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var fileVars map[string]interface{}
+		err = yaml.Unmarshal(contents, &fileVars)
+		if err != nil {
+			return nil, fmt.Errorf("%s could not be parsed as a valid vars file: %s", varsFile, err)
+		}
+
+		for name, value := range fileVars {
+			vars[name] = value
+		}
+	}
+
+	return vars, nil
+}
+
+// interpolateVars replaces ((name)) placeholders in any string of the document.
+// A string that is exactly one placeholder is replaced by the value itself, so
+// that vars can hold maps and lists. A dotted ((name.key)) looks up key in the
+// map held by name. Placeholders without a var are left as-is.
+func interpolateVars(document interface{}, vars map[string]interface{}) interface{} {
+	switch value := document.(type) {
+	case map[interface{}]interface{}:
+		interpolated := map[interface{}]interface{}{}
+		for key, element := range value {
+			interpolated[key] = interpolateVars(element, vars)
+		}
+		return interpolated
+	case []interface{}:
+		interpolated := make([]interface{}, 0, len(value))
+		for _, element := range value {
+			interpolated = append(interpolated, interpolateVars(element, vars))
+		}
+		return interpolated
+	case string:
+		if match := varPattern.FindStringSubmatch(value); match != nil && match[0] == value {
+			if v, ok := lookupVar(vars, match[1]); ok {
+				return v
+			}
+			return value
+		}
+
+		return varPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := varPattern.FindStringSubmatch(placeholder)[1]
+			if v, ok := lookupVar(vars, name); ok {
+				return fmt.Sprint(v)
+			}
+			return placeholder
+		})
+	default:
+		return document
+	}
+}
+
+// lookupVar returns the var with the given name. A name that is not a var
+// itself is split on dots to look up a value nested in a var.
+func lookupVar(vars map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := vars[name]; ok {
+		return v, true
+	}
+
+	parts := strings.Split(name, ".")
+	value, ok := vars[parts[0]]
+	if !ok {
+		return nil, false
+	}
+
+	for _, part := range parts[1:] {
+		nested, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = nested[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// compareVersions orders dotted versions such as 2.2.10 and 2.2.9, or 97.18
+// and 97.9, by comparing each numeric part as a number. Parts that are not
+// numbers are compared as strings, and a version followed by a pre-release
+// suffix such as 2.3.0-build.1 is lower than the version itself. It returns
+// -1, 0 or 1 when a is lower than, equal to or greater than b.
+func compareVersions(a, b string) int {
+	aParts := strings.FieldsFunc(a, isVersionSeparator)
+	bParts := strings.FieldsFunc(b, isVersionSeparator)
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		if i >= len(aParts) {
+			if isNumber(bParts[i]) {
+				return -1
+			}
+			return 1
+		}
+
+		if i >= len(bParts) {
+			if isNumber(aParts[i]) {
+				return 1
+			}
+			return -1
+		}
+
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber != bNumber {
+				return compareInts(aNumber, bNumber)
+			}
+		case aErr == nil:
+			return 1
+		case bErr == nil:
+			return -1
+		default:
+			if aParts[i] != bParts[i] {
+				return strings.Compare(aParts[i], bParts[i])
+			}
+		}
+	}
+
+	return 0
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '+'
+}
+
+func isNumber(part string) bool {
+	_, err := strconv.Atoi(part)
+	return err == nil
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	}
+
+	return 1
+}
+
+var versionConstraintOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// matchesVersionConstraint reports whether a version meets a constraint such
+// as "~> 2.2" or ">= 1.10, < 2". A pessimistic constraint allows the last
+// given part to increase, so "~> 2.2" allows 2.9 but not 3.0, and "~> 2.2.1"
+// allows 2.2.9 but not 2.3.0. A version without an operator must match
+// exactly.
+func matchesVersionConstraint(constraint, version string) (bool, error) {
+	for _, requirement := range strings.Split(constraint, ",") {
+		requirement = strings.TrimSpace(requirement)
+
+		operator := "="
+		for _, candidate := range versionConstraintOperators {
+			if strings.HasPrefix(requirement, candidate) {
+				operator = candidate
+				requirement = strings.TrimSpace(strings.TrimPrefix(requirement, candidate))
+				break
+			}
+		}
+
+		if requirement == "" {
+			return false, fmt.Errorf("invalid version constraint %q", constraint)
+		}
+
+		comparison := compareVersions(version, requirement)
+
+		var matched bool
+		switch operator {
+		case "~>":
+			upper, err := pessimisticUpperBound(requirement)
+			if err != nil {
+				return false, fmt.Errorf("invalid version constraint %q", constraint)
+			}
+
+			matched = comparison >= 0 && compareVersions(version, upper) < 0
+		case ">=":
+			matched = comparison >= 0
+		case "<=":
+			matched = comparison <= 0
+		case "!=":
+			matched = comparison != 0
+		case ">":
+			matched = comparison > 0
+		case "<":
+			matched = comparison < 0
+		default:
+			matched = comparison == 0
+		}
+
+		if !matched {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func pessimisticUpperBound(version string) (string, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) > 1 {
+		parts = parts[:len(parts)-1]
+	}
+
+	last, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "", err
+	}
+
+	parts[len(parts)-1] = strconv.Itoa(last + 1)
+
+	return strings.Join(parts, "."), nil
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+//go:generate counterfeiter -o ./fakes/vm_extensions_service.go --fake-name VMExtensionsService . vmExtensionsService
+type vmExtensionsService interface {
+	ListStagedVMExtensions() ([]api.VMExtension, error)
+}
+
+type VMExtensions struct {
+	service   vmExtensionsService
+	presenter presenters.Presenter
+}
+
+func NewVMExtensions(service vmExtensionsService, presenter presenters.Presenter) VMExtensions {
+	return VMExtensions{
+		service:   service,
+		presenter: presenter,
+	}
+}
+
+func (v VMExtensions) Execute(args []string) error {
+	vmExtensions, err := v.service.ListStagedVMExtensions()
+	if err != nil {
+		return fmt.Errorf("failed to list vm extensions: %s", err)
+	}
+
+	v.presenter.PresentVMExtensions(vmExtensions)
+
+	return nil
+}
+
+func (v VMExtensions) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command lists all staged VM extensions.",
+		ShortDescription: "lists VM extensions",
+	}
+}
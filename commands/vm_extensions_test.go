@@ -0,0 +1,63 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMExtensions", func() {
+	var (
+		fakePresenter *presenterfakes.Presenter
+		fakeService   *fakes.VMExtensionsService
+		command       commands.VMExtensions
+	)
+
+	BeforeEach(func() {
+		fakePresenter = &presenterfakes.Presenter{}
+		fakeService = &fakes.VMExtensionsService{}
+		command = commands.NewVMExtensions(fakeService, fakePresenter)
+	})
+
+	Describe("Execute", func() {
+		It("lists the vm extensions", func() {
+			vmExtensions := []api.VMExtension{
+				{Name: "some-vm-extension", CloudProperties: json.RawMessage(`{"iam_instance_profile": "some-iam-profile"}`)},
+				{Name: "some-other-vm-extension", CloudProperties: json.RawMessage(`{"elbs": ["some-elb"]}`)},
+			}
+			fakeService.ListStagedVMExtensionsReturns(vmExtensions, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePresenter.PresentVMExtensionsCallCount()).To(Equal(1))
+			Expect(fakePresenter.PresentVMExtensionsArgsForCall(0)).To(Equal(vmExtensions))
+		})
+
+		Context("when listing the vm extensions fails", func() {
+			It("returns an error", func() {
+				fakeService.ListStagedVMExtensionsReturns(nil, errors.New("boom"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list vm extensions: boom"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command lists all staged VM extensions.",
+				ShortDescription: "lists VM extensions",
+			}))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+//go:generate counterfeiter -o ./fakes/vm_types_service.go --fake-name VMTypesService . vmTypesService
+type vmTypesService interface {
+	ListVMTypes() ([]api.VMType, error)
+}
+
+type VMTypes struct {
+	service   vmTypesService
+	presenter presenters.Presenter
+}
+
+func NewVMTypes(service vmTypesService, presenter presenters.Presenter) VMTypes {
+	return VMTypes{
+		service:   service,
+		presenter: presenter,
+	}
+}
+
+func (v VMTypes) Execute(args []string) error {
+	vmTypes, err := v.service.ListVMTypes()
+	if err != nil {
+		return fmt.Errorf("failed to list vm types: %s", err)
+	}
+
+	v.presenter.PresentVMTypes(vmTypes)
+
+	return nil
+}
+
+func (v VMTypes) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command lists all VM types available to the director.",
+		ShortDescription: "lists VM types",
+	}
+}
@@ -0,0 +1,63 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMTypes", func() {
+	var (
+		fakePresenter *presenterfakes.Presenter
+		fakeService   *fakes.VMTypesService
+		command       commands.VMTypes
+	)
+
+	BeforeEach(func() {
+		fakePresenter = &presenterfakes.Presenter{}
+		fakeService = &fakes.VMTypesService{}
+		command = commands.NewVMTypes(fakeService, fakePresenter)
+	})
+
+	Describe("Execute", func() {
+		It("lists the vm types", func() {
+			vmTypes := []api.VMType{
+				{Name: "nano", CPU: 1, RAM: 512, EphemeralDisk: 1024, BuiltIn: true},
+				{Name: "memory-heavy", CPU: 8, RAM: 65536, EphemeralDisk: 32768},
+			}
+			fakeService.ListVMTypesReturns(vmTypes, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.ListVMTypesCallCount()).To(Equal(1))
+			Expect(fakePresenter.PresentVMTypesCallCount()).To(Equal(1))
+			Expect(fakePresenter.PresentVMTypesArgsForCall(0)).To(Equal(vmTypes))
+		})
+
+		Context("when listing the vm types fails", func() {
+			It("returns an error", func() {
+				fakeService.ListVMTypesReturns(nil, errors.New("boom"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list vm types: boom"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command lists all VM types available to the director.",
+				ShortDescription: "lists VM types",
+			}))
+		})
+	})
+})
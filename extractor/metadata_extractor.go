@@ -5,20 +5,67 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"regexp"
 
+	"github.com/pivotal-cf/om/network"
 	yaml "gopkg.in/yaml.v2"
 )
 
-type MetadataExtractor struct{}
+type MetadataExtractor struct {
+	remoteClient *http.Client
+}
+
+// NewMetadataExtractor returns an extractor that reads remote products with
+// the given client.
+func NewMetadataExtractor(remoteClient *http.Client) MetadataExtractor {
+	return MetadataExtractor{remoteClient: remoteClient}
+}
 
 type Metadata struct {
-	Name    string
-	Version string `yaml:"product_version"`
-	Raw     []byte
+	Name                    string
+	Version                 string           `yaml:"product_version"`
+	StemcellCriteria        StemcellCriteria `yaml:"stemcell_criteria"`
+	RequiresProductVersions []ProductVersion `yaml:"requires_product_versions"`
+	ProvidesProductVersions []ProductVersion `yaml:"provides_product_versions"`
+	Raw                     []byte
+}
+
+// ProductVersion names another product. In requires_product_versions the
+// version is a constraint such as "~> 2.2" rather than a single version.
+type ProductVersion struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// StemcellCriteria describes the stemcell a product must be deployed with.
+// When EnablePatchSecurityUpdates is set, newer stemcells on the same major
+// line as Version are accepted as well.
+type StemcellCriteria struct {
+	OS                         string `yaml:"os"`
+	Version                    string `yaml:"version"`
+	RequiresCPI                bool   `yaml:"requires_cpi"`
+	EnablePatchSecurityUpdates bool   `yaml:"enable_patch_security_updates"`
 }
 
+// ExtractMetadata reads the metadata of a product from a local path, or from
+// an http(s) url using ranged requests, so that only the zip central directory
+// and the metadata file are downloaded.
 func (me MetadataExtractor) ExtractMetadata(productPath string) (Metadata, error) {
+	if network.IsURL(productPath) {
+		remoteFile, err := network.OpenRemoteFile(me.remoteClient, productPath)
+		if err != nil {
+			return Metadata{}, err
+		}
+
+		zipReader, err := zip.NewReader(remoteFile, remoteFile.Size)
+		if err != nil {
+			return Metadata{}, err
+		}
+
+		return metadataFromFiles(zipReader.File)
+	}
+
 	zipReader, err := zip.OpenReader(productPath)
 	if err != nil {
 		return Metadata{}, err
@@ -26,7 +73,11 @@ func (me MetadataExtractor) ExtractMetadata(productPath string) (Metadata, error
 
 	defer zipReader.Close()
 
-	for _, file := range zipReader.File {
+	return metadataFromFiles(zipReader.File)
+}
+
+func metadataFromFiles(files []*zip.File) (Metadata, error) {
+	for _, file := range files {
 		metadataRegexp := regexp.MustCompile("metadata/.*\\.yml")
 		matched := metadataRegexp.MatchString(file.Name)
 
@@ -4,6 +4,8 @@ import (
 	"archive/zip"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 
 	"github.com/pivotal-cf/om/extractor"
@@ -16,7 +18,18 @@ const (
 	validYAML = `
 ---
 product_version: 1.8.14
-name: some-product`
+name: some-product
+stemcell_criteria:
+  os: ubuntu-xenial
+  version: 97.10
+  requires_cpi: false
+  enable_patch_security_updates: true
+requires_product_versions:
+- name: cf
+  version: ~> 2.2
+provides_product_versions:
+- name: some-product
+  version: 1.8.14`
 )
 
 var _ = Describe("MetadataExtractor", func() {
@@ -48,7 +61,7 @@ var _ = Describe("MetadataExtractor", func() {
 		err = zipper.Close()
 		Expect(err).NotTo(HaveOccurred())
 
-		metadataExtractor = extractor.MetadataExtractor{}
+		metadataExtractor = extractor.NewMetadataExtractor(http.DefaultClient)
 	})
 
 	AfterEach(func() {
@@ -65,6 +78,65 @@ var _ = Describe("MetadataExtractor", func() {
 			Expect(metadata.Raw).To(MatchYAML(validYAML))
 		})
 
+		It("extracts the stemcell criteria, keeping the version as written", func() {
+			metadata, err := metadataExtractor.ExtractMetadata(productFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadata.StemcellCriteria).To(Equal(extractor.StemcellCriteria{
+				OS:                         "ubuntu-xenial",
+				Version:                    "97.10",
+				RequiresCPI:                false,
+				EnablePatchSecurityUpdates: true,
+			}))
+		})
+
+		It("extracts the required and provided product versions", func() {
+			metadata, err := metadataExtractor.ExtractMetadata(productFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadata.RequiresProductVersions).To(Equal([]extractor.ProductVersion{{Name: "cf", Version: "~> 2.2"}}))
+			Expect(metadata.ProvidesProductVersions).To(Equal([]extractor.ProductVersion{{Name: "some-product", Version: "1.8.14"}}))
+		})
+
+		Context("when the product is a url", func() {
+			var (
+				server *httptest.Server
+				ranges []string
+			)
+
+			BeforeEach(func() {
+				ranges = nil
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					ranges = append(ranges, req.Header.Get("Range"))
+					http.ServeFile(w, req, productFile.Name())
+				}))
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("extracts the metadata using ranged requests", func() {
+				metadata, err := metadataExtractor.ExtractMetadata(server.URL + "/some-product.pivotal")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(metadata.Name).To(Equal("some-product"))
+				Expect(metadata.Version).To(Equal("1.8.14"))
+
+				Expect(ranges).NotTo(BeEmpty())
+				for _, r := range ranges {
+					Expect(r).To(HavePrefix("bytes="))
+				}
+			})
+
+			It("returns an error when the url cannot be found", func() {
+				server.Config.Handler = http.NotFoundHandler()
+
+				_, err := metadataExtractor.ExtractMetadata(server.URL + "/missing.pivotal")
+				Expect(err).To(MatchError(ContainSubstring("unexpected status 404 Not Found")))
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when the product tarball does not exist", func() {
 				It("returns an error", func() {
@@ -1,17 +1,27 @@
 package formcontent
 
 import (
+	"bytes"
 	"errors"
 	"io"
-	"io/ioutil"
 	"mime/multipart"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+
+	"github.com/pivotal-cf/om/network"
 )
 
+// Form builds a multipart form without copying file contents. Files are read
+// from their original location, which may be an http(s) url, each time the
+// finalized content is read.
 type Form struct {
 	multipartWriter *multipart.Writer
-	body            *os.File
+	buffer          *bytes.Buffer
+	content         *formContent
+	remoteClient    *http.Client
 }
 
 type ContentSubmission struct {
@@ -20,50 +30,67 @@ type ContentSubmission struct {
 	ContentType string
 }
 
-func NewForm() (Form, error) {
-	body, err := ioutil.TempFile("", "")
-	if err != nil {
-		return Form{}, err
-	}
+type formContent struct {
+	segments  []segment
+	finalized bool
+}
+
+// segment is either literal form data, or the first length bytes of a local
+// or remote file.
+type segment struct {
+	data   []byte
+	path   string
+	remote *network.RemoteFile
+	length int64
+}
+
+// NewForm returns a form that reads remote files with the given client.
+func NewForm(remoteClient *http.Client) (Form, error) {
+	buffer := &bytes.Buffer{}
 
 	return Form{
-		multipartWriter: multipart.NewWriter(body),
-		body:            body,
+		multipartWriter: multipart.NewWriter(buffer),
+		buffer:          buffer,
+		content:         &formContent{},
+		remoteClient:    remoteClient,
 	}, nil
 }
 
+// Finalize returns a new submission each time it is called, so that a failed
+// request can be retried by finalizing the form again.
 func (f Form) Finalize() (ContentSubmission, error) {
-	err := f.multipartWriter.Close()
-	if err != nil {
-		return ContentSubmission{}, err
-	}
-
-	_, err = f.body.Seek(0, 0)
-	if err != nil {
-		return ContentSubmission{}, err
+	if !f.content.finalized {
+		err := f.multipartWriter.Close()
+		if err != nil {
+			return ContentSubmission{}, err // un-tested
+		}
+
+		f.flushBuffer()
+		f.content.finalized = true
 	}
 
-	stats, err := f.body.Stat()
-	if err != nil {
-		return ContentSubmission{}, err
+	var length int64
+	for _, s := range f.content.segments {
+		length += s.length
 	}
 
 	return ContentSubmission{
-		Length:      stats.Size(),
-		Content:     f.body,
+		Length:      length,
+		Content:     &segmentReader{segments: f.content.segments},
 		ContentType: f.multipartWriter.FormDataContentType(),
 	}, nil
 }
 
 func (f Form) AddFile(key, path string) error {
-	originalContent, err := os.Open(path)
-	if err != nil {
-		return err
+	if f.content.finalized {
+		return errors.New("form has already been finalized")
 	}
 
-	defer originalContent.Close()
+	if network.IsURL(path) {
+		return f.addRemoteFile(key, path)
+	}
 
-	stats, err := originalContent.Stat()
+	stats, err := os.Stat(path)
 	if err != nil {
 		return err
 	}
@@ -72,20 +99,54 @@ func (f Form) AddFile(key, path string) error {
 		return errors.New("file provided has no content")
 	}
 
-	formFile, err := f.multipartWriter.CreateFormFile(key, filepath.Base(path))
+	_, err = f.multipartWriter.CreateFormFile(key, filepath.Base(path))
 	if err != nil {
-		return err
+		return err // un-tested
 	}
 
-	_, err = io.Copy(formFile, originalContent)
+	f.flushBuffer()
+	f.content.segments = append(f.content.segments, segment{
+		path:   path,
+		length: stats.Size(),
+	})
+
+	return nil
+}
+
+func (f Form) addRemoteFile(key, fileURL string) error {
+	remoteFile, err := network.OpenRemoteFile(f.remoteClient, fileURL)
 	if err != nil {
 		return err
 	}
 
+	if remoteFile.Size == 0 {
+		return errors.New("file provided has no content")
+	}
+
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil {
+		return err // un-tested
+	}
+
+	_, err = f.multipartWriter.CreateFormFile(key, path.Base(parsedURL.Path))
+	if err != nil {
+		return err // un-tested
+	}
+
+	f.flushBuffer()
+	f.content.segments = append(f.content.segments, segment{
+		remote: remoteFile,
+		length: remoteFile.Size,
+	})
+
 	return nil
 }
 
 func (f Form) AddField(key, value string) error {
+	if f.content.finalized {
+		return errors.New("form has already been finalized")
+	}
+
 	fieldWriter, err := f.multipartWriter.CreateFormField(key)
 	if err != nil {
 		return err
@@ -94,3 +155,85 @@ func (f Form) AddField(key, value string) error {
 	fieldWriter.Write([]byte(value))
 	return nil
 }
+
+func (f Form) flushBuffer() {
+	if f.buffer.Len() == 0 {
+		return
+	}
+
+	data := make([]byte, f.buffer.Len())
+	copy(data, f.buffer.Bytes())
+	f.buffer.Reset()
+
+	f.content.segments = append(f.content.segments, segment{
+		data:   data,
+		length: int64(len(data)),
+	})
+}
+
+// segmentReader reads each segment in turn, only keeping the file of the
+// current segment open.
+type segmentReader struct {
+	segments []segment
+	current  io.Reader
+	file     io.Closer
+}
+
+func (r *segmentReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.segments) == 0 {
+				return 0, io.EOF
+			}
+
+			next := r.segments[0]
+			r.segments = r.segments[1:]
+
+			switch {
+			case next.remote != nil:
+				body, err := next.remote.Open()
+				if err != nil {
+					return 0, err
+				}
+
+				r.file = body
+				r.current = io.LimitReader(body, next.length)
+			case next.path != "":
+				file, err := os.Open(next.path)
+				if err != nil {
+					return 0, err
+				}
+
+				r.file = file
+				r.current = io.LimitReader(file, next.length)
+			default:
+				r.current = bytes.NewReader(next.data)
+			}
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current = nil
+			r.Close()
+
+			if n > 0 {
+				return n, nil
+			}
+
+			continue
+		}
+
+		return n, err
+	}
+}
+
+func (r *segmentReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
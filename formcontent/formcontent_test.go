@@ -1,8 +1,15 @@
 package formcontent_test
 
 import (
+	"bytes"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -33,7 +40,7 @@ var _ = Describe("Form", func() {
 
 			fileWithContent2 = handle2.Name()
 
-			form, err = formcontent.NewForm()
+			form, err = formcontent.NewForm(http.DefaultClient)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -61,12 +68,115 @@ var _ = Describe("Form", func() {
 			Expect(string(content)).To(ContainSubstring("some more content"))
 		})
 
+		It("streams the file contents with a length matching the content", func() {
+			err := form.AddField("something[field]", "some value")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = form.AddFile("something[file1]", fileWithContent1)
+			Expect(err).NotTo(HaveOccurred())
+
+			submission, err := form.Finalize()
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := ioutil.ReadAll(submission.Content)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(submission.Length).To(Equal(int64(len(content))))
+
+			reader := multipart.NewReader(bytes.NewReader(content), strings.TrimPrefix(submission.ContentType, "multipart/form-data; boundary="))
+
+			part, err := reader.NextPart()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(part.FormName()).To(Equal("something[field]"))
+
+			part, err = reader.NextPart()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(part.FormName()).To(Equal("something[file1]"))
+			Expect(part.FileName()).To(Equal(filepath.Base(fileWithContent1)))
+
+			fileContent, err := ioutil.ReadAll(part)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(fileContent)).To(Equal("some content"))
+
+			_, err = reader.NextPart()
+			Expect(err).To(Equal(io.EOF))
+		})
+
+		It("can be finalized again to read the content from the start", func() {
+			err := form.AddFile("something[file1]", fileWithContent1)
+			Expect(err).NotTo(HaveOccurred())
+
+			first, err := form.Finalize()
+			Expect(err).NotTo(HaveOccurred())
+
+			firstContent, err := ioutil.ReadAll(first.Content)
+			Expect(err).NotTo(HaveOccurred())
+
+			second, err := form.Finalize()
+			Expect(err).NotTo(HaveOccurred())
+
+			secondContent, err := ioutil.ReadAll(second.Content)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(second.Length).To(Equal(first.Length))
+			Expect(secondContent).To(Equal(firstContent))
+		})
+
+		Context("when the file is a url", func() {
+			var server *httptest.Server
+
+			BeforeEach(func() {
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					http.ServeFile(w, req, fileWithContent1)
+				}))
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("streams the file from the url with the length it reports", func() {
+				err := form.AddFile("something[file1]", server.URL+"/some/product.pivotal?signature=abc")
+				Expect(err).NotTo(HaveOccurred())
+
+				submission, err := form.Finalize()
+				Expect(err).NotTo(HaveOccurred())
+
+				content, err := ioutil.ReadAll(submission.Content)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(submission.Length).To(Equal(int64(len(content))))
+				Expect(string(content)).To(ContainSubstring(`filename="product.pivotal"`))
+				Expect(string(content)).To(ContainSubstring("some content"))
+			})
+
+			It("returns an error when the url cannot be requested", func() {
+				server.Config.Handler = http.NotFoundHandler()
+
+				err := form.AddFile("something[file1]", server.URL+"/some/product.pivotal")
+				Expect(err).To(MatchError(ContainSubstring("unexpected status 404 Not Found")))
+			})
+		})
+
+		Context("when the form has already been finalized", func() {
+			It("returns an error", func() {
+				_, err := form.Finalize()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = form.AddFile("something[file1]", fileWithContent1)
+				Expect(err).To(MatchError("form has already been finalized"))
+
+				err = form.AddField("key", "value")
+				Expect(err).To(MatchError("form has already been finalized"))
+			})
+		})
+
 		Context("when the file provided is empty", func() {
 			It("returns an error", func() {
 				emptyFile, err := ioutil.TempFile("", "")
 				Expect(err).NotTo(HaveOccurred())
 
-				form, err := formcontent.NewForm()
+				form, err := formcontent.NewForm(http.DefaultClient)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = form.AddFile("foo", emptyFile.Name())
@@ -77,7 +187,7 @@ var _ = Describe("Form", func() {
 		Context("when an error occurs", func() {
 			Context("when the original file cannot be read", func() {
 				It("returns an error", func() {
-					form, err := formcontent.NewForm()
+					form, err := formcontent.NewForm(http.DefaultClient)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = form.AddFile("foo", "/file/does/not/exist")
@@ -92,7 +202,7 @@ var _ = Describe("Form", func() {
 
 		BeforeEach(func() {
 			var err error
-			form, err = formcontent.NewForm()
+			form, err = formcontent.NewForm(http.DefaultClient)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -126,7 +236,7 @@ var _ = Describe("Form", func() {
 
 		BeforeEach(func() {
 			var err error
-			form, err = formcontent.NewForm()
+			form, err = formcontent.NewForm(http.DefaultClient)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
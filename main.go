@@ -1,9 +1,12 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/gosuri/uilive"
 	"github.com/olekukonko/tablewriter"
@@ -24,6 +27,8 @@ import (
 var version = "unknown"
 
 const applySleepSeconds = 10
+const uploadRetrySeconds = 5
+const availabilitySleepSeconds = 5
 
 type httpClient interface {
 	Do(*http.Request) (*http.Response, error)
@@ -34,17 +39,20 @@ func main() {
 	stderr := log.New(os.Stderr, "", 0)
 
 	var global struct {
-		ClientID          string `short:"c"  long:"client-id"                           description:"Client ID for the Ops Manager VM (not required for unauthenticated commands, $OM_CLIENT_ID)"`
-		ClientSecret      string `short:"s"  long:"client-secret"                       description:"Client Secret for the Ops Manager VM (not required for unauthenticated commands, $OM_CLIENT_SECRET)"`
-		Format            string `short:"f"  long:"format"              default:"table" description:"Format to print as (options: table,json)"`
-		Help              bool   `short:"h"  long:"help"                default:"false" description:"prints this usage information"`
-		Password          string `short:"p"  long:"password"                            description:"admin password for the Ops Manager VM (not required for unauthenticated commands, $OM_PASSWORD)"`
-		RequestTimeout    int    `short:"r"  long:"request-timeout"     default:"1800"  description:"timeout in seconds for HTTP requests to Ops Manager"`
-		SkipSSLValidation bool   `short:"k"  long:"skip-ssl-validation" default:"false" description:"skip ssl certificate validation during http requests"`
-		Target            string `short:"t"  long:"target"                              description:"location of the Ops Manager VM"`
-		Trace             bool   `short:"tr" long:"trace"                               description:"prints HTTP requests and response payloads"`
-		Username          string `short:"u"  long:"username"                            description:"admin username for the Ops Manager VM (not required for unauthenticated commands, $OM_USERNAME)"`
-		Version           bool   `short:"v"  long:"version"             default:"false" description:"prints the om release version"`
+		ClientID             string `short:"c"  long:"client-id"                             description:"Client ID for the Ops Manager VM (not required for unauthenticated commands, $OM_CLIENT_ID)"`
+		ClientSecret         string `short:"s"  long:"client-secret"                         description:"Client Secret for the Ops Manager VM (not required for unauthenticated commands, $OM_CLIENT_SECRET)"`
+		DecryptionPassphrase string `short:"d"  long:"decryption-passphrase"                 description:"passphrase to unlock the Ops Manager VM automatically when it has been restarted ($OM_DECRYPTION_PASSPHRASE)"`
+		Format               string `short:"f"  long:"format"                default:"table" description:"Format to print as (options: table,json)"`
+		Help                 bool   `short:"h"  long:"help"                  default:"false" description:"prints this usage information"`
+		Passcode             string `           long:"passcode"                              description:"one-time passcode from https://OPSMAN/uaa/passcode, the token is stored for later commands"`
+		Password             string `short:"p"  long:"password"                              description:"admin password for the Ops Manager VM (not required for unauthenticated commands, $OM_PASSWORD)"`
+		RequestTimeout       int    `short:"r"  long:"request-timeout"       default:"1800"  description:"timeout in seconds for HTTP requests to Ops Manager"`
+		SkipSSLValidation    bool   `short:"k"  long:"skip-ssl-validation"   default:"false" description:"skip ssl certificate validation during http requests"`
+		SSO                  bool   `           long:"sso"                   default:"false" description:"prompts for a one-time passcode to log in with single sign-on, the token is stored for later commands"`
+		Target               string `short:"t"  long:"target"                                description:"location of the Ops Manager VM"`
+		Trace                bool   `short:"tr" long:"trace"                                 description:"prints HTTP requests and response payloads"`
+		Username             string `short:"u"  long:"username"                              description:"admin username for the Ops Manager VM (not required for unauthenticated commands, $OM_USERNAME)"`
+		Version              bool   `short:"v"  long:"version"               default:"false" description:"prints the om release version"`
 	}
 
 	args, err := jhanda.Parse(&global, os.Args[1:])
@@ -90,19 +98,52 @@ func main() {
 		global.ClientSecret = os.Getenv("OM_CLIENT_SECRET")
 	}
 
+	if global.DecryptionPassphrase == "" {
+		global.DecryptionPassphrase = os.Getenv("OM_DECRYPTION_PASSPHRASE")
+	}
+
 	requestTimeout := time.Duration(global.RequestTimeout) * time.Second
 
 	var unauthenticatedClient, authedClient, authedCookieClient, unauthenticatedProgressClient, authedProgressClient httpClient
 	unauthenticatedClient = network.NewUnauthenticatedClient(global.Target, global.SkipSSLValidation, requestTimeout)
-	authedClient, err = network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, false, requestTimeout)
+	oauthClient, err := network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, false, requestTimeout, global.DecryptionPassphrase)
 	if err != nil {
 		stdout.Fatal(err)
 	}
-	authedCookieClient, err = network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, true, requestTimeout)
+	oauthCookieClient, err := network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, true, requestTimeout, global.DecryptionPassphrase)
 	if err != nil {
 		stdout.Fatal(err)
 	}
 
+	// The stored sso token is only looked up when asked for a passcode, or when
+	// there are no other credentials to log in with.
+	askPasscode := global.SSO || global.Passcode != ""
+	useSSO := askPasscode || (global.Username == "" && global.ClientID == "")
+
+	home := os.Getenv("HOME")
+	if home == "" && askPasscode {
+		stdout.Fatal("could not store the sso token: $HOME is not set")
+	}
+
+	if home != "" && useSSO {
+		var passcode network.PasscodeSource
+		switch {
+		case global.Passcode != "":
+			passcode = func(string) (string, error) {
+				return global.Passcode, nil
+			}
+		case global.SSO:
+			passcode = promptPasscode
+		}
+
+		session := network.NewSSOSession(network.NewTokenStore(filepath.Join(home, ".om", "tokens.json")), passcode)
+		oauthClient = oauthClient.WithSSOSession(session)
+		oauthCookieClient = oauthCookieClient.WithSSOSession(session)
+	}
+
+	authedClient = oauthClient
+	authedCookieClient = oauthCookieClient
+
 	liveWriter := uilive.New()
 	liveWriter.Out = os.Stderr
 	unauthenticatedProgressClient = network.NewProgressClient(unauthenticatedClient, progress.NewBar(), liveWriter)
@@ -116,6 +157,26 @@ func main() {
 		authedProgressClient = network.NewTraceClient(authedProgressClient, os.Stderr)
 	}
 
+	newAPI := func(env commands.Environment) (api.Api, error) {
+		var client, unauthedClient httpClient
+		client, err := network.NewOAuthClient(env.Target, env.Username, env.Password, env.ClientID, env.ClientSecret, env.SkipSSLValidation, false, requestTimeout, "")
+		if err != nil {
+			return api.Api{}, err
+		}
+		unauthedClient = network.NewUnauthenticatedClient(env.Target, env.SkipSSLValidation, requestTimeout)
+
+		if global.Trace {
+			client = network.NewTraceClient(client, os.Stderr)
+			unauthedClient = network.NewTraceClient(unauthedClient, os.Stderr)
+		}
+
+		return api.New(api.ApiInput{
+			Client:         client,
+			UnauthedClient: unauthedClient,
+			Logger:         stderr,
+		}), nil
+	}
+
 	api := api.New(api.ApiInput{
 		Client:                 authedClient,
 		UnauthedClient:         unauthenticatedClient,
@@ -129,12 +190,14 @@ func main() {
 	logWriter := commands.NewLogWriter(os.Stdout)
 	tableWriter := tablewriter.NewWriter(os.Stdout)
 
-	form, err := formcontent.NewForm()
+	remoteFileClient := network.NewRemoteFileClient(global.SkipSSLValidation, requestTimeout)
+
+	form, err := formcontent.NewForm(remoteFileClient)
 	if err != nil {
 		stdout.Fatal(err)
 	}
 
-	metadataExtractor := extractor.MetadataExtractor{}
+	metadataExtractor := extractor.NewMetadataExtractor(remoteFileClient)
 
 	var presenter presenters.Presenter
 	switch global.Format {
@@ -149,15 +212,25 @@ func main() {
 	commandSet := jhanda.CommandSet{}
 	commandSet["activate-certificate-authority"] = commands.NewActivateCertificateAuthority(api, stdout)
 	commandSet["apply-changes"] = commands.NewApplyChanges(api, logWriter, stdout, applySleepSeconds)
+	commandSet["assign-stemcell"] = commands.NewAssignStemcell(api, stdout)
 	commandSet["available-products"] = commands.NewAvailableProducts(api, presenter, stdout)
+	commandSet["bosh-diff"] = commands.NewBoshDiff(api, stdout)
+	commandSet["bosh-env"] = commands.NewBoshEnv(api, stdout)
 	commandSet["certificate-authorities"] = commands.NewCertificateAuthorities(api, presenter)
 	commandSet["certificate-authority"] = commands.NewCertificateAuthority(api, presenter, stdout)
-	commandSet["configure-authentication"] = commands.NewConfigureAuthentication(api, stdout)
+	commandSet["check-dependencies"] = commands.NewCheckDependencies(metadataExtractor, api, stdout)
+	commandSet["check-drift"] = commands.NewCheckDrift(api, stdout)
+	commandSet["configure-authentication"] = commands.NewConfigureAuthentication(api, stdout, availabilitySleepSeconds)
 	commandSet["configure-bosh"] = commands.NewConfigureBosh(ui, api, stdout, stderr)
 	commandSet["configure-director"] = commands.NewConfigureDirector(api, stdout)
+	commandSet["configure-opsman"] = commands.NewConfigureOpsman(api, stdout)
 	commandSet["configure-product"] = commands.NewConfigureProduct(api, stdout)
+	commandSet["configure-vm-extensions"] = commands.NewConfigureVMExtensions(api, stdout)
+	commandSet["configure-vm-types"] = commands.NewConfigureVMTypes(api, stdout)
 	commandSet["config-template"] = commands.NewConfigTemplate(metadataExtractor, stdout)
 	commandSet["create-certificate-authority"] = commands.NewCreateCertificateAuthority(api, presenter)
+	commandSet["create-uaa-client"] = commands.NewCreateUAAClient(api, stdout)
+	commandSet["create-uaa-user"] = commands.NewCreateUAAUser(api, stdout)
 	commandSet["create-vm-extension"] = commands.NewCreateVMExtension(api, stdout)
 	commandSet["credential-references"] = commands.NewCredentialReferences(api, presenter, stdout)
 	commandSet["credentials"] = commands.NewCredentials(api, presenter, stdout)
@@ -165,9 +238,14 @@ func main() {
 	commandSet["delete-certificate-authority"] = commands.NewDeleteCertificateAuthority(api, stdout)
 	commandSet["delete-installation"] = commands.NewDeleteInstallation(api, logWriter, stdout, applySleepSeconds)
 	commandSet["delete-product"] = commands.NewDeleteProduct(api)
+	commandSet["delete-uaa-client"] = commands.NewDeleteUAAClient(api, stdout)
 	commandSet["delete-unused-products"] = commands.NewDeleteUnusedProducts(api, stdout)
+	commandSet["delete-unused-stemcells"] = commands.NewDeleteUnusedStemcells(api, stdout)
+	commandSet["delete-vm-extension"] = commands.NewDeleteVMExtension(api, stdout)
 	commandSet["deployed-manifest"] = commands.NewDeployedManifest(api, stdout)
 	commandSet["deployed-products"] = commands.NewDeployedProducts(presenter, api)
+	commandSet["diff-foundations"] = commands.NewDiffFoundations(newAPI, stdout)
+	commandSet["download-product"] = commands.NewDownloadProduct(remoteFileClient, stdout)
 	commandSet["errands"] = commands.NewErrands(presenter, api)
 	commandSet["export-installation"] = commands.NewExportInstallation(api, stderr)
 	commandSet["generate-certificate"] = commands.NewGenerateCertificate(api, stdout)
@@ -177,20 +255,40 @@ func main() {
 	commandSet["installation-log"] = commands.NewInstallationLog(api, stdout)
 	commandSet["installations"] = commands.NewInstallations(api, presenter)
 	commandSet["pending-changes"] = commands.NewPendingChanges(presenter, api)
+	commandSet["reconcile"] = commands.NewReconcile(api, commandSet, os.Stdin, stdout)
 	commandSet["regenerate-certificates"] = commands.NewRegenerateCertificates(api, stdout)
 	commandSet["revert-staged-changes"] = commands.NewRevertStagedChanges(ui, stdout)
 	commandSet["set-errand-state"] = commands.NewSetErrandState(api)
 	commandSet["staged-config"] = commands.NewStagedConfig(api, stdout)
-	commandSet["stage-product"] = commands.NewStageProduct(api, stdout)
+	commandSet["stage-product"] = commands.NewStageProduct(metadataExtractor, api, stdout)
 	commandSet["staged-manifest"] = commands.NewStagedManifest(api, stdout)
+	commandSet["staged-opsman-config"] = commands.NewStagedOpsmanConfig(api, stdout)
 	commandSet["staged-products"] = commands.NewStagedProducts(presenter, api)
+	commandSet["stemcell-assignments"] = commands.NewStemcellAssignments(presenter, api)
+	commandSet["stemcell-requirements"] = commands.NewStemcellRequirements(metadataExtractor, api, stdout)
+	commandSet["uaa-clients"] = commands.NewUAAClients(presenter, api)
+	commandSet["unlock"] = commands.NewUnlock(api, stdout, availabilitySleepSeconds)
 	commandSet["unstage-product"] = commands.NewUnstageProduct(api, stdout)
-	commandSet["upload-product"] = commands.NewUploadProduct(form, metadataExtractor, api, stdout)
+	commandSet["upgrade-plan"] = commands.NewUpgradePlan(metadataExtractor, api, presenter)
+	commandSet["upload-product"] = commands.NewUploadProduct(form, metadataExtractor, api, stdout, uploadRetrySeconds)
 	commandSet["upload-stemcell"] = commands.NewUploadStemcell(form, api, stdout)
 	commandSet["version"] = commands.NewVersion(version, os.Stdout)
+	commandSet["vm-extensions"] = commands.NewVMExtensions(api, presenter)
+	commandSet["vm-types"] = commands.NewVMTypes(api, presenter)
 
 	err = commandSet.Execute(command, args)
 	if err != nil {
 		stderr.Fatal(err)
 	}
 }
+
+func promptPasscode(passcodeURL string) (string, error) {
+	fmt.Fprintf(os.Stderr, "One Time Code ( Get one at %s ): ", passcodeURL)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return "", fmt.Errorf("no passcode was entered")
+	}
+
+	return scanner.Text(), scanner.Err()
+}
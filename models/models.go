@@ -20,3 +20,24 @@ type Errand struct {
 	PostDeployEnabled string `json:"post_deploy_enabled,omitempty"`
 	PreDeleteEnabled  string `json:"pre_delete_enabled,omitempty"`
 }
+
+type PendingChangeDetail struct {
+	Product          string   `json:"product"`
+	Action           string   `json:"action"`
+	DeployedVersion  string   `json:"deployed_version,omitempty"`
+	StagedVersion    string   `json:"staged_version,omitempty"`
+	DeployedStemcell string   `json:"deployed_stemcell,omitempty"`
+	StagedStemcell   string   `json:"staged_stemcell,omitempty"`
+	Errands          []string `json:"errands"`
+}
+
+type UpgradeStep struct {
+	Order          int      `json:"order,omitempty"`
+	Product        string   `json:"product"`
+	CurrentVersion string   `json:"current_version"`
+	TargetVersion  string   `json:"target_version"`
+	Stemcell       string   `json:"stemcell,omitempty"`
+	Requires       []string `json:"requires,omitempty"`
+	Blocked        bool     `json:"blocked"`
+	Notes          []string `json:"notes,omitempty"`
+}
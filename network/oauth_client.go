@@ -24,9 +24,16 @@ type OAuthClient struct {
 	password      string
 	target        string
 	timeout       time.Duration
+
+	decryptionPassphrase string
+	unauthedClient       UnauthenticatedClient
+	sso                  *SSOSession
 }
 
-func NewOAuthClient(target, username, password string, clientID, clientSecret string, insecureSkipVerify bool, includeCookies bool, requestTimeout time.Duration) (OAuthClient, error) {
+// NewOAuthClient returns a client that authenticates requests to Ops Manager.
+// When a decryption passphrase is given, a request that fails because Ops
+// Manager is locked after a restart unlocks it and is retried.
+func NewOAuthClient(target, username, password string, clientID, clientSecret string, insecureSkipVerify bool, includeCookies bool, requestTimeout time.Duration, decryptionPassphrase string) (OAuthClient, error) {
 	conf := &oauth2.Config{
 		ClientID:     "opsman",
 		ClientSecret: "",
@@ -72,10 +79,54 @@ func NewOAuthClient(target, username, password string, clientID, clientSecret st
 		password:      password,
 		target:        target,
 		timeout:       requestTimeout,
+
+		decryptionPassphrase: decryptionPassphrase,
+		unauthedClient:       NewUnauthenticatedClient(target, insecureSkipVerify, requestTimeout),
 	}, nil
 }
 
+// WithSSOSession returns a copy of the client that authenticates with the
+// token of the session when no client or username is given, or when the
+// session asks for a passcode.
+func (oc OAuthClient) WithSSOSession(session *SSOSession) OAuthClient {
+	oc.sso = session
+	return oc
+}
+
 func (oc OAuthClient) Do(request *http.Request) (*http.Response, error) {
+	resp, err := oc.do(request)
+	if oc.decryptionPassphrase == "" || !lockedResponse(resp, err) {
+		return resp, err
+	}
+
+	unlocked, unlockErr := oc.unlock()
+	if unlockErr != nil {
+		return nil, unlockErr
+	}
+
+	if !unlocked {
+		return resp, err
+	}
+
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if request.Body != nil {
+		if request.GetBody == nil {
+			return nil, fmt.Errorf("Ops Manager was unlocked, but the request to %s could not be retried", request.URL.Path)
+		}
+
+		request.Body, err = request.GetBody()
+		if err != nil {
+			return nil, err // un-tested
+		}
+	}
+
+	return oc.do(request)
+}
+
+func (oc OAuthClient) do(request *http.Request) (*http.Response, error) {
 	var client *http.Client
 
 	if oc.target == "" {
@@ -102,15 +153,26 @@ func (oc OAuthClient) Do(request *http.Request) (*http.Response, error) {
 	oc.oauthConfigCC.TokenURL = targetURL.String()
 	oc.oauthConfig.Endpoint.TokenURL = targetURL.String()
 
+	var tokenSource oauth2.TokenSource
 	if oc.oauthConfigCC.ClientID != "" {
 		client = oc.oauthConfigCC.Client(oc.context)
 	} else {
-		token, err := retrieveTokenWithRetry(oc.oauthConfig, oc.context, oc.username, oc.password, oc.timeout)
+		token, err := oc.ssoToken(targetURL)
 		if err != nil {
 			return nil, err
 		}
 
-		client = oc.oauthConfig.Client(oc.context, token)
+		if token != nil {
+			tokenSource = oc.oauthConfig.TokenSource(oc.context, token)
+			client = oauth2.NewClient(oc.context, tokenSource)
+		} else {
+			token, err = retrieveTokenWithRetry(oc.oauthConfig, oc.context, oc.username, oc.password, oc.timeout)
+			if err != nil {
+				return nil, err
+			}
+
+			client = oc.oauthConfig.Client(oc.context, token)
+		}
 	}
 
 	client.Timeout = oc.timeout
@@ -122,12 +184,33 @@ func (oc OAuthClient) Do(request *http.Request) (*http.Response, error) {
 	request.URL.Scheme = targetURL.Scheme
 	request.URL.Host = targetURL.Host
 
+	var resp *http.Response
 	// we only want to retry non-modifying actions
 	if request.Method == "GET" {
-		return httpResponseWithRetry(client, request)
+		resp, err = httpResponseWithRetry(client, request)
+	} else {
+		resp, err = client.Do(request)
+	}
+
+	if tokenSource != nil {
+		updateErr := oc.sso.update(targetURL, tokenSource)
+		if updateErr != nil && err == nil {
+			resp.Body.Close()
+			return nil, updateErr
+		}
 	}
 
-	return client.Do(request)
+	return resp, err
+}
+
+// ssoToken returns the token of the SSO session, or nil when the client
+// should log in with the username and password instead.
+func (oc OAuthClient) ssoToken(targetURL *url.URL) (*oauth2.Token, error) {
+	if oc.sso == nil || (oc.sso.passcode == nil && oc.username != "") {
+		return nil, nil
+	}
+
+	return oc.sso.currentToken(oc.context, targetURL, oc.oauthConfig.Endpoint.TokenURL)
 }
 
 func retrieveTokenWithRetry(config *oauth2.Config, ctx context.Context, username, password string, timeout time.Duration) (*oauth2.Token, error) {
@@ -140,12 +223,22 @@ retry:
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("token could not be retrieved from target url: %s", err)
+		return nil, tokenRetrievalError{err: err}
 	}
 
 	return token, err
 }
 
+// tokenRetrievalError keeps the error of the token request, so that a locked
+// Ops Manager can be told apart from other failures.
+type tokenRetrievalError struct {
+	err error
+}
+
+func (e tokenRetrievalError) Error() string {
+	return fmt.Sprintf("token could not be retrieved from target url: %s", e.err)
+}
+
 func httpResponseWithRetry(client *http.Client, request *http.Request) (*http.Response, error) {
 retry:
 	resp, err := client.Do(request)
@@ -3,13 +3,18 @@ package network_test
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/pivotal-cf/om/network"
+	"golang.org/x/oauth2"
 
 	"time"
 
@@ -61,7 +66,7 @@ var _ = Describe("OAuthClient", func() {
 
 	Describe("Do", func() {
 		It("makes a request with authentication", func() {
-			client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second)
+			client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(callCount).To(Equal(0))
@@ -95,7 +100,7 @@ var _ = Describe("OAuthClient", func() {
 		})
 
 		It("makes a request with client credentials", func() {
-			client, err := network.NewOAuthClient(server.URL, "", "", "client_id", "client_secret", true, false, time.Duration(30)*time.Second)
+			client, err := network.NewOAuthClient(server.URL, "", "", "client_id", "client_secret", true, false, time.Duration(30)*time.Second, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(callCount).To(Equal(0))
@@ -134,7 +139,7 @@ var _ = Describe("OAuthClient", func() {
 				noScheme.Scheme = ""
 				finalURL := noScheme.String()
 
-				client, err := network.NewOAuthClient(finalURL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second)
+				client, err := network.NewOAuthClient(finalURL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -150,7 +155,7 @@ var _ = Describe("OAuthClient", func() {
 		Context("when insecureSkipVerify is configured", func() {
 			Context("when it is set to false", func() {
 				It("throws an error for invalid certificates", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", false, false, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", false, false, time.Duration(30)*time.Second, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -163,7 +168,7 @@ var _ = Describe("OAuthClient", func() {
 
 			Context("when it is set to true", func() {
 				It("does not verify certificates", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -178,7 +183,7 @@ var _ = Describe("OAuthClient", func() {
 		Context("when includeCookies is configured", func() {
 			Context("when it is set to true", func() {
 				It("has a cookie jar", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, true, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, true, time.Duration(30)*time.Second, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -200,7 +205,7 @@ var _ = Describe("OAuthClient", func() {
 
 			Context("when it is false", func() {
 				It("does not collect any of the cookies", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -220,6 +225,326 @@ var _ = Describe("OAuthClient", func() {
 			})
 		})
 
+		Context("when a decryption passphrase is given", func() {
+			var (
+				lockedServer       *httptest.Server
+				locked             bool
+				passphrases        []string
+				apiBodies          []string
+				availabilityChecks int
+				unlockedStatus     int
+			)
+
+			BeforeEach(func() {
+				locked = true
+				passphrases = nil
+				apiBodies = nil
+				availabilityChecks = 0
+				unlockedStatus = 0
+
+				lockedServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					switch req.URL.Path {
+					case "/login/ensure_availability":
+						availabilityChecks++
+						switch {
+						case locked:
+							http.Redirect(w, req, "/unlock", http.StatusFound)
+						case unlockedStatus != 0:
+							w.WriteHeader(unlockedStatus)
+						default:
+							http.Redirect(w, req, "/auth/cloudfoundry", http.StatusFound)
+						}
+					case "/api/v0/unlock":
+						var body struct {
+							Passphrase string `json:"passphrase"`
+						}
+						Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+						passphrases = append(passphrases, body.Passphrase)
+
+						if body.Passphrase != "some-passphrase" {
+							w.WriteHeader(http.StatusForbidden)
+							return
+						}
+						locked = false
+					case "/uaa/oauth/token":
+						if locked {
+							w.WriteHeader(http.StatusServiceUnavailable)
+							return
+						}
+
+						w.Header().Set("Content-Type", "application/json")
+						w.Write([]byte(`{"access_token": "some-opsman-token", "token_type": "bearer", "expires_in": 3600}`))
+					case "/some/path":
+						if locked {
+							w.WriteHeader(http.StatusServiceUnavailable)
+							return
+						}
+
+						body, err := ioutil.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						apiBodies = append(apiBodies, string(body))
+
+						w.WriteHeader(http.StatusNoContent)
+					case "/some/gateway/error":
+						w.WriteHeader(http.StatusBadGateway)
+					}
+				}))
+			})
+
+			AfterEach(func() {
+				lockedServer.Close()
+			})
+
+			It("unlocks a locked Ops Manager and retries the request", func() {
+				client, err := network.NewOAuthClient(lockedServer.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "some-passphrase")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("POST", "/some/path", strings.NewReader("request-body"))
+				Expect(err).NotTo(HaveOccurred())
+
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(passphrases).To(Equal([]string{"some-passphrase"}))
+				Expect(apiBodies).To(Equal([]string{"request-body"}))
+			})
+
+			It("does not unlock when Ops Manager is not locked", func() {
+				locked = false
+
+				client, err := network.NewOAuthClient(lockedServer.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "some-passphrase")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(passphrases).To(BeEmpty())
+			})
+
+			It("does not check whether Ops Manager is locked when a request fails for another reason", func() {
+				locked = false
+
+				client, err := network.NewOAuthClient(lockedServer.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "some-passphrase")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/gateway/error", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+
+				Expect(availabilityChecks).To(Equal(0))
+			})
+
+			It("returns an error when the passphrase is incorrect", func() {
+				client, err := network.NewOAuthClient(lockedServer.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "wrong-passphrase")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.Do(req)
+				Expect(err).To(MatchError("could not unlock Ops Manager: the decryption passphrase is incorrect"))
+			})
+
+			It("returns an error straight away when the availability check fails after unlocking", func() {
+				unlockedStatus = http.StatusInternalServerError
+
+				client, err := network.NewOAuthClient(lockedServer.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "some-passphrase")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.Do(req)
+				Expect(err).To(MatchError(ContainSubstring("Ops Manager was unlocked, but its availability could not be determined: unexpected response 500 Internal Server Error")))
+				Expect(availabilityChecks).To(Equal(2))
+			})
+
+			It("does not unlock without a passphrase", func() {
+				client, err := network.NewOAuthClient(lockedServer.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.Do(req)
+				Expect(err).To(MatchError(ContainSubstring("token could not be retrieved from target url")))
+				Expect(passphrases).To(BeEmpty())
+			})
+		})
+
+		Context("when an sso session is given", func() {
+			var (
+				store        network.TokenStore
+				storeDir     string
+				passcodeURLs []string
+				passcode     network.PasscodeSource
+			)
+
+			BeforeEach(func() {
+				var err error
+				storeDir, err = ioutil.TempDir("", "om-tokens")
+				Expect(err).NotTo(HaveOccurred())
+
+				store = network.NewTokenStore(filepath.Join(storeDir, ".om", "tokens.json"))
+
+				passcodeURLs = nil
+				passcode = func(passcodeURL string) (string, error) {
+					passcodeURLs = append(passcodeURLs, passcodeURL)
+					return "some-passcode\n", nil
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(storeDir)
+			})
+
+			It("exchanges a passcode for a token and stores it", func() {
+				session := network.NewSSOSession(store, passcode)
+
+				client, err := network.NewOAuthClient(server.URL, "", "", "", "", true, false, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+				cookieClient, err := network.NewOAuthClient(server.URL, "", "", "", "", true, true, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, c := range []network.OAuthClient{client.WithSSOSession(session), cookieClient.WithSSOSession(session)} {
+					req, err := http.NewRequest("GET", "/some/path", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					resp, err := c.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+				}
+
+				Expect(authHeader).To(Equal("Bearer some-opsman-token"))
+				Expect(passcodeURLs).To(Equal([]string{server.URL + "/uaa/passcode"}))
+				Expect(callCount).To(Equal(1))
+
+				req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(receivedRequest)))
+				Expect(err).NotTo(HaveOccurred())
+
+				username, password, ok := req.BasicAuth()
+				Expect(ok).To(BeTrue())
+				Expect(username).To(Equal("opsman"))
+				Expect(password).To(BeEmpty())
+
+				Expect(req.ParseForm()).To(Succeed())
+				Expect(req.Form).To(Equal(url.Values{
+					"grant_type": []string{"password"},
+					"passcode":   []string{"some-passcode"},
+				}))
+
+				serverURL, err := url.Parse(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := store.Load(serverURL.Host)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.AccessToken).To(Equal("some-opsman-token"))
+			})
+
+			It("reuses a stored token", func() {
+				serverURL, err := url.Parse(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = store.Save(serverURL.Host, &oauth2.Token{
+					AccessToken:  "some-stored-token",
+					TokenType:    "bearer",
+					RefreshToken: "some-refresh-token",
+					Expiry:       time.Now().Add(time.Hour),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				client, err := network.NewOAuthClient(server.URL, "", "", "", "", true, false, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.WithSSOSession(network.NewSSOSession(store, nil)).Do(req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(authHeader).To(Equal("Bearer some-stored-token"))
+				Expect(callCount).To(Equal(0))
+			})
+
+			It("refreshes an expired stored token and stores the new one", func() {
+				serverURL, err := url.Parse(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = store.Save(serverURL.Host, &oauth2.Token{
+					AccessToken:  "some-stored-token",
+					TokenType:    "bearer",
+					RefreshToken: "some-refresh-token",
+					Expiry:       time.Now().Add(-time.Hour),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				client, err := network.NewOAuthClient(server.URL, "", "", "", "", true, false, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.WithSSOSession(network.NewSSOSession(store, nil)).Do(req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(authHeader).To(Equal("Bearer some-opsman-token"))
+
+				req, err = http.ReadRequest(bufio.NewReader(bytes.NewReader(receivedRequest)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.ParseForm()).To(Succeed())
+				Expect(req.Form.Get("grant_type")).To(Equal("refresh_token"))
+
+				token, err := store.Load(serverURL.Host)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.AccessToken).To(Equal("some-opsman-token"))
+			})
+
+			It("logs in with the username and password instead of a stored token", func() {
+				serverURL, err := url.Parse(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = store.Save(serverURL.Host, &oauth2.Token{AccessToken: "some-stored-token", Expiry: time.Now().Add(time.Hour)})
+				Expect(err).NotTo(HaveOccurred())
+
+				client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.WithSSOSession(network.NewSSOSession(store, nil)).Do(req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(authHeader).To(Equal("Bearer some-opsman-token"))
+				Expect(callCount).To(Equal(1))
+			})
+
+			It("returns an error when the passcode is rejected", func() {
+				rejectingServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte(`{"error": "unauthorized"}`))
+				}))
+				defer rejectingServer.Close()
+
+				client, err := network.NewOAuthClient(rejectingServer.URL, "", "", "", "", true, false, time.Duration(30)*time.Second, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.WithSSOSession(network.NewSSOSession(store, passcode)).Do(req)
+				Expect(err).To(MatchError(`token could not be retrieved with the passcode: 401 Unauthorized: {"error": "unauthorized"}`))
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when the initial token cannot be retrieved", func() {
 				var badServer *httptest.Server
@@ -231,7 +556,7 @@ var _ = Describe("OAuthClient", func() {
 				})
 
 				It("returns an error", func() {
-					client, err := network.NewOAuthClient(badServer.URL, "username", "password", "", "", true, false, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient(badServer.URL, "username", "password", "", "", true, false, time.Duration(30)*time.Second, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -244,7 +569,7 @@ var _ = Describe("OAuthClient", func() {
 
 			Context("when the target url is empty", func() {
 				It("returns an error", func() {
-					client, err := network.NewOAuthClient("", "username", "password", "", "", false, false, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient("", "username", "password", "", "", false, false, time.Duration(30)*time.Second, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
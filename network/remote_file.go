@@ -0,0 +1,172 @@
+package network
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// remoteFileChunkSize is the minimum number of bytes fetched by a ranged
+// read, so that small sequential reads do not each become a request.
+const remoteFileChunkSize = 1024 * 1024
+
+// RemoteFile reads a file served over HTTP(S) without downloading it, using
+// ranged requests for random access.
+type RemoteFile struct {
+	URL  string
+	Size int64
+
+	client      *http.Client
+	chunk       []byte
+	chunkOffset int64
+}
+
+// IsURL reports whether the path refers to a file served over HTTP(S) rather
+// than a local file.
+func IsURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// NewRemoteFileClient returns the client used to read remote files. The
+// request timeout only bounds the wait for response headers, as a whole file
+// streamed by Open may take much longer to read.
+func NewRemoteFileClient(insecureSkipVerify bool, requestTimeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: insecureSkipVerify,
+			},
+			Dial: (&net.Dialer{
+				Timeout:   5 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).Dial,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: requestTimeout,
+		},
+	}
+}
+
+// OpenRemoteFile determines the size of the file at the url. The size is
+// requested with a one byte ranged GET rather than a HEAD, as pre-signed urls
+// are usually only valid for GET requests.
+func OpenRemoteFile(client *http.Client, url string) (*RemoteFile, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not request %s: %s", url, err)
+	}
+	defer resp.Body.Close()
+
+	var size int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		size, err = strconv.ParseInt(contentRange[strings.LastIndex(contentRange, "/")+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine the size of %s from Content-Range %q", url, contentRange)
+		}
+	case http.StatusOK:
+		size = resp.ContentLength
+	default:
+		return nil, fmt.Errorf("could not request %s: unexpected status %s", url, resp.Status)
+	}
+
+	if size < 0 {
+		return nil, fmt.Errorf("could not determine the size of %s: no Content-Length was provided", url)
+	}
+
+	return &RemoteFile{
+		URL:    url,
+		Size:   size,
+		client: client,
+	}, nil
+}
+
+// ReadAt implements io.ReaderAt. Reads are served from the most recently
+// fetched chunk when possible.
+func (f *RemoteFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= f.Size {
+		return 0, io.EOF
+	}
+
+	end := off + int64(len(p))
+	if off < f.chunkOffset || end > f.chunkOffset+int64(len(f.chunk)) {
+		err := f.fetchChunk(off, int64(len(p)))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, f.chunk[off-f.chunkOffset:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Open streams the whole file.
+func (f *RemoteFile) Open() (io.ReadCloser, error) {
+	resp, err := f.client.Get(f.URL)
+	if err != nil {
+		return nil, fmt.Errorf("could not request %s: %s", f.URL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("could not request %s: unexpected status %s", f.URL, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+func (f *RemoteFile) fetchChunk(off, length int64) error {
+	if length < remoteFileChunkSize {
+		length = remoteFileChunkSize
+	}
+
+	end := off + length - 1
+	if end >= f.Size {
+		end = f.Size - 1
+	}
+
+	req, err := http.NewRequest("GET", f.URL, nil)
+	if err != nil {
+		return err // un-tested
+	}
+
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, end))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not request %s: %s", f.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("could not read %s: the server does not support ranged requests (status %s)", f.URL, resp.Status)
+	}
+
+	chunk := make([]byte, end-off+1)
+	_, err = io.ReadFull(resp.Body, chunk)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %s", f.URL, err)
+	}
+
+	f.chunk = chunk
+	f.chunkOffset = off
+
+	return nil
+}
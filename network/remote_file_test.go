@@ -0,0 +1,183 @@
+package network_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/pivotal-cf/om/network"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteFile", func() {
+	var (
+		server   *httptest.Server
+		client   *http.Client
+		ranges   []string
+		contents []byte
+	)
+
+	BeforeEach(func() {
+		client = network.NewRemoteFileClient(false, 5*time.Second)
+		ranges = nil
+		contents = bytes.Repeat([]byte("0123456789"), 300*1024)
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ranges = append(ranges, req.Header.Get("Range"))
+			http.ServeContent(w, req, "some-file", time.Time{}, bytes.NewReader(contents))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("IsURL", func() {
+		It("recognises http and https urls", func() {
+			Expect(network.IsURL("https://example.com/some.pivotal")).To(BeTrue())
+			Expect(network.IsURL("http://example.com/some.pivotal")).To(BeTrue())
+			Expect(network.IsURL("/tmp/some.pivotal")).To(BeFalse())
+			Expect(network.IsURL("some-http.pivotal")).To(BeFalse())
+		})
+	})
+
+	Describe("OpenRemoteFile", func() {
+		It("determines the size with a ranged request", func() {
+			remoteFile, err := network.OpenRemoteFile(client, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remoteFile.Size).To(Equal(int64(len(contents))))
+			Expect(ranges).To(Equal([]string{"bytes=0-0"}))
+		})
+
+		Context("when the server does not support ranged requests", func() {
+			It("uses the Content-Length of the response", func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.Header().Set("Content-Length", "12")
+					w.Write([]byte("some content"))
+				})
+
+				remoteFile, err := network.OpenRemoteFile(client, server.URL)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remoteFile.Size).To(Equal(int64(12)))
+			})
+		})
+
+		Context("when the server responds with an error", func() {
+			It("returns an error", func() {
+				server.Config.Handler = http.NotFoundHandler()
+
+				_, err := network.OpenRemoteFile(client, server.URL)
+				Expect(err).To(MatchError(ContainSubstring("unexpected status 404 Not Found")))
+			})
+		})
+
+		Context("when the server has a self-signed certificate", func() {
+			var tlsServer *httptest.Server
+
+			BeforeEach(func() {
+				tlsServer = httptest.NewTLSServer(server.Config.Handler)
+			})
+
+			AfterEach(func() {
+				tlsServer.Close()
+			})
+
+			It("returns an error unless ssl validation is skipped", func() {
+				_, err := network.OpenRemoteFile(client, tlsServer.URL)
+				Expect(err).To(MatchError(ContainSubstring("certificate")))
+
+				remoteFile, err := network.OpenRemoteFile(network.NewRemoteFileClient(true, 5*time.Second), tlsServer.URL)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remoteFile.Size).To(Equal(int64(len(contents))))
+			})
+		})
+
+		Context("when the server does not respond within the request timeout", func() {
+			It("returns an error", func() {
+				done := make(chan struct{})
+				defer close(done)
+
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					<-done
+				})
+
+				_, err := network.OpenRemoteFile(network.NewRemoteFileClient(false, 100*time.Millisecond), server.URL)
+				Expect(err).To(MatchError(ContainSubstring("timeout awaiting response headers")))
+			})
+		})
+	})
+
+	Describe("ReadAt", func() {
+		It("reads ranges of the file, fetching at least a chunk at a time", func() {
+			remoteFile, err := network.OpenRemoteFile(client, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			buffer := make([]byte, 5)
+			n, err := remoteFile.ReadAt(buffer, 13)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(5))
+			Expect(string(buffer)).To(Equal("34567"))
+
+			n, err = remoteFile.ReadAt(buffer, 1000)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buffer[:n])).To(Equal("01234"))
+
+			Expect(ranges).To(Equal([]string{"bytes=0-0", "bytes=13-1048588"}))
+		})
+
+		It("returns io.EOF when reading past the end of the file", func() {
+			remoteFile, err := network.OpenRemoteFile(client, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			buffer := make([]byte, 10)
+			n, err := remoteFile.ReadAt(buffer, remoteFile.Size-4)
+			Expect(err).To(MatchError("EOF"))
+			Expect(string(buffer[:n])).To(Equal("6789"))
+		})
+
+		Context("when the server does not support ranged requests", func() {
+			It("returns an error", func() {
+				remoteFile, err := network.OpenRemoteFile(client, server.URL)
+				Expect(err).NotTo(HaveOccurred())
+
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.Write(contents)
+				})
+
+				_, err = remoteFile.ReadAt(make([]byte, 5), 10)
+				Expect(err).To(MatchError(ContainSubstring("the server does not support ranged requests")))
+			})
+		})
+	})
+
+	Describe("Open", func() {
+		It("streams the whole file", func() {
+			remoteFile, err := network.OpenRemoteFile(client, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			body, err := remoteFile.Open()
+			Expect(err).NotTo(HaveOccurred())
+			defer body.Close()
+
+			read, err := ioutil.ReadAll(body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read).To(Equal(contents))
+		})
+
+		It("returns an error when the file is no longer available", func() {
+			remoteFile, err := network.OpenRemoteFile(client, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			server.Config.Handler = http.NotFoundHandler()
+
+			_, err = remoteFile.Open()
+			Expect(err).To(MatchError(fmt.Sprintf("could not request %s: unexpected status 404 Not Found", server.URL)))
+		})
+	})
+})
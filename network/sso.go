@@ -0,0 +1,223 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// PasscodeSource returns a one-time passcode, which the user gets by logging
+// in at the given /uaa/passcode url.
+type PasscodeSource func(passcodeURL string) (string, error)
+
+// TokenStore keeps the tokens of each Ops Manager in a file, so that a login
+// with a passcode is reused by later commands.
+type TokenStore struct {
+	path string
+}
+
+func NewTokenStore(path string) TokenStore {
+	return TokenStore{path: path}
+}
+
+// Load returns the stored token of the target, or nil when there is none.
+func (s TokenStore) Load(target string) (*oauth2.Token, error) {
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens[target], nil
+}
+
+func (s TokenStore) Save(target string, token *oauth2.Token) error {
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	tokens[target] = token
+
+	contents, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err // un-tested
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.path), 0700)
+	if err != nil {
+		return fmt.Errorf("could not store token: %s", err)
+	}
+
+	err = ioutil.WriteFile(s.path, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("could not store token: %s", err)
+	}
+
+	return nil
+}
+
+func (s TokenStore) read() (map[string]*oauth2.Token, error) {
+	tokens := map[string]*oauth2.Token{}
+
+	contents, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read stored tokens: %s", err)
+	}
+
+	err = json.Unmarshal(contents, &tokens)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse stored tokens in %s: %s", s.path, err)
+	}
+
+	return tokens, nil
+}
+
+// SSOSession authenticates with a token from a one-time passcode, or with a
+// token stored by an earlier command. It is shared by the clients of a
+// command, so that the user is asked for a passcode at most once.
+type SSOSession struct {
+	store    TokenStore
+	passcode PasscodeSource
+
+	mutex  sync.Mutex
+	loaded bool
+	token  *oauth2.Token
+}
+
+// NewSSOSession returns a session that asks for a passcode when passcode is
+// not nil, and otherwise reuses the token stored for the target, if any.
+func NewSSOSession(store TokenStore, passcode PasscodeSource) *SSOSession {
+	return &SSOSession{
+		store:    store,
+		passcode: passcode,
+	}
+}
+
+func (s *SSOSession) currentToken(ctx context.Context, target *url.URL, tokenURL string) (*oauth2.Token, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.loaded {
+		return s.token, nil
+	}
+
+	if s.passcode == nil {
+		token, err := s.store.Load(target.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		s.token, s.loaded = token, true
+		return s.token, nil
+	}
+
+	passcodeURL := *target
+	passcodeURL.Path = "/uaa/passcode"
+
+	passcode, err := s.passcode(passcodeURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not read passcode: %s", err)
+	}
+
+	token, err := retrievePasscodeToken(ctx, tokenURL, strings.TrimSpace(passcode))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.Save(target.Host, token)
+	if err != nil {
+		return nil, err
+	}
+
+	s.token, s.loaded = token, true
+	return s.token, nil
+}
+
+// update stores the token when it has been refreshed. A token that could not
+// be refreshed has already failed the request, so it is not reported again.
+func (s *SSOSession) update(target *url.URL, tokenSource oauth2.TokenSource) error {
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.token != nil && s.token.AccessToken == token.AccessToken {
+		return nil
+	}
+
+	s.token = token
+	return s.store.Save(target.Host, token)
+}
+
+// retrievePasscodeToken exchanges a one-time passcode for a token with the
+// uaa passcode grant.
+func retrievePasscodeToken(ctx context.Context, tokenURL, passcode string) (*oauth2.Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("passcode", passcode)
+
+	request, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err // un-tested
+	}
+
+	request.SetBasicAuth("opsman", "")
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client)
+	if !ok {
+		client = http.DefaultClient // un-tested
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("token could not be retrieved from target url: %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(response.Body)
+		return nil, fmt.Errorf("token could not be retrieved with the passcode: %s: %s", response.Status, body)
+	}
+
+	var output struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse token response: %s", err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  output.AccessToken,
+		TokenType:    output.TokenType,
+		RefreshToken: output.RefreshToken,
+	}
+
+	if output.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(output.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
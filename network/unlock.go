@@ -0,0 +1,141 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	availabilityLocked   = "locked"
+	availabilityPending  = "pending"
+	availabilityComplete = "complete"
+)
+
+// lockedResponse reports whether a response may have failed because Ops
+// Manager is locked: its authentication system is unavailable, so the token
+// cannot be retrieved or the api responds with a 503, or requests are sent to
+// the unlock page. Other errors, such as a target that cannot be reached, are
+// not treated as locked.
+func lockedResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return unavailableTokenError(err)
+	}
+
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+
+	return resp.Request != nil && resp.Request.URL.Path == "/unlock"
+}
+
+// unavailableTokenError reports whether the token could not be retrieved
+// because the authentication system responded with a 503.
+func unavailableTokenError(err error) bool {
+	switch e := err.(type) {
+	case *url.Error:
+		return unavailableTokenError(e.Err)
+	case tokenRetrievalError:
+		return unavailableTokenError(e.err)
+	case *oauth2.RetrieveError:
+		return e.Response != nil && e.Response.StatusCode == http.StatusServiceUnavailable
+	}
+
+	return false
+}
+
+// unlock sends the decryption passphrase when Ops Manager is locked, and
+// waits for its authentication system to start. It returns false when Ops
+// Manager was not locked.
+func (oc OAuthClient) unlock() (bool, error) {
+	status, err := oc.availability()
+	if err != nil || status != availabilityLocked {
+		return false, nil
+	}
+
+	payload, err := json.Marshal(map[string]string{"passphrase": oc.decryptionPassphrase})
+	if err != nil {
+		return false, err // un-tested
+	}
+
+	request, err := http.NewRequest("PUT", "/api/v0/unlock", bytes.NewReader(payload))
+	if err != nil {
+		return false, err // un-tested
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := oc.unauthedClient.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("could not unlock Ops Manager: %s", err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return false, fmt.Errorf("could not unlock Ops Manager: the decryption passphrase is incorrect")
+	default:
+		return false, fmt.Errorf("could not unlock Ops Manager: unexpected response %s", resp.Status)
+	}
+
+	deadline := time.Now().Add(oc.timeout)
+	for {
+		status, err = oc.availability()
+		if err != nil {
+			return false, fmt.Errorf("Ops Manager was unlocked, but its availability could not be determined: %s", err)
+		}
+
+		if status == availabilityComplete {
+			return true, nil
+		}
+
+		if time.Now().After(deadline) {
+			return false, fmt.Errorf("Ops Manager was unlocked, but its authentication system did not start within %s", oc.timeout)
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// availability reports the state of the authentication system, the same way
+// as api.EnsureAvailability does. Responses other than a 200 while it starts or
+// a redirect are returned as errors, so that unlock does not wait on them.
+func (oc OAuthClient) availability() (string, error) {
+	request, err := http.NewRequest("GET", "/login/ensure_availability", nil)
+	if err != nil {
+		return "", err // un-tested
+	}
+
+	resp, err := oc.unauthedClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return availabilityPending, nil
+	case http.StatusFound:
+	default:
+		return "", fmt.Errorf("unexpected response %s", resp.Status)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", err
+	}
+
+	switch location.Path {
+	case "/unlock":
+		return availabilityLocked, nil
+	case "/auth/cloudfoundry":
+		return availabilityComplete, nil
+	default:
+		return availabilityPending, nil
+	}
+}
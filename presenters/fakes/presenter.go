@@ -55,11 +55,41 @@ type Presenter struct {
 	presentPendingChangesArgsForCall []struct {
 		arg1 []api.ProductChange
 	}
+	PresentPendingChangesDetailStub        func([]models.PendingChangeDetail)
+	presentPendingChangesDetailMutex       sync.RWMutex
+	presentPendingChangesDetailArgsForCall []struct {
+		arg1 []models.PendingChangeDetail
+	}
 	PresentStagedProductsStub        func([]api.DiagnosticProduct)
 	presentStagedProductsMutex       sync.RWMutex
 	presentStagedProductsArgsForCall []struct {
 		arg1 []api.DiagnosticProduct
 	}
+	PresentStemcellAssignmentsStub        func([]api.ProductStemcells)
+	presentStemcellAssignmentsMutex       sync.RWMutex
+	presentStemcellAssignmentsArgsForCall []struct {
+		arg1 []api.ProductStemcells
+	}
+	PresentUAAClientsStub        func([]api.UAAClient)
+	presentUAAClientsMutex       sync.RWMutex
+	presentUAAClientsArgsForCall []struct {
+		arg1 []api.UAAClient
+	}
+	PresentUpgradePlanStub        func([]models.UpgradeStep)
+	presentUpgradePlanMutex       sync.RWMutex
+	presentUpgradePlanArgsForCall []struct {
+		arg1 []models.UpgradeStep
+	}
+	PresentVMExtensionsStub        func([]api.VMExtension)
+	presentVMExtensionsMutex       sync.RWMutex
+	presentVMExtensionsArgsForCall []struct {
+		arg1 []api.VMExtension
+	}
+	PresentVMTypesStub        func([]api.VMType)
+	presentVMTypesMutex       sync.RWMutex
+	presentVMTypesArgsForCall []struct {
+		arg1 []api.VMType
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -315,6 +345,35 @@ func (fake *Presenter) PresentPendingChangesArgsForCall(i int) []api.ProductChan
 	return fake.presentPendingChangesArgsForCall[i].arg1
 }
 
+func (fake *Presenter) PresentPendingChangesDetail(arg1 []models.PendingChangeDetail) {
+	var arg1Copy []models.PendingChangeDetail
+	if arg1 != nil {
+		arg1Copy = make([]models.PendingChangeDetail, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentPendingChangesDetailMutex.Lock()
+	fake.presentPendingChangesDetailArgsForCall = append(fake.presentPendingChangesDetailArgsForCall, struct {
+		arg1 []models.PendingChangeDetail
+	}{arg1Copy})
+	fake.recordInvocation("PresentPendingChangesDetail", []interface{}{arg1Copy})
+	fake.presentPendingChangesDetailMutex.Unlock()
+	if fake.PresentPendingChangesDetailStub != nil {
+		fake.PresentPendingChangesDetailStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentPendingChangesDetailCallCount() int {
+	fake.presentPendingChangesDetailMutex.RLock()
+	defer fake.presentPendingChangesDetailMutex.RUnlock()
+	return len(fake.presentPendingChangesDetailArgsForCall)
+}
+
+func (fake *Presenter) PresentPendingChangesDetailArgsForCall(i int) []models.PendingChangeDetail {
+	fake.presentPendingChangesDetailMutex.RLock()
+	defer fake.presentPendingChangesDetailMutex.RUnlock()
+	return fake.presentPendingChangesDetailArgsForCall[i].arg1
+}
+
 func (fake *Presenter) PresentStagedProducts(arg1 []api.DiagnosticProduct) {
 	var arg1Copy []api.DiagnosticProduct
 	if arg1 != nil {
@@ -344,6 +403,151 @@ func (fake *Presenter) PresentStagedProductsArgsForCall(i int) []api.DiagnosticP
 	return fake.presentStagedProductsArgsForCall[i].arg1
 }
 
+func (fake *Presenter) PresentStemcellAssignments(arg1 []api.ProductStemcells) {
+	var arg1Copy []api.ProductStemcells
+	if arg1 != nil {
+		arg1Copy = make([]api.ProductStemcells, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentStemcellAssignmentsMutex.Lock()
+	fake.presentStemcellAssignmentsArgsForCall = append(fake.presentStemcellAssignmentsArgsForCall, struct {
+		arg1 []api.ProductStemcells
+	}{arg1Copy})
+	fake.recordInvocation("PresentStemcellAssignments", []interface{}{arg1Copy})
+	fake.presentStemcellAssignmentsMutex.Unlock()
+	if fake.PresentStemcellAssignmentsStub != nil {
+		fake.PresentStemcellAssignmentsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentStemcellAssignmentsCallCount() int {
+	fake.presentStemcellAssignmentsMutex.RLock()
+	defer fake.presentStemcellAssignmentsMutex.RUnlock()
+	return len(fake.presentStemcellAssignmentsArgsForCall)
+}
+
+func (fake *Presenter) PresentStemcellAssignmentsArgsForCall(i int) []api.ProductStemcells {
+	fake.presentStemcellAssignmentsMutex.RLock()
+	defer fake.presentStemcellAssignmentsMutex.RUnlock()
+	return fake.presentStemcellAssignmentsArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentUAAClients(arg1 []api.UAAClient) {
+	var arg1Copy []api.UAAClient
+	if arg1 != nil {
+		arg1Copy = make([]api.UAAClient, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentUAAClientsMutex.Lock()
+	fake.presentUAAClientsArgsForCall = append(fake.presentUAAClientsArgsForCall, struct {
+		arg1 []api.UAAClient
+	}{arg1Copy})
+	fake.recordInvocation("PresentUAAClients", []interface{}{arg1Copy})
+	fake.presentUAAClientsMutex.Unlock()
+	if fake.PresentUAAClientsStub != nil {
+		fake.PresentUAAClientsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentUAAClientsCallCount() int {
+	fake.presentUAAClientsMutex.RLock()
+	defer fake.presentUAAClientsMutex.RUnlock()
+	return len(fake.presentUAAClientsArgsForCall)
+}
+
+func (fake *Presenter) PresentUAAClientsArgsForCall(i int) []api.UAAClient {
+	fake.presentUAAClientsMutex.RLock()
+	defer fake.presentUAAClientsMutex.RUnlock()
+	return fake.presentUAAClientsArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentUpgradePlan(arg1 []models.UpgradeStep) {
+	var arg1Copy []models.UpgradeStep
+	if arg1 != nil {
+		arg1Copy = make([]models.UpgradeStep, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentUpgradePlanMutex.Lock()
+	fake.presentUpgradePlanArgsForCall = append(fake.presentUpgradePlanArgsForCall, struct {
+		arg1 []models.UpgradeStep
+	}{arg1Copy})
+	fake.recordInvocation("PresentUpgradePlan", []interface{}{arg1Copy})
+	fake.presentUpgradePlanMutex.Unlock()
+	if fake.PresentUpgradePlanStub != nil {
+		fake.PresentUpgradePlanStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentUpgradePlanCallCount() int {
+	fake.presentUpgradePlanMutex.RLock()
+	defer fake.presentUpgradePlanMutex.RUnlock()
+	return len(fake.presentUpgradePlanArgsForCall)
+}
+
+func (fake *Presenter) PresentUpgradePlanArgsForCall(i int) []models.UpgradeStep {
+	fake.presentUpgradePlanMutex.RLock()
+	defer fake.presentUpgradePlanMutex.RUnlock()
+	return fake.presentUpgradePlanArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentVMExtensions(arg1 []api.VMExtension) {
+	var arg1Copy []api.VMExtension
+	if arg1 != nil {
+		arg1Copy = make([]api.VMExtension, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentVMExtensionsMutex.Lock()
+	fake.presentVMExtensionsArgsForCall = append(fake.presentVMExtensionsArgsForCall, struct {
+		arg1 []api.VMExtension
+	}{arg1Copy})
+	fake.recordInvocation("PresentVMExtensions", []interface{}{arg1Copy})
+	fake.presentVMExtensionsMutex.Unlock()
+	if fake.PresentVMExtensionsStub != nil {
+		fake.PresentVMExtensionsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentVMExtensionsCallCount() int {
+	fake.presentVMExtensionsMutex.RLock()
+	defer fake.presentVMExtensionsMutex.RUnlock()
+	return len(fake.presentVMExtensionsArgsForCall)
+}
+
+func (fake *Presenter) PresentVMExtensionsArgsForCall(i int) []api.VMExtension {
+	fake.presentVMExtensionsMutex.RLock()
+	defer fake.presentVMExtensionsMutex.RUnlock()
+	return fake.presentVMExtensionsArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentVMTypes(arg1 []api.VMType) {
+	var arg1Copy []api.VMType
+	if arg1 != nil {
+		arg1Copy = make([]api.VMType, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentVMTypesMutex.Lock()
+	fake.presentVMTypesArgsForCall = append(fake.presentVMTypesArgsForCall, struct {
+		arg1 []api.VMType
+	}{arg1Copy})
+	fake.recordInvocation("PresentVMTypes", []interface{}{arg1Copy})
+	fake.presentVMTypesMutex.Unlock()
+	if fake.PresentVMTypesStub != nil {
+		fake.PresentVMTypesStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentVMTypesCallCount() int {
+	fake.presentVMTypesMutex.RLock()
+	defer fake.presentVMTypesMutex.RUnlock()
+	return len(fake.presentVMTypesArgsForCall)
+}
+
+func (fake *Presenter) PresentVMTypesArgsForCall(i int) []api.VMType {
+	fake.presentVMTypesMutex.RLock()
+	defer fake.presentVMTypesMutex.RUnlock()
+	return fake.presentVMTypesArgsForCall[i].arg1
+}
+
 func (fake *Presenter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -365,8 +569,20 @@ func (fake *Presenter) Invocations() map[string][][]interface{} {
 	defer fake.presentInstallationsMutex.RUnlock()
 	fake.presentPendingChangesMutex.RLock()
 	defer fake.presentPendingChangesMutex.RUnlock()
+	fake.presentPendingChangesDetailMutex.RLock()
+	defer fake.presentPendingChangesDetailMutex.RUnlock()
 	fake.presentStagedProductsMutex.RLock()
 	defer fake.presentStagedProductsMutex.RUnlock()
+	fake.presentStemcellAssignmentsMutex.RLock()
+	defer fake.presentStemcellAssignmentsMutex.RUnlock()
+	fake.presentUAAClientsMutex.RLock()
+	defer fake.presentUAAClientsMutex.RUnlock()
+	fake.presentUpgradePlanMutex.RLock()
+	defer fake.presentUpgradePlanMutex.RUnlock()
+	fake.presentVMExtensionsMutex.RLock()
+	defer fake.presentVMExtensionsMutex.RUnlock()
+	fake.presentVMTypesMutex.RLock()
+	defer fake.presentVMTypesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
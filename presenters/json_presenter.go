@@ -54,10 +54,34 @@ func (j JSONPresenter) PresentPendingChanges(pendingChanges []api.ProductChange)
 	j.encodeJSON(pendingChanges)
 }
 
+func (j JSONPresenter) PresentPendingChangesDetail(pendingChanges []models.PendingChangeDetail) {
+	j.encodeJSON(pendingChanges)
+}
+
 func (j JSONPresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
 	j.encodeJSON(stagedProducts)
 }
 
+func (j JSONPresenter) PresentStemcellAssignments(products []api.ProductStemcells) {
+	j.encodeJSON(products)
+}
+
+func (j JSONPresenter) PresentUAAClients(clients []api.UAAClient) {
+	j.encodeJSON(clients)
+}
+
+func (j JSONPresenter) PresentUpgradePlan(steps []models.UpgradeStep) {
+	j.encodeJSON(steps)
+}
+
+func (j JSONPresenter) PresentVMExtensions(vmExtensions []api.VMExtension) {
+	j.encodeJSON(vmExtensions)
+}
+
+func (j JSONPresenter) PresentVMTypes(vmTypes []api.VMType) {
+	j.encodeJSON(vmTypes)
+}
+
 func (j JSONPresenter) encodeJSON(v interface{}) {
 	b, _ := json.MarshalIndent(&v, "", "  ")
 
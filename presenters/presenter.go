@@ -17,5 +17,11 @@ type Presenter interface {
 	PresentErrands([]models.Errand)
 	PresentInstallations([]models.Installation)
 	PresentPendingChanges([]api.ProductChange)
+	PresentPendingChangesDetail([]models.PendingChangeDetail)
 	PresentStagedProducts([]api.DiagnosticProduct)
+	PresentStemcellAssignments([]api.ProductStemcells)
+	PresentUAAClients([]api.UAAClient)
+	PresentUpgradePlan([]models.UpgradeStep)
+	PresentVMExtensions([]api.VMExtension)
+	PresentVMTypes([]api.VMType)
 }
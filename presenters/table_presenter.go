@@ -1,8 +1,10 @@
 package presenters
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
@@ -152,6 +154,28 @@ func (t TablePresenter) PresentPendingChanges(pendingChanges []api.ProductChange
 	t.tableWriter.Render()
 }
 
+func (t TablePresenter) PresentPendingChangesDetail(pendingChanges []models.PendingChangeDetail) {
+	t.tableWriter.SetHeader([]string{"PRODUCT", "ACTION", "VERSION", "STEMCELL", "ERRANDS"})
+
+	for _, change := range pendingChanges {
+		version := versionChange(change.DeployedVersion, change.StagedVersion)
+		stemcell := versionChange(change.DeployedStemcell, change.StagedStemcell)
+
+		if len(change.Errands) == 0 {
+			t.tableWriter.Append([]string{change.Product, change.Action, version, stemcell, ""})
+		}
+		for i, errand := range change.Errands {
+			if i == 0 {
+				t.tableWriter.Append([]string{change.Product, change.Action, version, stemcell, errand})
+			} else {
+				t.tableWriter.Append([]string{"", "", "", "", errand})
+			}
+		}
+	}
+
+	t.tableWriter.Render()
+}
+
 func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
 	t.tableWriter.SetHeader([]string{"Name", "Version"})
 
@@ -162,6 +186,93 @@ func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticPro
 	t.tableWriter.Render()
 }
 
+func (t TablePresenter) PresentStemcellAssignments(products []api.ProductStemcells) {
+	t.tableWriter.SetHeader([]string{"PRODUCT", "STAGED STEMCELL", "DEPLOYED STEMCELL", "REQUIRED STEMCELL", "AVAILABLE STEMCELLS"})
+
+	for _, product := range products {
+		required := product.RequiredStemcellVersion
+		if product.RequiredStemcellOS != "" {
+			required = fmt.Sprintf("%s/%s", product.RequiredStemcellOS, product.RequiredStemcellVersion)
+		}
+
+		t.tableWriter.Append([]string{
+			product.ProductName,
+			product.StagedStemcellVersion,
+			product.DeployedStemcellVersion,
+			required,
+			strings.Join(product.AvailableVersions, ", "),
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentUAAClients(clients []api.UAAClient) {
+	t.tableWriter.SetHeader([]string{"CLIENT ID", "GRANT TYPES", "SCOPE", "AUTHORITIES"})
+
+	for _, client := range clients {
+		t.tableWriter.Append([]string{
+			client.ClientID,
+			strings.Join(client.AuthorizedGrantTypes, ", "),
+			strings.Join(client.Scope, ", "),
+			strings.Join(client.Authorities, ", "),
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentUpgradePlan(steps []models.UpgradeStep) {
+	t.tableWriter.SetAutoWrapText(false)
+	t.tableWriter.SetHeader([]string{"ORDER", "PRODUCT", "VERSION", "STEMCELL", "REQUIRES", "NOTES"})
+
+	for _, step := range steps {
+		order := strconv.Itoa(step.Order)
+		if step.Blocked {
+			order = "blocked"
+		}
+
+		t.tableWriter.Append([]string{
+			order,
+			step.Product,
+			versionChange(step.CurrentVersion, step.TargetVersion),
+			step.Stemcell,
+			strings.Join(step.Requires, ", "),
+			strings.Join(step.Notes, "; "),
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentVMExtensions(vmExtensions []api.VMExtension) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetAutoWrapText(false)
+	t.tableWriter.SetHeader([]string{"Name", "Cloud Properties"})
+
+	for _, vmExtension := range vmExtensions {
+		t.tableWriter.Append([]string{vmExtension.Name, string(vmExtension.CloudProperties)})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentVMTypes(vmTypes []api.VMType) {
+	t.tableWriter.SetHeader([]string{"Name", "CPU", "RAM (MB)", "Ephemeral Disk (MB)", "Built In"})
+
+	for _, vmType := range vmTypes {
+		t.tableWriter.Append([]string{
+			vmType.Name,
+			strconv.Itoa(vmType.CPU),
+			strconv.Itoa(vmType.RAM),
+			strconv.Itoa(vmType.EphemeralDisk),
+			strconv.FormatBool(vmType.BuiltIn),
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
 func sortCredentialMap(cm map[string]string) ([]string, []string) {
 	var header []string
 	var credential []string
@@ -181,3 +292,15 @@ func sortCredentialMap(cm map[string]string) ([]string, []string) {
 
 	return header, credential
 }
+
+func versionChange(from, to string) string {
+	if from == "" || from == to {
+		return to
+	}
+
+	if to == "" {
+		return from
+	}
+
+	return fmt.Sprintf("%s -> %s", from, to)
+}
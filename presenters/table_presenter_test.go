@@ -1,6 +1,7 @@
 package presenters_test
 
 import (
+	"encoding/json"
 	"strconv"
 	"time"
 
@@ -265,6 +266,37 @@ var _ = Describe("TablePresenter", func() {
 		})
 	})
 
+	Describe("PresentPendingChangesDetail", func() {
+		It("creates a table with the version and stemcell changes", func() {
+			tablePresenter.PresentPendingChangesDetail([]models.PendingChangeDetail{
+				{
+					Product:          "some-product",
+					Action:           "update",
+					DeployedVersion:  "1.0.0",
+					StagedVersion:    "1.1.0",
+					DeployedStemcell: "ubuntu-xenial/97.18",
+					StagedStemcell:   "ubuntu-xenial/97.18",
+					Errands:          []string{"some-errand", "some-errand-2"},
+				},
+				{
+					Product:        "some-new-product",
+					Action:         "install",
+					StagedVersion:  "2.0.0",
+					StagedStemcell: "ubuntu-xenial/97.19",
+				},
+			})
+
+			Expect(fakeTableWriter.SetHeaderCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"PRODUCT", "ACTION", "VERSION", "STEMCELL", "ERRANDS"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(3))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"some-product", "update", "1.0.0 -> 1.1.0", "ubuntu-xenial/97.18", "some-errand"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"", "", "", "", "some-errand-2"}))
+			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"some-new-product", "install", "2.0.0", "ubuntu-xenial/97.19", ""}))
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
 	Describe("PresentStagedProducts", func() {
 		var stagedProducts []api.DiagnosticProduct
 		BeforeEach(func() {
@@ -360,4 +392,139 @@ var _ = Describe("TablePresenter", func() {
 			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
 		})
 	})
+
+	Describe("PresentStemcellAssignments", func() {
+		It("creates a table with the stemcells of each product", func() {
+			tablePresenter.PresentStemcellAssignments([]api.ProductStemcells{
+				{
+					ProductName:             "cf",
+					StagedStemcellVersion:   "97.19",
+					DeployedStemcellVersion: "97.18",
+					RequiredStemcellVersion: "97.18",
+					RequiredStemcellOS:      "ubuntu-xenial",
+					AvailableVersions:       []string{"97.18", "97.19"},
+				},
+				{
+					ProductName: "p-isolation-segment",
+				},
+			})
+
+			Expect(fakeTableWriter.SetHeaderCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"PRODUCT", "STAGED STEMCELL", "DEPLOYED STEMCELL", "REQUIRED STEMCELL", "AVAILABLE STEMCELLS"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"cf", "97.19", "97.18", "ubuntu-xenial/97.18", "97.18, 97.19"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"p-isolation-segment", "", "", "", ""}))
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentUAAClients", func() {
+		It("creates a table with the grants of each client", func() {
+			tablePresenter.PresentUAAClients([]api.UAAClient{
+				{
+					ClientID:             "pipeline",
+					Scope:                []string{"uaa.none"},
+					Authorities:          []string{"opsman.admin", "scim.read"},
+					AuthorizedGrantTypes: []string{"client_credentials"},
+				},
+				{
+					ClientID:             "opsman",
+					Scope:                []string{"opsman.admin", "opsman.restricted_view"},
+					AuthorizedGrantTypes: []string{"password", "refresh_token"},
+				},
+			})
+
+			Expect(fakeTableWriter.SetHeaderCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"CLIENT ID", "GRANT TYPES", "SCOPE", "AUTHORITIES"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"pipeline", "client_credentials", "uaa.none", "opsman.admin, scim.read"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"opsman", "password, refresh_token", "opsman.admin, opsman.restricted_view", ""}))
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentUpgradePlan", func() {
+		It("creates a table with a row for each upgrade", func() {
+			tablePresenter.PresentUpgradePlan([]models.UpgradeStep{
+				{
+					Order:          1,
+					Product:        "cf",
+					CurrentVersion: "2.1.7",
+					TargetVersion:  "2.2.4",
+					Stemcell:       "ubuntu-xenial 97.18",
+				},
+				{
+					Order:          2,
+					Product:        "p-redis",
+					CurrentVersion: "1.13.0",
+					TargetVersion:  "1.14.2",
+					Requires:       []string{"cf ~> 2.2", "p-bosh ~> 2.2"},
+					Notes:          []string{"upload the product first", "no uploaded stemcell meets its stemcell criteria"},
+				},
+				{
+					Product:        "p-mysql",
+					CurrentVersion: "2.2.5",
+					TargetVersion:  "2.4.0",
+					Blocked:        true,
+				},
+			})
+
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"ORDER", "PRODUCT", "VERSION", "STEMCELL", "REQUIRES", "NOTES"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(3))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"1", "cf", "2.1.7 -> 2.2.4", "ubuntu-xenial 97.18", "", ""}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"2", "p-redis", "1.13.0 -> 1.14.2", "", "cf ~> 2.2, p-bosh ~> 2.2", "upload the product first; no uploaded stemcell meets its stemcell criteria"}))
+			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"blocked", "p-mysql", "2.2.5 -> 2.4.0", "", "", ""}))
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentVMExtensions", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentVMExtensions([]api.VMExtension{
+				{Name: "some-vm-extension", CloudProperties: json.RawMessage(`{"iam_instance_profile":"some-iam-profile"}`)},
+				{Name: "some-other-vm-extension", CloudProperties: json.RawMessage(`{"elbs":["some-elb"]}`)},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetAutoWrapTextCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetAutoWrapTextArgsForCall(0)).To(BeFalse())
+
+			Expect(fakeTableWriter.SetHeaderCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "Cloud Properties"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"some-vm-extension", `{"iam_instance_profile":"some-iam-profile"}`}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"some-other-vm-extension", `{"elbs":["some-elb"]}`}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentVMTypes", func() {
+		var vmTypes []api.VMType
+
+		BeforeEach(func() {
+			vmTypes = []api.VMType{
+				{Name: "nano", CPU: 1, RAM: 512, EphemeralDisk: 1024, BuiltIn: true},
+				{Name: "memory-heavy", CPU: 8, RAM: 65536, EphemeralDisk: 32768, BuiltIn: false},
+			}
+		})
+
+		It("creates a table", func() {
+			tablePresenter.PresentVMTypes(vmTypes)
+
+			Expect(fakeTableWriter.SetHeaderCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "CPU", "RAM (MB)", "Ephemeral Disk (MB)", "Built In"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"nano", "1", "512", "1024", "true"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"memory-heavy", "8", "65536", "32768", "false"}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
 })